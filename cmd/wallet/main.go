@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"database/sql"
+	"flag"
 	"fmt"
 	"io"
 	"log"
@@ -10,39 +11,248 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/ximura/gowallet/api"
+	"github.com/ximura/gowallet/internal/auth"
+	"github.com/ximura/gowallet/internal/config"
 	grpcCtrl "github.com/ximura/gowallet/internal/controller/grpc"
+	"github.com/ximura/gowallet/internal/core/ports"
 	"github.com/ximura/gowallet/internal/core/server/grpc"
 	"github.com/ximura/gowallet/internal/core/service"
+	"github.com/ximura/gowallet/internal/fxrate"
+	"github.com/ximura/gowallet/internal/metrics"
+	"github.com/ximura/gowallet/internal/migration"
 	"github.com/ximura/gowallet/internal/repository"
+	"github.com/ximura/gowallet/internal/walletfeed"
 	googleGrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	connectString := "host=postgres port=5432 user=postgres password=postgres dbname=wallet sslmode=disable"
-	db, err := sql.Open("postgres", connectString)
+
+	if len(os.Args) > 1 {
+		commands := map[string]func(context.Context, []string) int{
+			"reconcile": reconcile,
+			"migrate":   migrate,
+		}
+		if command, ok := commands[os.Args[1]]; ok {
+			code := command(ctx, os.Args[2:])
+			cancel()
+			os.Exit(code)
+		}
+	}
+
+	serve(ctx, os.Args[1:])
+}
+
+func serve(ctx context.Context, args []string) {
+	flags := flag.NewFlagSet("wallet", flag.ExitOnError)
+	loader := config.NewLoader(flags)
+	printConfig := flags.Bool("print-config", false, "print effective configuration with secrets redacted and exit")
+	flags.Parse(args)
+
+	cfg, err := loader.Load(os.Getenv)
+	if err != nil {
+		log.Fatal(fmt.Errorf("invalid configuration: %w", err))
+	}
+	if *printConfig {
+		out, err := cfg.Print()
+		if err != nil {
+			log.Fatal(fmt.Errorf("failed to print configuration %w", err))
+		}
+		fmt.Print(out)
+		return
+	}
+
+	db, err := openDB(cfg.DB)
 	if err != nil {
 		log.Fatal(fmt.Errorf("failed to create DB repo %w", err))
 	}
 	defer db.Close()
 
+	if err := checkSchema(ctx, db, cfg.DB.MigrateOnStartup); err != nil {
+		log.Fatal(err)
+	}
+
 	repo := repository.NewWalletRepo(db)
-	walletService := service.NewWalletService(&repo)
-	walletController := grpcCtrl.NewWalletController(&walletService)
+	currencies := service.NewCurrencyRegistry(&repo)
+	if err := currencies.Load(ctx); err != nil {
+		log.Fatal(err)
+	}
+	go currencies.RunRefresh(ctx, cfg.Currency.RefreshInterval)
 
-	grpcService := grpc.NewGRPCService(50051)
+	feed := walletfeed.NewFeed(cfg.DB.DSN)
+	go func() {
+		if err := feed.Run(ctx); err != nil {
+			log.Println(fmt.Errorf("wallet feed stopped %w", err))
+		}
+	}()
+
+	walletOpts := []service.Option{
+		service.WithHoldTTL(cfg.Hold.TTL),
+		service.WithCurrencyRegistry(currencies),
+		service.WithFeed(feed),
+	}
+	var registry *prometheus.Registry
+	if cfg.Metrics.Enabled() {
+		registry = metrics.NewRegistry(db)
+		walletOpts = append(walletOpts, service.WithMetrics(metrics.NewWalletMetrics(registry)))
+	}
+	var fx ports.FXService
+	if cfg.FX.Enabled() {
+		rates, err := fxrate.NewFileProvider(cfg.FX.RatesFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fxService := service.NewFXService(rates, &repo, currencies,
+			service.WithSpread(cfg.FX.SpreadBps),
+			service.WithQuoteTTL(cfg.FX.QuoteTTL),
+		)
+		walletOpts = append(walletOpts, service.WithFX(fxService))
+		fx = fxService
+		go fxService.RunQuoteCleanup(ctx, cfg.FX.CleanupInterval)
+	}
+
+	walletService := service.NewWalletService(&repo, walletOpts...)
+	go walletService.RunHoldExpiry(ctx, cfg.Hold.ExpiryInterval)
+
+	var wallets ports.WalletService = &walletService
+	var catalog ports.CurrencyService = currencies
+	if cfg.Auth.Enabled() {
+		// every call carries authenticated principal, so it's checked against permissions of its roles
+		wallets = service.NewAuthorizedWalletService(wallets)
+		catalog = service.NewAuthorizedCurrencyService(catalog)
+		if fx != nil {
+			fx = service.NewAuthorizedFXService(fx)
+		}
+	}
+	// exponents of wallet currencies are read from catalog without authorization of caller
+	walletController := grpcCtrl.NewWalletController(wallets, currencies, fx)
+	adminController := grpcCtrl.NewAdminController(catalog)
+
+	opts := []grpc.Option{
+		grpc.WithRequestTimeout(cfg.GRPC.RequestTimeout),
+		grpc.WithShutdownTimeout(cfg.GRPC.ShutdownTimeout),
+		grpc.WithHealthCheck(readiness(db), cfg.GRPC.HealthCheckInterval),
+	}
+	if registry != nil {
+		opts = append(opts, grpc.WithMetrics(registry))
+	}
+	if cfg.GRPC.TLS.Enabled() {
+		certs, err := grpc.NewCertReloader(cfg.GRPC.TLS.CertFile, cfg.GRPC.TLS.KeyFile, cfg.GRPC.TLS.ClientCAFile)
+		if err != nil {
+			log.Fatal(fmt.Errorf("failed to load TLS certificate %w", err))
+		}
+		go certs.RunReload(ctx, cfg.GRPC.TLS.ReloadInterval)
+		opts = append(opts, grpc.WithTLS(credentials.NewTLS(certs.TLSConfig())))
+	}
+	if cfg.Auth.Enabled() {
+		authenticator, err := newAuthenticator(cfg.Auth)
+		if err != nil {
+			log.Fatal(fmt.Errorf("failed to configure authentication %w", err))
+		}
+		// probes don't have credentials
+		opts = append(opts, grpc.WithInterceptors(
+			grpcCtrl.AuthUnaryInterceptor(authenticator, grpc.HealthService),
+			grpcCtrl.AuthStreamInterceptor(authenticator, grpc.HealthService),
+		))
+	} else {
+		log.Println("authentication is disabled, set auth.api_keys_file or auth.jwt.jwks_file to enable it")
+	}
+	grpcService := grpc.NewGRPCService(cfg.GRPC.Addr, opts...)
 	defer grpcService.Close()
 
 	grpcService.Register(func(server *googleGrpc.Server) {
 		api.RegisterWalletServiceServer(server, walletController)
+		api.RegisterAdminServiceServer(server, adminController)
 	})
 	go func() {
 		grpcService.Run(ctx)
 	}()
 
-	AddShutdownHook(grpcService, &repo)
+	closers := []io.Closer{grpcService}
+	if registry != nil {
+		metricsServer := metrics.NewServer(cfg.Metrics.Addr, registry)
+		go func() {
+			if err := metricsServer.Run(ctx); err != nil {
+				log.Println(fmt.Errorf("metrics server stopped %w", err))
+			}
+		}()
+		closers = append(closers, metricsServer)
+	}
+
+	AddShutdownHook(append(closers, &repo)...)
+}
+
+// newAuthenticator accepts credentials of every enabled authentication method
+func newAuthenticator(cfg config.Auth) (auth.Authenticator, error) {
+	var chain auth.Chain
+	if cfg.APIKeysFile != "" {
+		keys, err := auth.NewAPIKeys(cfg.APIKeysFile)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, keys)
+	}
+	if cfg.JWT.Enabled() {
+		opts := []auth.JWTOption{auth.WithLeeway(cfg.JWT.Leeway)}
+		if cfg.JWT.Issuer != "" {
+			opts = append(opts, auth.WithIssuer(cfg.JWT.Issuer))
+		}
+		if cfg.JWT.Audience != "" {
+			opts = append(opts, auth.WithAudience(cfg.JWT.Audience))
+		}
+		tokens, err := auth.NewJWT(cfg.JWT.JWKSFile, opts...)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, tokens)
+	}
+
+	return chain, nil
+}
+
+// readiness checks that database is reachable and its schema is current
+func readiness(db *sql.DB) func(context.Context) error {
+	return func(ctx context.Context) error {
+		if err := db.PingContext(ctx); err != nil {
+			return fmt.Errorf("database is unreachable: %w", err)
+		}
+		return migration.Check(ctx, db)
+	}
+}
+
+// checkSchema applies migrations when asked to and refuses to serve database that is behind the binary
+func checkSchema(ctx context.Context, db *sql.DB, apply bool) error {
+	migrator, err := migration.New(ctx, db)
+	if err != nil {
+		return fmt.Errorf("failed to prepare migrations %w", err)
+	}
+	defer migrator.Close()
+
+	if apply {
+		if err := migrator.Up(); err != nil {
+			return fmt.Errorf("failed to apply migrations %w", err)
+		}
+	}
+
+	return migrator.CheckCurrent()
+}
+
+// openDB opens connection pool configured by cfg
+func openDB(cfg config.DB) (*sql.DB, error) {
+	db, err := sql.Open("postgres", cfg.DSN)
+	if err != nil {
+		return nil, err
+	}
+
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+	return db, nil
 }
 
 func AddShutdownHook(closers ...io.Closer) {
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/ximura/gowallet/internal/config"
+	"github.com/ximura/gowallet/internal/migration"
+)
+
+type migrationStatus struct {
+	Current uint `json:"current"`
+	Applied bool `json:"applied"`
+	Dirty   bool `json:"dirty"`
+	Latest  uint `json:"latest"`
+	Behind  bool `json:"behind"`
+}
+
+// migrate runs `wallet migrate up|down|status|version` command against configured database
+func migrate(ctx context.Context, args []string) int {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	loader := config.NewLoader(flags)
+	steps := flags.Int("steps", 1, "number of migrations rolled back by down")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: wallet migrate [flags] up|down|status|version")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitFailure
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return exitFailure
+	}
+
+	cfg, err := loader.Load(os.Getenv)
+	if err != nil {
+		log.Println(fmt.Errorf("invalid configuration: %w", err))
+		return exitFailure
+	}
+
+	db, err := openDB(cfg.DB)
+	if err != nil {
+		log.Println(fmt.Errorf("failed to create DB repo %w", err))
+		return exitFailure
+	}
+	defer db.Close()
+
+	migrator, err := migration.New(ctx, db)
+	if err != nil {
+		log.Println(fmt.Errorf("failed to prepare migrations %w", err))
+		return exitFailure
+	}
+	defer migrator.Close()
+
+	switch flags.Arg(0) {
+	case "up":
+		err = migrator.Up()
+	case "down":
+		err = migrator.Down(*steps)
+	case "status":
+		err = printStatus(migrator)
+	case "version":
+		err = printVersion(migrator)
+	default:
+		flags.Usage()
+		return exitFailure
+	}
+	if err != nil {
+		log.Println(fmt.Errorf("migrate %s failed %w", flags.Arg(0), err))
+		return exitFailure
+	}
+
+	return 0
+}
+
+func printStatus(migrator *migration.Migrator) error {
+	s, err := migrator.Status()
+	if err != nil {
+		return err
+	}
+
+	return json.NewEncoder(os.Stdout).Encode(migrationStatus{
+		Current: s.Current,
+		Applied: s.Applied,
+		Dirty:   s.Dirty,
+		Latest:  s.Latest,
+		Behind:  s.Behind(),
+	})
+}
+
+// printVersion prints version of the last applied migration or none for empty database
+func printVersion(migrator *migration.Migrator) error {
+	s, err := migrator.Status()
+	if err != nil {
+		return err
+	}
+
+	if !s.Applied {
+		fmt.Println("none")
+		return nil
+	}
+	fmt.Println(s.Current)
+	return nil
+}
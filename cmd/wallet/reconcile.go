@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/google/uuid"
+	"github.com/ximura/gowallet/internal/config"
+	"github.com/ximura/gowallet/internal/core/service"
+	"github.com/ximura/gowallet/internal/repository"
+)
+
+// exit codes of reconcile command
+const (
+	exitDrift   = 1
+	exitFailure = 2
+)
+
+type reconcileReport struct {
+	Checked int           `json:"checked"`
+	Drift   []walletDrift `json:"drift"`
+}
+
+type walletDrift struct {
+	WalletID       int    `json:"wallet_id"`
+	Account        string `json:"account"`
+	Currency       string `json:"currency"`
+	Balance        int64  `json:"balance"`
+	HistoryBalance int64  `json:"history_balance"`
+	LedgerBalance  int64  `json:"ledger_balance"`
+}
+
+// reconcile recomputes wallet balances from transaction history and ledger postings,
+// prints JSON report to stdout and returns non-zero exit code when any wallet drifted.
+func reconcile(ctx context.Context, args []string) int {
+	flags := flag.NewFlagSet("reconcile", flag.ContinueOnError)
+	loader := config.NewLoader(flags)
+	accountID := flags.String("account", "", "uuid of account to reconcile, all wallets are checked when empty")
+	if err := flags.Parse(args); err != nil {
+		return exitFailure
+	}
+
+	account := uuid.Nil
+	if *accountID != "" {
+		u, err := uuid.Parse(*accountID)
+		if err != nil {
+			log.Println(fmt.Errorf("account should be uuid: %w", err))
+			return exitFailure
+		}
+		account = u
+	}
+
+	cfg, err := loader.Load(os.Getenv)
+	if err != nil {
+		log.Println(fmt.Errorf("invalid configuration: %w", err))
+		return exitFailure
+	}
+
+	db, err := openDB(cfg.DB)
+	if err != nil {
+		log.Println(fmt.Errorf("failed to create DB repo %w", err))
+		return exitFailure
+	}
+	defer db.Close()
+
+	repo := repository.NewWalletRepo(db)
+	reconciliation := service.NewReconciliationService(&repo)
+
+	result, err := reconciliation.Reconcile(ctx, account)
+	if err != nil {
+		log.Println(fmt.Errorf("failed to reconcile %w", err))
+		return exitFailure
+	}
+
+	report := reconcileReport{
+		Checked: result.Checked,
+		Drift:   make([]walletDrift, 0, len(result.Drift)),
+	}
+	for _, d := range result.Drift {
+		report.Drift = append(report.Drift, walletDrift{
+			WalletID:       d.WalletID,
+			Account:        d.Account.String(),
+			Currency:       string(d.Currency),
+			Balance:        int64(d.Balance),
+			HistoryBalance: int64(d.HistoryBalance),
+			LedgerBalance:  int64(d.LedgerBalance),
+		})
+	}
+
+	if err := json.NewEncoder(os.Stdout).Encode(report); err != nil {
+		log.Println(fmt.Errorf("failed to write report %w", err))
+		return exitFailure
+	}
+
+	if len(report.Drift) > 0 {
+		return exitDrift
+	}
+	return 0
+}
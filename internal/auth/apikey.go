@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ximura/gowallet/internal/core/domain"
+
+	"gopkg.in/yaml.v3"
+)
+
+var _ Authenticator = (*APIKeys)(nil)
+
+// APIKeys authenticates requests with static keys listed in YAML file. File has SHA-256 hashes
+// of keys, so keys can't be taken from it:
+//
+//	# hash is printed by: printf %s "$KEY" | sha256sum
+//	- name: payroll
+//	  sha256: 5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8
+//	  roles: [operator]
+type APIKeys struct {
+	keys map[[sha256.Size]byte]domain.Principal
+}
+
+type apiKey struct {
+	Name   string        `yaml:"name"`
+	SHA256 string        `yaml:"sha256"`
+	Roles  []domain.Role `yaml:"roles"`
+}
+
+// NewAPIKeys returns authenticator of keys loaded from file
+func NewAPIKeys(path string) (*APIKeys, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("can't read API keys file: %w", err)
+	}
+
+	keys, err := parseAPIKeys(data)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse API keys file %s: %w", path, err)
+	}
+
+	return &APIKeys{keys: keys}, nil
+}
+
+func (a *APIKeys) Authenticate(ctx context.Context, credentials Credentials) (domain.Principal, error) {
+	if credentials.APIKey == "" {
+		return domain.Principal{}, ErrMissingCredentials
+	}
+
+	// only hash of presented key is compared, so lookup time doesn't reveal the key
+	principal, ok := a.keys[sha256.Sum256([]byte(credentials.APIKey))]
+	if !ok {
+		return domain.Principal{}, fmt.Errorf("%w: unknown API key", ErrInvalidCredentials)
+	}
+
+	return principal, nil
+}
+
+func parseAPIKeys(data []byte) (map[[sha256.Size]byte]domain.Principal, error) {
+	var file []apiKey
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[[sha256.Size]byte]domain.Principal, len(file))
+	names := make(map[string]bool, len(file))
+	for i, key := range file {
+		if key.Name == "" {
+			return nil, fmt.Errorf("key %d has no name", i)
+		}
+		if names[key.Name] {
+			return nil, fmt.Errorf("key %s is listed twice", key.Name)
+		}
+		names[key.Name] = true
+
+		hash, err := hex.DecodeString(key.SHA256)
+		if err == nil && len(hash) != sha256.Size {
+			err = errors.New("wrong length")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("key %s has invalid sha256: %w", key.Name, err)
+		}
+		if _, ok := keys[[sha256.Size]byte(hash)]; ok {
+			return nil, fmt.Errorf("key %s has the same hash as another key", key.Name)
+		}
+		for _, role := range key.Roles {
+			if !role.Valid() {
+				return nil, fmt.Errorf("key %s has unknown role %q", key.Name, role)
+			}
+		}
+
+		keys[[sha256.Size]byte(hash)] = domain.Principal{Subject: key.Name, Method: MethodAPIKey, Roles: key.Roles}
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,93 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ximura/gowallet/internal/auth"
+	"github.com/ximura/gowallet/internal/core/domain"
+	"gotest.tools/v3/assert"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	assert.NilError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestAPIKeys(t *testing.T) {
+	ctx := context.Background()
+	keys, err := auth.NewAPIKeys(writeFile(t, "keys.yaml", fmt.Sprintf(`
+- name: payroll
+  sha256: %s
+  roles: [operator]
+- name: reports
+  sha256: %s
+`, hash("payroll-key"), hash("reports-key"))))
+	assert.NilError(t, err)
+
+	tests := map[string]struct {
+		key       string
+		principal domain.Principal
+		err       error
+	}{
+		"valid": {
+			key:       "payroll-key",
+			principal: domain.Principal{Subject: "payroll", Method: auth.MethodAPIKey, Roles: []domain.Role{domain.RoleOperator}},
+		},
+		"without roles": {
+			key:       "reports-key",
+			principal: domain.Principal{Subject: "reports", Method: auth.MethodAPIKey},
+		},
+		"unknown": {key: "other-key", err: auth.ErrInvalidCredentials},
+		"missing": {err: auth.ErrMissingCredentials},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			principal, err := keys.Authenticate(ctx, auth.Credentials{APIKey: tt.key})
+			assert.ErrorIs(t, err, tt.err)
+			assert.DeepEqual(t, principal, tt.principal)
+		})
+	}
+}
+
+func TestNewAPIKeysInvalid(t *testing.T) {
+	tests := map[string]struct {
+		content string
+		err     string
+	}{
+		"no name":      {content: "- sha256: " + hash("key"), err: "key 0 has no name"},
+		"short hash":   {content: "- name: payroll\n  sha256: abcd", err: "key payroll has invalid sha256"},
+		"plain key":    {content: "- name: payroll\n  sha256: payroll-key", err: "key payroll has invalid sha256"},
+		"same name":    {content: fmt.Sprintf("- {name: a, sha256: %s}\n- {name: a, sha256: %s}", hash("1"), hash("2")), err: "key a is listed twice"},
+		"same hash":    {content: fmt.Sprintf("- {name: a, sha256: %s}\n- {name: b, sha256: %s}", hash("1"), hash("1")), err: "key b has the same hash"},
+		"unknown role": {content: fmt.Sprintf("- {name: a, sha256: %s, roles: [operator, admin]}", hash("1")), err: `key a has unknown role "admin"`},
+		"not a list":   {content: "payroll: key", err: "can't parse API keys file"},
+		"empty":        {content: "[]"},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			_, err := auth.NewAPIKeys(writeFile(t, "keys.yaml", tt.content))
+			if tt.err == "" {
+				assert.NilError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
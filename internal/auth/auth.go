@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ximura/gowallet/internal/core/domain"
+)
+
+var ErrMissingCredentials = domain.NewError(domain.KindUnauthenticated, "MISSING_CREDENTIALS", "request has no credentials")
+var ErrInvalidCredentials = domain.NewError(domain.KindUnauthenticated, "INVALID_CREDENTIALS", "invalid credentials")
+
+// Methods principal is authenticated with
+const (
+	MethodAPIKey = "api_key"
+	MethodJWT    = "jwt"
+)
+
+// Credentials are presented by request, empty when request doesn't have them
+type Credentials struct {
+	APIKey      string
+	BearerToken string
+}
+
+// Authenticator verifies credentials of requests
+type Authenticator interface {
+	// Authenticate returns principal of valid credentials, ErrMissingCredentials when request doesn't
+	// have credentials authenticator checks and ErrInvalidCredentials when they are not valid
+	Authenticate(context.Context, Credentials) (domain.Principal, error)
+}
+
+// Chain authenticates request with the first authenticator that finds its credentials in request
+type Chain []Authenticator
+
+func (c Chain) Authenticate(ctx context.Context, credentials Credentials) (domain.Principal, error) {
+	for _, authenticator := range c {
+		principal, err := authenticator.Authenticate(ctx, credentials)
+		if errors.Is(err, ErrMissingCredentials) {
+			continue
+		}
+		return principal, err
+	}
+
+	return domain.Principal{}, ErrMissingCredentials
+}
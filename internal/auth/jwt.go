@@ -0,0 +1,141 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/ximura/gowallet/internal/core/domain"
+)
+
+var _ Authenticator = (*JWT)(nil)
+
+// signingMethods are accepted algorithms of tokens, symmetric algorithms and "none" are never accepted
+var signingMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+// JWT authenticates requests with bearer tokens signed by keys of local JWKS file.
+// Subject of principal is sub claim and roles are taken from roles claim.
+type JWT struct {
+	keys   map[string]jwk
+	parser *jwt.Parser
+}
+
+// JWTOption configures validation of tokens
+type JWTOption func(*jwtOptions)
+
+type jwtOptions struct {
+	parser []jwt.ParserOption
+}
+
+// WithIssuer requires tokens to have iss claim
+func WithIssuer(issuer string) JWTOption {
+	return func(o *jwtOptions) {
+		o.parser = append(o.parser, jwt.WithIssuer(issuer))
+	}
+}
+
+// WithAudience requires aud claim of tokens to have audience
+func WithAudience(audience string) JWTOption {
+	return func(o *jwtOptions) {
+		o.parser = append(o.parser, jwt.WithAudience(audience))
+	}
+}
+
+// WithLeeway allows difference between clocks of token issuer and service
+func WithLeeway(leeway time.Duration) JWTOption {
+	return func(o *jwtOptions) {
+		o.parser = append(o.parser, jwt.WithLeeway(leeway))
+	}
+}
+
+// WithClock sets time tokens are validated at, used by tests
+func WithClock(now func() time.Time) JWTOption {
+	return func(o *jwtOptions) {
+		o.parser = append(o.parser, jwt.WithTimeFunc(now))
+	}
+}
+
+type claims struct {
+	jwt.RegisteredClaims
+	Roles []domain.Role `json:"roles"`
+}
+
+// NewJWT returns authenticator of tokens signed by keys loaded from JWKS file
+func NewJWT(path string, opts ...JWTOption) (*JWT, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("can't read JWKS file: %w", err)
+	}
+
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse JWKS file %s: %w", path, err)
+	}
+
+	o := jwtOptions{parser: []jwt.ParserOption{jwt.WithValidMethods(signingMethods), jwt.WithExpirationRequired()}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &JWT{keys: keys, parser: jwt.NewParser(o.parser...)}, nil
+}
+
+func (a *JWT) Authenticate(ctx context.Context, credentials Credentials) (domain.Principal, error) {
+	if credentials.BearerToken == "" {
+		return domain.Principal{}, ErrMissingCredentials
+	}
+
+	var c claims
+	if _, err := a.parser.ParseWithClaims(credentials.BearerToken, &c, a.key); err != nil {
+		return domain.Principal{}, fmt.Errorf("%w: %s", ErrInvalidCredentials, tokenError(err))
+	}
+	if c.Subject == "" {
+		return domain.Principal{}, fmt.Errorf("%w: token has no subject", ErrInvalidCredentials)
+	}
+
+	return domain.Principal{Subject: c.Subject, Method: MethodJWT, Roles: c.Roles}, nil
+}
+
+// key returns key token is signed with, the only key of set is used when token has no key id
+func (a *JWT) key(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := a.keys[kid]
+	if !ok && kid == "" && len(a.keys) == 1 {
+		for _, key = range a.keys {
+			ok = true
+		}
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown key %q", kid)
+	}
+	if key.alg != "" && key.alg != token.Method.Alg() {
+		return nil, fmt.Errorf("key %q can't be used with %s", kid, token.Method.Alg())
+	}
+
+	return key.key, nil
+}
+
+// tokenError returns reason token was rejected without parts of the token
+func tokenError(err error) string {
+	switch {
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return "token is expired"
+	case errors.Is(err, jwt.ErrTokenNotValidYet), errors.Is(err, jwt.ErrTokenUsedBeforeIssued):
+		return "token is not valid yet"
+	case errors.Is(err, jwt.ErrTokenInvalidIssuer):
+		return "token has invalid issuer"
+	case errors.Is(err, jwt.ErrTokenInvalidAudience):
+		return "token has invalid audience"
+	case errors.Is(err, jwt.ErrTokenRequiredClaimMissing):
+		return "token has no expiration time"
+	case errors.Is(err, jwt.ErrTokenSignatureInvalid), errors.Is(err, jwt.ErrTokenUnverifiable):
+		return "token signature can't be verified"
+	case errors.Is(err, jwt.ErrTokenMalformed):
+		return "token is malformed"
+	}
+
+	return "token is invalid"
+}
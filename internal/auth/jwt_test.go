@@ -0,0 +1,191 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/ximura/gowallet/internal/auth"
+	"github.com/ximura/gowallet/internal/core/domain"
+	"gotest.tools/v3/assert"
+)
+
+var now = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// writeJWKS writes key set with public parts of keys by their ids
+func writeJWKS(t *testing.T, keys map[string]any) string {
+	t.Helper()
+	var set struct {
+		Keys []map[string]string `json:"keys"`
+	}
+	for kid, key := range keys {
+		switch k := key.(type) {
+		case *rsa.PrivateKey:
+			set.Keys = append(set.Keys, map[string]string{"kty": "RSA", "kid": kid, "n": b64(k.N.Bytes()), "e": b64(big.NewInt(int64(k.E)).Bytes())})
+		case *ecdsa.PrivateKey:
+			set.Keys = append(set.Keys, map[string]string{"kty": "EC", "kid": kid, "crv": "P-256", "x": b64(k.X.FillBytes(make([]byte, 32))), "y": b64(k.Y.FillBytes(make([]byte, 32)))})
+		case ed25519.PrivateKey:
+			set.Keys = append(set.Keys, map[string]string{"kty": "OKP", "kid": kid, "crv": "Ed25519", "x": b64(k.Public().(ed25519.PublicKey))})
+		}
+	}
+
+	data, err := json.Marshal(set)
+	assert.NilError(t, err)
+	return writeFile(t, "jwks.json", string(data))
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, key any, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	assert.NilError(t, err)
+	return signed
+}
+
+func TestJWT(t *testing.T) {
+	ctx := context.Background()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NilError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NilError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NilError(t, err)
+
+	tokens, err := auth.NewJWT(writeJWKS(t, map[string]any{"rsa": rsaKey, "ec": ecKey, "ed": edKey}),
+		auth.WithIssuer("https://id.example.com"),
+		auth.WithAudience("wallet"),
+		auth.WithLeeway(time.Minute),
+		auth.WithClock(func() time.Time { return now }),
+	)
+	assert.NilError(t, err)
+
+	claims := func(overrides jwt.MapClaims) jwt.MapClaims {
+		c := jwt.MapClaims{
+			"sub":   "alice",
+			"iss":   "https://id.example.com",
+			"aud":   "wallet",
+			"exp":   now.Add(time.Hour).Unix(),
+			"roles": []string{"operator"},
+		}
+		for k, v := range overrides {
+			if v == nil {
+				delete(c, k)
+				continue
+			}
+			c[k] = v
+		}
+		return c
+	}
+	alice := domain.Principal{Subject: "alice", Method: auth.MethodJWT, Roles: []domain.Role{domain.RoleOperator}}
+
+	tests := map[string]struct {
+		token     string
+		principal domain.Principal
+		err       string
+	}{
+		"rsa":              {token: sign(t, jwt.SigningMethodRS256, "rsa", rsaKey, claims(nil)), principal: alice},
+		"rsa pss":          {token: sign(t, jwt.SigningMethodPS256, "rsa", rsaKey, claims(nil)), principal: alice},
+		"ecdsa":            {token: sign(t, jwt.SigningMethodES256, "ec", ecKey, claims(nil)), principal: alice},
+		"ed25519":          {token: sign(t, jwt.SigningMethodEdDSA, "ed", edKey, claims(nil)), principal: alice},
+		"within leeway":    {token: sign(t, jwt.SigningMethodRS256, "rsa", rsaKey, claims(jwt.MapClaims{"exp": now.Add(-time.Second).Unix()})), principal: alice},
+		"expired":          {token: sign(t, jwt.SigningMethodRS256, "rsa", rsaKey, claims(jwt.MapClaims{"exp": now.Add(-time.Hour).Unix()})), err: "token is expired"},
+		"no expiration":    {token: sign(t, jwt.SigningMethodRS256, "rsa", rsaKey, claims(jwt.MapClaims{"exp": nil})), err: "token has no expiration time"},
+		"not valid yet":    {token: sign(t, jwt.SigningMethodRS256, "rsa", rsaKey, claims(jwt.MapClaims{"nbf": now.Add(time.Hour).Unix()})), err: "token is not valid yet"},
+		"wrong issuer":     {token: sign(t, jwt.SigningMethodRS256, "rsa", rsaKey, claims(jwt.MapClaims{"iss": "https://evil.example.com"})), err: "token has invalid issuer"},
+		"wrong audience":   {token: sign(t, jwt.SigningMethodRS256, "rsa", rsaKey, claims(jwt.MapClaims{"aud": "ledger"})), err: "token has invalid audience"},
+		"no subject":       {token: sign(t, jwt.SigningMethodRS256, "rsa", rsaKey, claims(jwt.MapClaims{"sub": nil})), err: "token has no subject"},
+		"unknown key":      {token: sign(t, jwt.SigningMethodRS256, "other", otherKey, claims(nil)), err: "token signature can't be verified"},
+		"forged signature": {token: sign(t, jwt.SigningMethodRS256, "rsa", otherKey, claims(nil)), err: "token signature can't be verified"},
+		"key of other type": {
+			token: sign(t, jwt.SigningMethodES256, "rsa", ecKey, claims(nil)),
+			err:   "token signature can't be verified",
+		},
+		"symmetric": {
+			token: sign(t, jwt.SigningMethodHS256, "rsa", []byte("secret"), claims(nil)),
+			err:   "token signature can't be verified",
+		},
+		"none": {
+			token: sign(t, jwt.SigningMethodNone, "rsa", jwt.UnsafeAllowNoneSignatureType, claims(nil)),
+			err:   "token signature can't be verified",
+		},
+		"malformed": {token: "not.a.token", err: "token is malformed"},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			principal, err := tokens.Authenticate(ctx, auth.Credentials{BearerToken: tt.token})
+			if tt.err != "" {
+				assert.ErrorIs(t, err, auth.ErrInvalidCredentials)
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, principal, tt.principal)
+		})
+	}
+
+	_, err = tokens.Authenticate(ctx, auth.Credentials{APIKey: "key"})
+	assert.ErrorIs(t, err, auth.ErrMissingCredentials)
+}
+
+func TestJWTSingleKey(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	tokens, err := auth.NewJWT(writeJWKS(t, map[string]any{"": key}), auth.WithClock(func() time.Time { return now }))
+	assert.NilError(t, err)
+
+	// token without key id is verified with the only key of set
+	token := sign(t, jwt.SigningMethodES256, "", key, jwt.MapClaims{"sub": "bob", "exp": now.Add(time.Hour).Unix()})
+	principal, err := tokens.Authenticate(context.Background(), auth.Credentials{BearerToken: token})
+	assert.NilError(t, err)
+	assert.Equal(t, principal.Subject, "bob")
+}
+
+func TestNewJWTInvalid(t *testing.T) {
+	tests := map[string]struct {
+		jwks string
+		err  string
+	}{
+		"not json":       {jwks: "keys", err: "can't parse JWKS file"},
+		"empty":          {jwks: `{"keys": []}`, err: "no signing keys"},
+		"encryption key": {jwks: `{"keys": [{"kty": "OKP", "use": "enc", "crv": "X25519", "x": "AA"}]}`, err: "no signing keys"},
+		"short rsa":      {jwks: `{"keys": [{"kty": "RSA", "kid": "a", "n": "AQAB", "e": "AQAB"}]}`, err: "at least 2048 are required"},
+		"point not on curve": {
+			jwks: `{"keys": [{"kty": "EC", "kid": "a", "crv": "P-256", "x": "` + b64(make([]byte, 32)) + `", "y": "` + b64(make([]byte, 32)) + `"}]}`,
+			err:  `key 0 "a"`,
+		},
+		"unknown curve": {jwks: `{"keys": [{"kty": "EC", "kid": "a", "crv": "P-192"}]}`, err: `unsupported curve "P-192"`},
+		"symmetric key": {jwks: `{"keys": [{"kty": "oct", "kid": "a", "k": "c2VjcmV0"}]}`, err: `unsupported key type "oct"`},
+		"same kid": {
+			jwks: `{"keys": [{"kty": "OKP", "kid": "a", "crv": "Ed25519", "x": "` + b64(make([]byte, 32)) + `"}, {"kty": "OKP", "kid": "a", "crv": "Ed25519", "x": "` + b64(make([]byte, 32)) + `"}]}`,
+			err:  `key "a" is listed twice`,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			_, err := auth.NewJWT(writeFile(t, "jwks.json", tt.jwks))
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,443 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// envPrefix is prepended to environment variables of settings, e.g. WALLET_DB_DSN for db.dsn
+const envPrefix = "WALLET_"
+
+// envConfigFile names config file when --config flag is not set
+const envConfigFile = envPrefix + "CONFIG"
+
+const redacted = "xxxxx"
+
+type Config struct {
+	DB       DB       `yaml:"db" toml:"db"`
+	GRPC     GRPC     `yaml:"grpc" toml:"grpc"`
+	Hold     Hold     `yaml:"hold" toml:"hold"`
+	Currency Currency `yaml:"currency" toml:"currency"`
+	FX       FX       `yaml:"fx" toml:"fx"`
+	Auth     Auth     `yaml:"auth" toml:"auth"`
+	Metrics  Metrics  `yaml:"metrics" toml:"metrics"`
+}
+
+type DB struct {
+	// libpq connection string, either key=value pairs or postgres:// URL
+	DSN             string        `yaml:"dsn" toml:"dsn"`
+	MaxOpenConns    int           `yaml:"max_open_conns" toml:"max_open_conns"`
+	MaxIdleConns    int           `yaml:"max_idle_conns" toml:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" toml:"conn_max_lifetime"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time" toml:"conn_max_idle_time"`
+	// apply embedded migrations before serving
+	MigrateOnStartup bool `yaml:"migrate_on_startup" toml:"migrate_on_startup"`
+}
+
+type GRPC struct {
+	// address server listens on, host:port
+	Addr string `yaml:"addr" toml:"addr"`
+	// deadline of a single request, 0 disables it
+	RequestTimeout time.Duration `yaml:"request_timeout" toml:"request_timeout"`
+	// time given to running requests on shutdown before connections are closed
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout"`
+	// how often database is checked for health status of services
+	HealthCheckInterval time.Duration `yaml:"health_check_interval" toml:"health_check_interval"`
+	TLS                 TLS           `yaml:"tls" toml:"tls"`
+}
+
+// TLS is enabled when certificate and key are set
+type TLS struct {
+	CertFile string `yaml:"cert_file" toml:"cert_file"`
+	KeyFile  string `yaml:"key_file" toml:"key_file"`
+	// PEM bundle of CAs client certificates are verified against, enables mutual TLS
+	ClientCAFile string `yaml:"client_ca_file" toml:"client_ca_file"`
+	// how often files are checked for changes
+	ReloadInterval time.Duration `yaml:"reload_interval" toml:"reload_interval"`
+}
+
+func (t TLS) Enabled() bool {
+	return t.CertFile != ""
+}
+
+type Hold struct {
+	// time after which not captured holds expire
+	TTL time.Duration `yaml:"ttl" toml:"ttl"`
+	// how often expired holds are closed
+	ExpiryInterval time.Duration `yaml:"expiry_interval" toml:"expiry_interval"`
+}
+
+type Currency struct {
+	// how often currency catalog is reloaded from database
+	RefreshInterval time.Duration `yaml:"refresh_interval" toml:"refresh_interval"`
+}
+
+// FX conversion is enabled when rates file is set
+type FX struct {
+	// YAML file with rates of base currencies in quote currencies
+	RatesFile string `yaml:"rates_file" toml:"rates_file"`
+	// spread charged on conversions, in basis points
+	SpreadBps int `yaml:"spread_bps" toml:"spread_bps"`
+	// time quoted rate stays locked
+	QuoteTTL time.Duration `yaml:"quote_ttl" toml:"quote_ttl"`
+	// how often expired quotes are deleted
+	CleanupInterval time.Duration `yaml:"cleanup_interval" toml:"cleanup_interval"`
+}
+
+func (f FX) Enabled() bool {
+	return f.RatesFile != ""
+}
+
+// Auth is enabled when API keys file or JWKS file is set
+type Auth struct {
+	// YAML file with names, SHA-256 hashes and roles of API keys
+	APIKeysFile string `yaml:"api_keys_file" toml:"api_keys_file"`
+	JWT         JWT    `yaml:"jwt" toml:"jwt"`
+}
+
+func (a Auth) Enabled() bool {
+	return a.APIKeysFile != "" || a.JWT.Enabled()
+}
+
+// JWT authentication is enabled when JWKS file is set
+type JWT struct {
+	// JSON Web Key Set with public keys tokens are signed with
+	JWKSFile string `yaml:"jwks_file" toml:"jwks_file"`
+	// required iss claim, not checked when empty
+	Issuer string `yaml:"issuer" toml:"issuer"`
+	// required aud claim, not checked when empty
+	Audience string `yaml:"audience" toml:"audience"`
+	// allowed difference between clocks of token issuer and server
+	Leeway time.Duration `yaml:"leeway" toml:"leeway"`
+}
+
+func (j JWT) Enabled() bool {
+	return j.JWKSFile != ""
+}
+
+// Metrics are served when address is set
+type Metrics struct {
+	// address HTTP server with prometheus metrics listens on
+	Addr string `yaml:"addr" toml:"addr"`
+}
+
+func (m Metrics) Enabled() bool {
+	return m.Addr != ""
+}
+
+func Default() Config {
+	return Config{
+		DB: DB{
+			MaxOpenConns:    20,
+			MaxIdleConns:    10,
+			ConnMaxLifetime: 30 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
+		},
+		GRPC: GRPC{
+			Addr:                ":50051",
+			RequestTimeout:      30 * time.Second,
+			ShutdownTimeout:     10 * time.Second,
+			HealthCheckInterval: 10 * time.Second,
+			TLS: TLS{
+				ReloadInterval: time.Minute,
+			},
+		},
+		Hold: Hold{
+			TTL:            7 * 24 * time.Hour,
+			ExpiryInterval: time.Minute,
+		},
+		Currency: Currency{
+			RefreshInterval: time.Minute,
+		},
+		FX: FX{
+			QuoteTTL:        30 * time.Second,
+			CleanupInterval: 10 * time.Minute,
+		},
+		Auth: Auth{
+			JWT: JWT{
+				Leeway: 30 * time.Second,
+			},
+		},
+	}
+}
+
+// setting is a single configuration value that can be overridden by environment variable and flag
+type setting struct {
+	// dotted path of the value in config file
+	name  string
+	usage string
+	set   func(c *Config, value string) error
+	// boolean settings can be set by flag without value
+	boolean bool
+}
+
+var settings = []setting{
+	{"db.dsn", "postgres connection string", stringSetting(func(c *Config) *string { return &c.DB.DSN }), false},
+	{"db.max_open_conns", "maximum number of open database connections, 0 is unlimited", intSetting(func(c *Config) *int { return &c.DB.MaxOpenConns }), false},
+	{"db.max_idle_conns", "maximum number of idle database connections", intSetting(func(c *Config) *int { return &c.DB.MaxIdleConns }), false},
+	{"db.conn_max_lifetime", "maximum time database connection is reused", durationSetting(func(c *Config) *time.Duration { return &c.DB.ConnMaxLifetime }), false},
+	{"db.conn_max_idle_time", "maximum time database connection stays idle", durationSetting(func(c *Config) *time.Duration { return &c.DB.ConnMaxIdleTime }), false},
+	{"db.migrate_on_startup", "apply schema migrations before serving", boolSetting(func(c *Config) *bool { return &c.DB.MigrateOnStartup }), true},
+	{"grpc.addr", "address gRPC server listens on", stringSetting(func(c *Config) *string { return &c.GRPC.Addr }), false},
+	{"grpc.request_timeout", "deadline of a single request, 0 disables it", durationSetting(func(c *Config) *time.Duration { return &c.GRPC.RequestTimeout }), false},
+	{"grpc.shutdown_timeout", "time given to running requests on shutdown", durationSetting(func(c *Config) *time.Duration { return &c.GRPC.ShutdownTimeout }), false},
+	{"grpc.health_check_interval", "how often database is checked for health status", durationSetting(func(c *Config) *time.Duration { return &c.GRPC.HealthCheckInterval }), false},
+	{"grpc.tls.cert_file", "PEM certificate of the server, enables TLS", stringSetting(func(c *Config) *string { return &c.GRPC.TLS.CertFile }), false},
+	{"grpc.tls.key_file", "PEM private key of the server certificate", stringSetting(func(c *Config) *string { return &c.GRPC.TLS.KeyFile }), false},
+	{"grpc.tls.client_ca_file", "PEM bundle of CAs that sign client certificates, enables mutual TLS", stringSetting(func(c *Config) *string { return &c.GRPC.TLS.ClientCAFile }), false},
+	{"grpc.tls.reload_interval", "how often TLS files are checked for changes", durationSetting(func(c *Config) *time.Duration { return &c.GRPC.TLS.ReloadInterval }), false},
+	{"hold.ttl", "time after which not captured holds expire", durationSetting(func(c *Config) *time.Duration { return &c.Hold.TTL }), false},
+	{"hold.expiry_interval", "how often expired holds are closed", durationSetting(func(c *Config) *time.Duration { return &c.Hold.ExpiryInterval }), false},
+	{"currency.refresh_interval", "how often currency catalog is reloaded from database", durationSetting(func(c *Config) *time.Duration { return &c.Currency.RefreshInterval }), false},
+	{"fx.rates_file", "YAML file with exchange rates, enables currency conversion", stringSetting(func(c *Config) *string { return &c.FX.RatesFile }), false},
+	{"fx.spread_bps", "spread charged on conversions, in basis points", intSetting(func(c *Config) *int { return &c.FX.SpreadBps }), false},
+	{"fx.quote_ttl", "time quoted exchange rate stays locked", durationSetting(func(c *Config) *time.Duration { return &c.FX.QuoteTTL }), false},
+	{"fx.cleanup_interval", "how often expired exchange rate quotes are deleted", durationSetting(func(c *Config) *time.Duration { return &c.FX.CleanupInterval }), false},
+	{"auth.api_keys_file", "YAML file with hashes of API keys, enables API key authentication", stringSetting(func(c *Config) *string { return &c.Auth.APIKeysFile }), false},
+	{"auth.jwt.jwks_file", "JWKS file with keys of token issuer, enables JWT authentication", stringSetting(func(c *Config) *string { return &c.Auth.JWT.JWKSFile }), false},
+	{"auth.jwt.issuer", "required issuer of tokens", stringSetting(func(c *Config) *string { return &c.Auth.JWT.Issuer }), false},
+	{"auth.jwt.audience", "required audience of tokens", stringSetting(func(c *Config) *string { return &c.Auth.JWT.Audience }), false},
+	{"auth.jwt.leeway", "allowed clock difference when token times are checked", durationSetting(func(c *Config) *time.Duration { return &c.Auth.JWT.Leeway }), false},
+	{"metrics.addr", "address of HTTP server with prometheus metrics, enables metrics", stringSetting(func(c *Config) *string { return &c.Metrics.Addr }), false},
+}
+
+// flagName is command line flag of setting, e.g. db-max-open-conns for db.max_open_conns
+func (s setting) flagName() string {
+	return strings.NewReplacer(".", "-", "_", "-").Replace(s.name)
+}
+
+// envName is environment variable of setting, e.g. WALLET_DB_MAX_OPEN_CONNS for db.max_open_conns
+func (s setting) envName() string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(s.name, ".", "_"))
+}
+
+// Loader builds configuration from defaults, config file, environment variables and flags,
+// every source overrides values of the previous one.
+type Loader struct {
+	file  string
+	flags map[string]string
+}
+
+// NewLoader registers configuration flags on the flag set, values are read by Load after flags are parsed
+func NewLoader(flags *flag.FlagSet) *Loader {
+	l := &Loader{flags: make(map[string]string)}
+	flags.StringVar(&l.file, "config", "", "path to YAML or TOML config file, "+envConfigFile+" is used when empty")
+	for _, s := range settings {
+		name := s.name
+		usage := fmt.Sprintf("%s (%s)", s.usage, s.envName())
+		set := func(value string) error {
+			l.flags[name] = value
+			return nil
+		}
+		if s.boolean {
+			flags.BoolFunc(s.flagName(), usage, set)
+		} else {
+			flags.Func(s.flagName(), usage, set)
+		}
+	}
+
+	return l
+}
+
+// Load returns validated configuration, getenv is usually os.Getenv
+func (l *Loader) Load(getenv func(string) string) (Config, error) {
+	c := Default()
+
+	file := l.file
+	if file == "" {
+		file = getenv(envConfigFile)
+	}
+	if file != "" {
+		if err := readFile(file, &c); err != nil {
+			return Config{}, err
+		}
+	}
+
+	for _, s := range settings {
+		if value := getenv(s.envName()); value != "" {
+			if err := s.set(&c, value); err != nil {
+				return Config{}, fmt.Errorf("invalid %s: %w", s.envName(), err)
+			}
+		}
+	}
+
+	for _, s := range settings {
+		if value, ok := l.flags[s.name]; ok {
+			if err := s.set(&c, value); err != nil {
+				return Config{}, fmt.Errorf("invalid -%s: %w", s.flagName(), err)
+			}
+		}
+	}
+
+	if err := c.Validate(); err != nil {
+		return Config{}, err
+	}
+	return c, nil
+}
+
+func readFile(path string, c *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("can't read config file: %w", err)
+	}
+
+	switch ext := filepath.Ext(path); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, c)
+	case ".toml":
+		err = toml.Unmarshal(data, c)
+	default:
+		return fmt.Errorf("unsupported config file format %q", ext)
+	}
+	if err != nil {
+		return fmt.Errorf("can't parse config file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// Validate reports all invalid settings at once
+func (c Config) Validate() error {
+	var errs []error
+	if c.DB.DSN == "" {
+		errs = append(errs, errors.New("db.dsn is required"))
+	}
+	if c.DB.MaxOpenConns < 0 {
+		errs = append(errs, errors.New("db.max_open_conns can't be negative"))
+	}
+	if c.DB.MaxIdleConns < 0 {
+		errs = append(errs, errors.New("db.max_idle_conns can't be negative"))
+	}
+	if c.DB.MaxOpenConns > 0 && c.DB.MaxIdleConns > c.DB.MaxOpenConns {
+		errs = append(errs, errors.New("db.max_idle_conns can't exceed db.max_open_conns"))
+	}
+	if c.DB.ConnMaxLifetime < 0 || c.DB.ConnMaxIdleTime < 0 {
+		errs = append(errs, errors.New("db connection lifetimes can't be negative"))
+	}
+	if _, _, err := net.SplitHostPort(c.GRPC.Addr); err != nil {
+		errs = append(errs, fmt.Errorf("grpc.addr should be host:port: %w", err))
+	}
+	if c.GRPC.RequestTimeout < 0 || c.GRPC.ShutdownTimeout < 0 {
+		errs = append(errs, errors.New("grpc timeouts can't be negative"))
+	}
+	if c.GRPC.HealthCheckInterval <= 0 {
+		errs = append(errs, errors.New("grpc.health_check_interval should be positive"))
+	}
+	if (c.GRPC.TLS.CertFile == "") != (c.GRPC.TLS.KeyFile == "") {
+		errs = append(errs, errors.New("grpc.tls.cert_file and grpc.tls.key_file should be set together"))
+	}
+	if c.GRPC.TLS.ClientCAFile != "" && !c.GRPC.TLS.Enabled() {
+		errs = append(errs, errors.New("grpc.tls.client_ca_file requires grpc.tls.cert_file"))
+	}
+	if c.GRPC.TLS.ReloadInterval <= 0 {
+		errs = append(errs, errors.New("grpc.tls.reload_interval should be positive"))
+	}
+	if c.Hold.TTL <= 0 || c.Hold.ExpiryInterval <= 0 {
+		errs = append(errs, errors.New("hold.ttl and hold.expiry_interval should be positive"))
+	}
+	if c.Currency.RefreshInterval <= 0 {
+		errs = append(errs, errors.New("currency.refresh_interval should be positive"))
+	}
+	if c.FX.SpreadBps < 0 || c.FX.SpreadBps >= 10000 {
+		errs = append(errs, errors.New("fx.spread_bps should be between 0 and 9999"))
+	}
+	if c.FX.QuoteTTL <= 0 || c.FX.CleanupInterval <= 0 {
+		errs = append(errs, errors.New("fx.quote_ttl and fx.cleanup_interval should be positive"))
+	}
+	if !c.Auth.JWT.Enabled() && (c.Auth.JWT.Issuer != "" || c.Auth.JWT.Audience != "") {
+		errs = append(errs, errors.New("auth.jwt.issuer and auth.jwt.audience require auth.jwt.jwks_file"))
+	}
+	if c.Auth.JWT.Leeway < 0 {
+		errs = append(errs, errors.New("auth.jwt.leeway can't be negative"))
+	}
+	if c.Metrics.Enabled() && c.Metrics.Addr == c.GRPC.Addr {
+		errs = append(errs, errors.New("metrics.addr should differ from grpc.addr"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Redacted returns copy of configuration safe to print, secrets are replaced
+func (c Config) Redacted() Config {
+	c.DB.DSN = redactDSN(c.DB.DSN)
+	return c
+}
+
+// Print writes redacted configuration as YAML
+func (c Config) Print() (string, error) {
+	data, err := yaml.Marshal(c.Redacted())
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+var dsnPassword = regexp.MustCompile(`(password\s*=\s*)('(\\.|[^'])*'|\S+)`)
+
+func redactDSN(dsn string) string {
+	if u, err := url.Parse(dsn); err == nil && u.Scheme != "" {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), redacted)
+		}
+		q := u.Query()
+		if q.Has("password") {
+			q.Set("password", redacted)
+			u.RawQuery = q.Encode()
+		}
+		return u.String()
+	}
+
+	return dsnPassword.ReplaceAllString(dsn, "${1}"+redacted)
+}
+
+func stringSetting(field func(c *Config) *string) func(c *Config, value string) error {
+	return func(c *Config, value string) error {
+		*field(c) = value
+		return nil
+	}
+}
+
+func intSetting(field func(c *Config) *int) func(c *Config, value string) error {
+	return func(c *Config, value string) error {
+		v, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		*field(c) = v
+		return nil
+	}
+}
+
+func boolSetting(field func(c *Config) *bool) func(c *Config, value string) error {
+	return func(c *Config, value string) error {
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		*field(c) = v
+		return nil
+	}
+}
+
+func durationSetting(field func(c *Config) *time.Duration) func(c *Config, value string) error {
+	return func(c *Config, value string) error {
+		v, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		*field(c) = v
+		return nil
+	}
+}
@@ -0,0 +1,66 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/ximura/gowallet/api"
+	"github.com/ximura/gowallet/internal/core/domain"
+	"github.com/ximura/gowallet/internal/core/ports"
+)
+
+type adminServer struct {
+	currencies ports.CurrencyService
+
+	api.UnimplementedAdminServiceServer
+}
+
+func NewAdminController(currencies ports.CurrencyService) api.AdminServiceServer {
+	return adminServer{
+		currencies: currencies,
+	}
+}
+
+func (s adminServer) ListCurrencies(ctx context.Context, req *api.ListCurrenciesRequest) (*api.ListCurrenciesResponse, error) {
+	currencies, err := s.currencies.ListCurrencies(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	var response api.ListCurrenciesResponse
+	for i := range currencies {
+		response.Currencies = append(response.Currencies, convertCurrency(currencies[i]))
+	}
+
+	return &response, nil
+}
+
+func (s adminServer) EnableCurrency(ctx context.Context, req *api.CurrencyRequest) (*api.Currency, error) {
+	return s.setCurrencyEnabled(ctx, req.Code, true)
+}
+
+func (s adminServer) DisableCurrency(ctx context.Context, req *api.CurrencyRequest) (*api.Currency, error) {
+	return s.setCurrencyEnabled(ctx, req.Code, false)
+}
+
+func (s adminServer) setCurrencyEnabled(ctx context.Context, code string, enabled bool) (*api.Currency, error) {
+	currency, err := domain.ParseCurrency(code)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	c, err := s.currencies.SetCurrencyEnabled(ctx, currency, enabled)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return convertCurrency(c), nil
+}
+
+func convertCurrency(c domain.CurrencyInfo) *api.Currency {
+	return &api.Currency{
+		Code:        string(c.Code),
+		NumericCode: int32(c.NumericCode),
+		Exponent:    int32(c.Exponent),
+		Enabled:     c.Enabled,
+	}
+}
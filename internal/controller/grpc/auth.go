@@ -0,0 +1,104 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ximura/gowallet/internal/auth"
+	"github.com/ximura/gowallet/internal/core/domain"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	apiKeyHeader        = "x-api-key"
+	authorizationHeader = "authorization"
+	bearerScheme        = "bearer "
+)
+
+// AuthUnaryInterceptor rejects requests without valid credentials, principal is put into context of the others.
+// Methods of public services, e.g. health checks, don't need credentials.
+func AuthUnaryInterceptor(authenticator auth.Authenticator, publicServices ...string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if isPublic(info.FullMethod, publicServices) {
+			return handler(ctx, req)
+		}
+		ctx, err := authenticate(ctx, authenticator)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// AuthStreamInterceptor rejects streams without valid credentials, principal is put into context of the others
+func AuthStreamInterceptor(authenticator auth.Authenticator, publicServices ...string) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if isPublic(info.FullMethod, publicServices) {
+			return handler(srv, stream)
+		}
+		ctx, err := authenticate(stream.Context(), authenticator)
+		if err != nil {
+			return err
+		}
+		return handler(srv, authenticatedStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// isPublic reports if method, in /package.Service/Method form, belongs to one of public services
+func isPublic(fullMethod string, publicServices []string) bool {
+	service, _, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	return ok && slices.Contains(publicServices, service)
+}
+
+func authenticate(ctx context.Context, authenticator auth.Authenticator) (context.Context, error) {
+	credentials, err := requestCredentials(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	principal, err := authenticator.Authenticate(ctx, credentials)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return domain.ContextWithPrincipal(ctx, principal), nil
+}
+
+// requestCredentials reads API key from x-api-key header and token from authorization header with bearer scheme
+func requestCredentials(ctx context.Context) (auth.Credentials, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var credentials auth.Credentials
+
+	keys := md.Get(apiKeyHeader)
+	if len(keys) > 1 {
+		return auth.Credentials{}, fmt.Errorf("%w: more than one %s header", auth.ErrInvalidCredentials, apiKeyHeader)
+	}
+	if len(keys) == 1 {
+		credentials.APIKey = keys[0]
+	}
+
+	values := md.Get(authorizationHeader)
+	if len(values) > 1 {
+		return auth.Credentials{}, fmt.Errorf("%w: more than one %s header", auth.ErrInvalidCredentials, authorizationHeader)
+	}
+	if len(values) == 1 {
+		if len(values[0]) < len(bearerScheme) || !strings.EqualFold(values[0][:len(bearerScheme)], bearerScheme) {
+			return auth.Credentials{}, fmt.Errorf("%w: unsupported authorization scheme", auth.ErrInvalidCredentials)
+		}
+		credentials.BearerToken = strings.TrimSpace(values[0][len(bearerScheme):])
+	}
+
+	return credentials, nil
+}
@@ -0,0 +1,118 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ximura/gowallet/internal/auth"
+	"github.com/ximura/gowallet/internal/core/domain"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gotest.tools/v3/assert"
+)
+
+// staticAuthenticator accepts a single API key and a single token
+type staticAuthenticator struct{}
+
+func (staticAuthenticator) Authenticate(ctx context.Context, c auth.Credentials) (domain.Principal, error) {
+	switch {
+	case c.BearerToken == "token":
+		return domain.Principal{Subject: "alice", Method: auth.MethodJWT}, nil
+	case c.BearerToken != "":
+		return domain.Principal{}, auth.ErrInvalidCredentials
+	case c.APIKey == "key":
+		return domain.Principal{Subject: "payroll", Method: auth.MethodAPIKey}, nil
+	case c.APIKey != "":
+		return domain.Principal{}, auth.ErrInvalidCredentials
+	}
+	return domain.Principal{}, auth.ErrMissingCredentials
+}
+
+func TestAuthUnaryInterceptor(t *testing.T) {
+	interceptor := AuthUnaryInterceptor(staticAuthenticator{}, "grpc.health.v1.Health")
+
+	tests := map[string]struct {
+		md      metadata.MD
+		method  string
+		subject string
+		code    codes.Code
+		reason  string
+	}{
+		"api key":         {md: metadata.Pairs("x-api-key", "key"), subject: "payroll"},
+		"bearer token":    {md: metadata.Pairs("authorization", "Bearer token"), subject: "alice"},
+		"lowercase":       {md: metadata.Pairs("authorization", "bearer token"), subject: "alice"},
+		"no credentials":  {md: metadata.MD{}, code: codes.Unauthenticated, reason: "MISSING_CREDENTIALS"},
+		"wrong key":       {md: metadata.Pairs("x-api-key", "other"), code: codes.Unauthenticated, reason: "INVALID_CREDENTIALS"},
+		"basic scheme":    {md: metadata.Pairs("authorization", "Basic dXNlcjpwYXNz"), code: codes.Unauthenticated, reason: "INVALID_CREDENTIALS"},
+		"two keys":        {md: metadata.Pairs("x-api-key", "key", "x-api-key", "other"), code: codes.Unauthenticated, reason: "INVALID_CREDENTIALS"},
+		"invalid token":   {md: metadata.Pairs("authorization", "Bearer other", "x-api-key", "key"), code: codes.Unauthenticated, reason: "INVALID_CREDENTIALS"},
+		"empty bearer":    {md: metadata.Pairs("authorization", "Bearer"), code: codes.Unauthenticated, reason: "INVALID_CREDENTIALS"},
+		"without context": {code: codes.Unauthenticated, reason: "MISSING_CREDENTIALS"},
+		"public service":  {method: "/grpc.health.v1.Health/Check"},
+		"service with public prefix": {
+			method: "/grpc.health.v1.HealthReport/Check",
+			code:   codes.Unauthenticated,
+			reason: "MISSING_CREDENTIALS",
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+
+			method := tt.method
+			if method == "" {
+				method = "/wallet.api.WalletService/Get"
+			}
+
+			var subject string
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req any) (any, error) {
+				principal, _ := domain.PrincipalFromContext(ctx)
+				subject = principal.Subject
+				return nil, nil
+			})
+			assert.Equal(t, status.Code(err), tt.code)
+			assert.Equal(t, subject, tt.subject)
+			if tt.reason != "" {
+				info, ok := status.Convert(err).Details()[0].(*errdetails.ErrorInfo)
+				assert.Assert(t, ok)
+				assert.Equal(t, info.Reason, tt.reason)
+			}
+		})
+	}
+}
+
+type testStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s testStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestAuthStreamInterceptor(t *testing.T) {
+	interceptor := AuthStreamInterceptor(staticAuthenticator{})
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-api-key", "key"))
+
+	err := interceptor(nil, testStream{ctx: ctx}, &grpc.StreamServerInfo{}, func(srv any, stream grpc.ServerStream) error {
+		principal, ok := domain.PrincipalFromContext(stream.Context())
+		assert.Assert(t, ok)
+		assert.Equal(t, principal.Subject, "payroll")
+		return nil
+	})
+	assert.NilError(t, err)
+
+	err = interceptor(nil, testStream{ctx: context.Background()}, &grpc.StreamServerInfo{}, func(srv any, stream grpc.ServerStream) error {
+		t.Fatal("handler of unauthenticated stream was called")
+		return nil
+	})
+	assert.Equal(t, status.Code(err), codes.Unauthenticated)
+}
@@ -0,0 +1,127 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ximura/gowallet/api"
+	"github.com/ximura/gowallet/internal/core/domain"
+)
+
+func (s server) ProcessBatch(ctx context.Context, req *api.BatchRequest) (*api.BatchResponse, error) {
+	mode, err := convertBatchMode(req.Mode)
+	if err != nil {
+		return nil, err
+	}
+
+	batch, err := appendTransactions(domain.Batch{Mode: mode}, req.Transactions)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.processBatch(ctx, batch)
+}
+
+func (s server) ProcessBatchStream(stream api.WalletService_ProcessBatchStreamServer) error {
+	var batch domain.Batch
+	for first := true; ; first = false {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if first {
+			if batch.Mode, err = convertBatchMode(req.Mode); err != nil {
+				return err
+			}
+		}
+		batch, err = appendTransactions(batch, req.Transactions)
+		if err != nil {
+			return err
+		}
+		// stop reading stream of batch that will be rejected anyway
+		if len(batch.Transactions) > domain.MaxBatchSize {
+			return toStatus(domain.ErrInvalidBatchSize)
+		}
+	}
+
+	response, err := s.processBatch(stream.Context(), batch)
+	if err != nil {
+		return err
+	}
+
+	return stream.SendAndClose(response)
+}
+
+func (s server) processBatch(ctx context.Context, batch domain.Batch) (*api.BatchResponse, error) {
+	items, err := s.service.ProcessBatch(ctx, batch)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	response := api.BatchResponse{
+		Results:  make([]*api.BatchResult, 0, len(items)),
+		Applied:  int32(domain.CountOutcome(items, domain.BatchApplied)),
+		Replayed: int32(domain.CountOutcome(items, domain.BatchReplayed)),
+		Failed:   int32(domain.CountOutcome(items, domain.BatchFailed)),
+	}
+	for _, item := range items {
+		result := &api.BatchResult{Outcome: convertBatchOutcome(item.Outcome)}
+		switch item.Outcome {
+		case domain.BatchApplied, domain.BatchReplayed:
+			wallet, err := s.convertWallet(ctx, item.Wallet)
+			if err != nil {
+				return nil, toStatus(err)
+			}
+			result.Transaction = convertTransaction(item.Transaction)
+			result.Wallet = wallet
+		case domain.BatchFailed:
+			result.Error = batchError(item.Err)
+		}
+		response.Results = append(response.Results, result)
+	}
+
+	return &response, nil
+}
+
+// appendTransactions parses transactions and appends them to batch, fields are reported with position in batch
+func appendTransactions(batch domain.Batch, transactions []*api.Transaction) (domain.Batch, error) {
+	for _, t := range transactions {
+		transaction, err := transactionRequest(fmt.Sprintf("transactions[%d].", len(batch.Transactions)), t)
+		if err != nil {
+			return domain.Batch{}, err
+		}
+		batch.Transactions = append(batch.Transactions, transaction)
+	}
+
+	return batch, nil
+}
+
+func convertBatchMode(mode api.BatchMode) (domain.BatchMode, error) {
+	switch mode {
+	case api.BatchMode_BATCH_ATOMIC:
+		return domain.BatchAtomic, nil
+	case api.BatchMode_BATCH_BEST_EFFORT:
+		return domain.BatchBestEffort, nil
+	}
+
+	return domain.BatchAtomic, invalidArgument("mode", fmt.Sprintf("unknown mode %d", mode))
+}
+
+func convertBatchOutcome(o domain.BatchOutcome) api.BatchOutcome {
+	switch o {
+	case domain.BatchApplied:
+		return api.BatchOutcome_BATCH_APPLIED
+	case domain.BatchReplayed:
+		return api.BatchOutcome_BATCH_REPLAYED
+	case domain.BatchFailed:
+		return api.BatchOutcome_BATCH_FAILED
+	}
+
+	return api.BatchOutcome_BATCH_ABORTED
+}
@@ -2,24 +2,30 @@ package grpc
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/google/uuid"
 	"github.com/ximura/gowallet/api"
 	"github.com/ximura/gowallet/internal/core/domain"
 	"github.com/ximura/gowallet/internal/core/ports"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type server struct {
 	service ports.WalletService
+	// catalog exponents of wallet currencies are read from, it's not authorized per caller
+	currencies ports.CurrencyService
+	// nil when currency conversion is not configured
+	fx ports.FXService
 
 	api.UnimplementedWalletServiceServer
 }
 
-func NewWalletController(service ports.WalletService) api.WalletServiceServer {
+func NewWalletController(service ports.WalletService, currencies ports.CurrencyService, fx ports.FXService) api.WalletServiceServer {
 	return server{
-		service: service,
+		service:    service,
+		currencies: currencies,
+		fx:         fx,
 	}
 }
 
@@ -30,36 +36,71 @@ func (s server) Ping(ctx context.Context, req *api.PingRequest) (*api.PingRespon
 }
 
 func (s server) Create(ctx context.Context, req *api.CreateRequest) (*api.CreateResponse, error) {
-	if req.Currency == "" {
-		return nil, status.Errorf(codes.InvalidArgument, "currency can't be empty")
+	currency, err := domain.ParseCurrency(req.Currency)
+	if err != nil {
+		return nil, toStatus(err)
 	}
 
 	u, err := uuid.Parse(req.AccountID)
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "account id should be uuid")
+		return nil, invalidArgument("accountID", "account id should be uuid")
+	}
+	create := s.service.Create
+	if req.MultiCurrency {
+		create = s.service.CreateMultiCurrency
+	}
+	w, err := create(ctx, u, currency)
+	if err != nil {
+		return nil, toStatus(err)
 	}
-	w, err := s.service.Create(ctx, u, domain.Currency(req.Currency))
+	wallet, err := s.convertWallet(ctx, w)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, err.Error())
+		return nil, toStatus(err)
 	}
 	return &api.CreateResponse{
-		Wallet: convertWallet(w),
+		Wallet: wallet,
 	}, nil
 }
 
 func (s server) List(ctx context.Context, req *api.ListRequest) (*api.ListResponse, error) {
 	u, err := uuid.Parse(req.AccountID)
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "account id should be uuid")
+		return nil, invalidArgument("accountID", "account id should be uuid")
+	}
+
+	order, err := convertWalletOrder(req.Order)
+	if err != nil {
+		return nil, invalidArgument("order", err.Error())
 	}
 
-	r, err := s.service.List(ctx, u)
+	cursor, err := decodeWalletCursor(req.PageToken)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, err.Error())
+		return nil, invalidArgument("page_token", err.Error())
 	}
-	var response api.ListResponse
-	for i := range r {
-		response.Wallet = append(response.Wallet, convertWallet(r[i]))
+	if cursor != nil && cursor.Order != order {
+		return nil, invalidArgument("page_token", "page token was issued for different order")
+	}
+
+	page, err := s.service.List(ctx, domain.WalletFilter{
+		Account:  u,
+		Currency: domain.Currency(req.Currency),
+		Order:    order,
+		Limit:    int(req.PageSize),
+		After:    cursor,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	response := api.ListResponse{
+		NextPageToken: encodeWalletCursor(page.Next),
+	}
+	for i := range page.Wallets {
+		wallet, err := s.convertWallet(ctx, page.Wallets[i])
+		if err != nil {
+			return nil, toStatus(err)
+		}
+		response.Wallet = append(response.Wallet, wallet)
 	}
 
 	return &response, nil
@@ -68,38 +109,489 @@ func (s server) List(ctx context.Context, req *api.ListRequest) (*api.ListRespon
 func (s server) Get(ctx context.Context, req *api.GetRequest) (*api.GetResponse, error) {
 	w, err := s.service.Get(ctx, int(req.WalletID))
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, err.Error())
+		return nil, toStatus(err)
+	}
+
+	wallet, err := s.convertWallet(ctx, w)
+	if err != nil {
+		return nil, toStatus(err)
 	}
 
 	return &api.GetResponse{
-		Wallet: convertWallet(w),
+		Wallet: wallet,
 	}, nil
 }
 
+func (s server) WatchWallet(req *api.WatchWalletRequest, stream api.WalletService_WatchWalletServer) error {
+	ctx := stream.Context()
+	err := s.service.WatchWallet(ctx, int(req.WalletID), req.FromVersion, func(w domain.Wallet) error {
+		wallet, err := s.convertWallet(ctx, w)
+		if err != nil {
+			return err
+		}
+		return stream.Send(wallet)
+	})
+	if err != nil {
+		return toStatus(err)
+	}
+
+	return nil
+}
+
 func (s server) ProcessTransaction(ctx context.Context, req *api.Transaction) (*api.Wallet, error) {
+	transaction, err := transactionRequest("", req)
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := s.service.ProcessTransaction(ctx, transaction)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	wallet, err := s.convertWallet(ctx, w)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return wallet, nil
+}
+
+func (s server) Transfer(ctx context.Context, req *api.TransferRequest) (*api.TransferResponse, error) {
 	u, err := uuid.Parse(req.Id)
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "id should be uuid")
+		return nil, invalidArgument("id", "id should be uuid")
+	}
+
+	currency, err := domain.ParseCurrency(req.Currency)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	conversion, err := conversionRequest("", req.Convert, req.QuoteID)
+	if err != nil {
+		return nil, err
 	}
 
-	w, err := s.service.ProcessTransaction(ctx, domain.Transaction{
+	from, to, err := s.service.Transfer(ctx, domain.Transfer{
+		ID:           u,
+		FromWalletID: int(req.FromWalletID),
+		ToWalletID:   int(req.ToWalletID),
+		Amount:       domain.Amount(req.Amount),
+		Currency:     currency,
+		Conversion:   conversion,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	source, err := s.convertWallet(ctx, from)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	destination, err := s.convertWallet(ctx, to)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &api.TransferResponse{
+		From: source,
+		To:   destination,
+	}, nil
+}
+
+func (s server) Refund(ctx context.Context, req *api.RefundRequest) (*api.RefundResponse, error) {
+	u, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, invalidArgument("id", "id should be uuid")
+	}
+
+	original, err := uuid.Parse(req.TransactionID)
+	if err != nil {
+		return nil, invalidArgument("transactionID", "transaction id should be uuid")
+	}
+
+	t, w, err := s.service.Refund(ctx, domain.Refund{
+		ID:            u,
+		WalletID:      int(req.WalletID),
+		TransactionID: original,
+		Amount:        domain.Amount(req.Amount),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	wallet, err := s.convertWallet(ctx, w)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &api.RefundResponse{
+		Refund: convertTransaction(t),
+		Wallet: wallet,
+	}, nil
+}
+
+func (s server) ListTransactions(ctx context.Context, req *api.ListTransactionsRequest) (*api.ListTransactionsResponse, error) {
+	cursor, err := decodeTransactionCursor(req.PageToken)
+	if err != nil {
+		return nil, invalidArgument("page_token", err.Error())
+	}
+
+	sign, err := convertSign(req.Sign)
+	if err != nil {
+		return nil, invalidArgument("sign", err.Error())
+	}
+
+	filter := domain.TransactionFilter{
+		WalletID: int(req.WalletID),
+		Sign:     sign,
+		Limit:    int(req.PageSize),
+		After:    cursor,
+	}
+	if req.From != nil {
+		filter.From = req.From.AsTime()
+	}
+	if req.To != nil {
+		filter.To = req.To.AsTime()
+	}
+
+	page, err := s.service.ListTransactions(ctx, filter)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	response := api.ListTransactionsResponse{
+		NextPageToken: encodeTransactionCursor(page.Next),
+	}
+	for i := range page.Transactions {
+		response.Transactions = append(response.Transactions, convertTransaction(page.Transactions[i]))
+	}
+
+	return &response, nil
+}
+
+func (s server) Hold(ctx context.Context, req *api.HoldRequest) (*api.WalletHold, error) {
+	u, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, invalidArgument("id", "id should be uuid")
+	}
+
+	currency, err := domain.ParseCurrency(req.Currency)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	h, err := s.service.Hold(ctx, domain.Hold{
 		ID:       u,
 		WalletID: int(req.WalletID),
-		Amount:   int(req.Amount),
-		Currency: domain.Currency(req.Currency),
+		Amount:   domain.Amount(req.Amount),
+		Currency: currency,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return convertHold(h), nil
+}
+
+func (s server) CaptureHold(ctx context.Context, req *api.CaptureHoldRequest) (*api.CaptureHoldResponse, error) {
+	u, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, invalidArgument("id", "id should be uuid")
+	}
+
+	h, w, err := s.service.CaptureHold(ctx, u, domain.Amount(req.Amount))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	wallet, err := s.convertWallet(ctx, w)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &api.CaptureHoldResponse{
+		Hold:   convertHold(h),
+		Wallet: wallet,
+	}, nil
+}
+
+func (s server) ReleaseHold(ctx context.Context, req *api.ReleaseHoldRequest) (*api.WalletHold, error) {
+	u, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, invalidArgument("id", "id should be uuid")
+	}
+
+	h, err := s.service.ReleaseHold(ctx, u)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return convertHold(h), nil
+}
+
+func (s server) FreezeWallet(ctx context.Context, req *api.FreezeWalletRequest) (*api.Wallet, error) {
+	w, err := s.service.FreezeWallet(ctx, domain.StatusChange{
+		WalletID:     int(req.WalletID),
+		BlockCredits: req.BlockCredits,
+		Actor:        req.Actor,
+		Reason:       req.Reason,
 	})
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, err.Error())
+		return nil, toStatus(err)
+	}
+
+	wallet, err := s.convertWallet(ctx, w)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return wallet, nil
+}
+
+func (s server) UnfreezeWallet(ctx context.Context, req *api.UnfreezeWalletRequest) (*api.Wallet, error) {
+	w, err := s.service.UnfreezeWallet(ctx, domain.StatusChange{WalletID: int(req.WalletID), Actor: req.Actor, Reason: req.Reason})
+	if err != nil {
+		return nil, toStatus(err)
 	}
 
-	return convertWallet(w), nil
+	wallet, err := s.convertWallet(ctx, w)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return wallet, nil
+}
+
+func (s server) CloseWallet(ctx context.Context, req *api.CloseWalletRequest) (*api.Wallet, error) {
+	w, err := s.service.CloseWallet(ctx, domain.StatusChange{WalletID: int(req.WalletID), Actor: req.Actor, Reason: req.Reason})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	wallet, err := s.convertWallet(ctx, w)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return wallet, nil
 }
 
-func convertWallet(w domain.Wallet) *api.Wallet {
-	return &api.Wallet{
-		Id:       int32(w.ID),
-		Customer: w.Account.String(),
-		Amount:   int64(w.Amount),
-		Currency: string(w.Currency),
+func (s server) Quote(ctx context.Context, req *api.QuoteRequest) (*api.RateQuote, error) {
+	if s.fx == nil {
+		return nil, toStatus(fmt.Errorf("%w: currency conversion is not configured", domain.ErrRateNotAvailable))
+	}
+
+	from, err := domain.ParseCurrency(req.FromCurrency)
+	if err != nil {
+		return nil, toStatus(err)
 	}
+	to, err := domain.ParseCurrency(req.ToCurrency)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	q, err := s.fx.Quote(ctx, from, to)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &api.RateQuote{
+		Id:           q.ID.String(),
+		FromCurrency: string(q.From),
+		ToCurrency:   string(q.To),
+		Rate:         q.Rate.String(),
+		SpreadBps:    int32(q.SpreadBps),
+		ExpiresAt:    timestamppb.New(q.ExpiresAt),
+	}, nil
+}
+
+// transactionRequest parses transaction, field names of invalid arguments are reported with prefix
+func transactionRequest(prefix string, req *api.Transaction) (domain.Transaction, error) {
+	u, err := uuid.Parse(req.Id)
+	if err != nil {
+		return domain.Transaction{}, invalidArgument(prefix+"id", "id should be uuid")
+	}
+
+	currency, err := domain.ParseCurrency(req.Currency)
+	if err != nil {
+		return domain.Transaction{}, toStatus(err)
+	}
+
+	conversion, err := conversionRequest(prefix, req.Convert, req.QuoteID)
+	if err != nil {
+		return domain.Transaction{}, err
+	}
+
+	return domain.Transaction{
+		ID:         u,
+		WalletID:   int(req.WalletID),
+		Amount:     domain.Amount(req.Amount),
+		Currency:   currency,
+		Conversion: conversion,
+	}, nil
+}
+
+// conversionRequest allows conversion to wallet currency when it's asked for, nil otherwise
+func conversionRequest(prefix string, convert bool, quoteID string) (*domain.Conversion, error) {
+	if quoteID == "" {
+		if !convert {
+			return nil, nil
+		}
+		return &domain.Conversion{}, nil
+	}
+
+	id, err := uuid.Parse(quoteID)
+	if err != nil {
+		return nil, invalidArgument(prefix+"quoteID", "quote id should be uuid")
+	}
+
+	return &domain.Conversion{QuoteID: id}, nil
+}
+
+// convertWallet fails when currency of wallet or its pocket is missing from catalog,
+// so that amounts are never sent with wrong exponent
+func (s server) convertWallet(ctx context.Context, w domain.Wallet) (*api.Wallet, error) {
+	exponent, err := s.exponent(ctx, w.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &api.Wallet{
+		Id:            int32(w.ID),
+		Customer:      w.Account.String(),
+		Amount:        int64(w.Amount),
+		Currency:      string(w.Currency),
+		Exponent:      exponent,
+		Available:     int64(w.Available()),
+		MultiCurrency: w.MultiCurrency,
+		ParentID:      int32(w.ParentID),
+		Status:        convertWalletStatus(w.Status),
+		BlockCredits:  w.BlockCredits,
+		Version:       w.Version,
+	}
+	for _, p := range w.Pockets {
+		exponent, err := s.exponent(ctx, p.Currency)
+		if err != nil {
+			return nil, err
+		}
+		result.Pockets = append(result.Pockets, &api.Pocket{
+			WalletID:  int32(p.WalletID),
+			Currency:  string(p.Currency),
+			Amount:    int64(p.Amount),
+			Available: int64(p.Available()),
+			Exponent:  exponent,
+		})
+	}
+
+	return result, nil
+}
+
+// exponent returns number of minor unit digits of currency.
+// Wallet currency is always in the catalog, currencies are disabled instead of removed,
+// so missing currency is reported as internal error rather than as error of the request.
+func (s server) exponent(ctx context.Context, currency domain.Currency) (int32, error) {
+	c, err := s.currencies.GetCurrency(ctx, currency)
+	if err != nil {
+		return 0, fmt.Errorf("can't get exponent of %s: %v", currency, err)
+	}
+
+	return int32(c.Exponent), nil
+}
+
+func convertHold(h domain.Hold) *api.WalletHold {
+	return &api.WalletHold{
+		Id:             h.ID.String(),
+		WalletID:       int32(h.WalletID),
+		Amount:         int64(h.Amount),
+		Currency:       string(h.Currency),
+		Status:         convertHoldStatus(h.Status),
+		CapturedAmount: int64(h.CapturedAmount),
+		ExpiresAt:      timestamppb.New(h.ExpiresAt),
+	}
+}
+
+func convertWalletStatus(s domain.WalletStatus) api.WalletStatus {
+	switch s {
+	case domain.WalletActive:
+		return api.WalletStatus_WALLET_ACTIVE
+	case domain.WalletFrozen:
+		return api.WalletStatus_WALLET_FROZEN
+	case domain.WalletClosed:
+		return api.WalletStatus_WALLET_CLOSED
+	}
+
+	return api.WalletStatus_WALLET_STATUS_UNSPECIFIED
+}
+
+func convertHoldStatus(s domain.HoldStatus) api.HoldStatus {
+	switch s {
+	case domain.HoldActive:
+		return api.HoldStatus_HOLD_ACTIVE
+	case domain.HoldCaptured:
+		return api.HoldStatus_HOLD_CAPTURED
+	case domain.HoldReleased:
+		return api.HoldStatus_HOLD_RELEASED
+	case domain.HoldExpired:
+		return api.HoldStatus_HOLD_EXPIRED
+	}
+
+	return api.HoldStatus_HOLD_STATUS_UNSPECIFIED
+}
+
+func convertTransaction(t domain.Transaction) *api.TransactionRecord {
+	result := &api.TransactionRecord{
+		Id:                   t.ID.String(),
+		WalletID:             int32(t.WalletID),
+		Amount:               int64(t.Amount),
+		Currency:             string(t.Currency),
+		Balance:              int64(t.Balance),
+		CounterpartyWalletID: int32(t.CounterpartyWalletID),
+	}
+	if !t.CreatedAt.IsZero() {
+		result.CreatedAt = timestamppb.New(t.CreatedAt)
+	}
+	if t.ReversalOf != uuid.Nil {
+		result.ReversalOf = t.ReversalOf.String()
+	}
+	if c := t.Conversion; c.Applied() {
+		result.Conversion = &api.Conversion{
+			Amount:    int64(c.FromAmount),
+			Currency:  string(c.FromCurrency),
+			Rate:      c.Rate.String(),
+			SpreadBps: int32(c.SpreadBps),
+		}
+		if c.QuoteID != uuid.Nil {
+			result.Conversion.QuoteID = c.QuoteID.String()
+		}
+	}
+
+	return result
+}
+
+func convertWalletOrder(order api.WalletOrder) (domain.WalletOrder, error) {
+	switch order {
+	case api.WalletOrder_WALLET_ORDER_OLDEST:
+		return domain.OrderOldest, nil
+	case api.WalletOrder_WALLET_ORDER_NEWEST:
+		return domain.OrderNewest, nil
+	case api.WalletOrder_WALLET_ORDER_CURRENCY:
+		return domain.OrderCurrency, nil
+	}
+
+	return domain.OrderOldest, fmt.Errorf("unknown order %d", order)
+}
+
+func convertSign(sign api.Sign) (domain.Sign, error) {
+	switch sign {
+	case api.Sign_SIGN_ANY:
+		return domain.SignAny, nil
+	case api.Sign_SIGN_CREDIT:
+		return domain.SignCredit, nil
+	case api.Sign_SIGN_DEBIT:
+		return domain.SignDebit, nil
+	}
+
+	return domain.SignAny, fmt.Errorf("unknown sign %d", sign)
 }
@@ -0,0 +1,55 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ximura/gowallet/internal/core/domain"
+	"github.com/ximura/gowallet/internal/core/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gotest.tools/v3/assert"
+)
+
+func TestConvertWallet(t *testing.T) {
+	ctx := context.Background()
+	s := server{currencies: service.NewCurrencyRegistry(nil)}
+
+	tests := map[string]struct {
+		wallet    domain.Wallet
+		exponents []int32
+		err       bool
+	}{
+		"wallet with pockets": {
+			wallet:    domain.Wallet{ID: 1, Currency: "usd", MultiCurrency: true, Pockets: []domain.Pocket{{WalletID: 2, Currency: "jpy"}}},
+			exponents: []int32{2, 0},
+		},
+		"currency missing from catalog": {
+			wallet: domain.Wallet{ID: 1, Currency: "xyz"},
+			err:    true,
+		},
+		"pocket currency missing from catalog": {
+			wallet: domain.Wallet{ID: 1, Currency: "usd", MultiCurrency: true, Pockets: []domain.Pocket{{WalletID: 2, Currency: "xyz"}}},
+			err:    true,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			result, err := s.convertWallet(ctx, tt.wallet)
+			if tt.err {
+				// wrong exponent is never sent, client gets internal error instead
+				assert.Equal(t, status.Code(toStatus(err)), codes.Internal)
+				return
+			}
+
+			assert.NilError(t, err)
+			exponents := []int32{result.Exponent}
+			for _, p := range result.Pockets {
+				exponents = append(exponents, p.Exponent)
+			}
+			assert.DeepEqual(t, exponents, tt.exponents)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/ximura/gowallet/api"
+	"github.com/ximura/gowallet/internal/core/domain"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorDomain is reported in ErrorInfo details, reasons are unique within it
+const errorDomain = "wallet.api"
+
+const reasonInvalidArgument = "INVALID_ARGUMENT"
+
+var kindCodes = map[domain.ErrorKind]codes.Code{
+	domain.KindInvalidArgument:    codes.InvalidArgument,
+	domain.KindNotFound:           codes.NotFound,
+	domain.KindAlreadyExists:      codes.AlreadyExists,
+	domain.KindFailedPrecondition: codes.FailedPrecondition,
+	domain.KindAborted:            codes.Aborted,
+	domain.KindUnauthenticated:    codes.Unauthenticated,
+	domain.KindPermissionDenied:   codes.PermissionDenied,
+}
+
+// toStatus translates service error to grpc status,
+// errors that are not domain errors are logged and hidden from client.
+func toStatus(err error) error {
+	var domainErr *domain.Error
+	switch {
+	case errors.As(err, &domainErr):
+		code, ok := kindCodes[domainErr.Kind]
+		if !ok {
+			break
+		}
+		return withReason(status.New(code, err.Error()), domainErr.Reason, nil)
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+
+	log.Println(err)
+	return status.Error(codes.Internal, "internal error")
+}
+
+// invalidArgument reports malformed request field
+func invalidArgument(field, msg string) error {
+	return withReason(status.New(codes.InvalidArgument, msg), reasonInvalidArgument, map[string]string{"field": field})
+}
+
+func withReason(s *status.Status, reason string, metadata map[string]string) error {
+	detailed, err := s.WithDetails(&errdetails.ErrorInfo{
+		Reason:   reason,
+		Domain:   errorDomain,
+		Metadata: metadata,
+	})
+	if err != nil {
+		return s.Err()
+	}
+	return detailed.Err()
+}
+
+// batchError reports failed transaction of a batch with status code and reason it would fail with alone
+func batchError(err error) *api.BatchError {
+	s := status.Convert(toStatus(err))
+	result := &api.BatchError{
+		Code:    int32(s.Code()),
+		Message: s.Message(),
+	}
+	for _, detail := range s.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			result.Reason = info.Reason
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,86 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ximura/gowallet/internal/core/domain"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gotest.tools/v3/assert"
+)
+
+func TestToStatus(t *testing.T) {
+	tests := map[string]struct {
+		err    error
+		code   codes.Code
+		reason string
+	}{
+		"not found": {
+			err:    fmt.Errorf("can't get wallet 1: %w", domain.ErrWalletNotFound),
+			code:   codes.NotFound,
+			reason: "WALLET_NOT_FOUND",
+		},
+		"invalid argument": {
+			err:    domain.ErrInvalidAmount,
+			code:   codes.InvalidArgument,
+			reason: "INVALID_AMOUNT",
+		},
+		"failed precondition": {
+			err:    domain.ErrInsufficientFunds,
+			code:   codes.FailedPrecondition,
+			reason: "INSUFFICIENT_FUNDS",
+		},
+		"already exists": {
+			err:    domain.ErrIdempotencyKeyReused,
+			code:   codes.AlreadyExists,
+			reason: "IDEMPOTENCY_KEY_REUSED",
+		},
+		"aborted": {
+			err:    fmt.Errorf("%w: %w", domain.ErrConcurrentUpdate, errors.New("pq: could not serialize access")),
+			code:   codes.Aborted,
+			reason: "CONCURRENT_UPDATE",
+		},
+		"deadline": {
+			err:  fmt.Errorf("can't get wallet 1: %w", context.DeadlineExceeded),
+			code: codes.DeadlineExceeded,
+		},
+		"unknown": {
+			err:  errors.New("pq: connection refused"),
+			code: codes.Internal,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			s := status.Convert(toStatus(tt.err))
+			assert.Equal(t, s.Code(), tt.code)
+
+			if tt.reason == "" {
+				assert.Equal(t, len(s.Details()), 0)
+				return
+			}
+			assert.Equal(t, s.Message(), tt.err.Error())
+			assert.Equal(t, len(s.Details()), 1)
+			info, ok := s.Details()[0].(*errdetails.ErrorInfo)
+			assert.Assert(t, ok)
+			assert.Equal(t, info.Reason, tt.reason)
+			assert.Equal(t, info.Domain, errorDomain)
+		})
+	}
+}
+
+func TestBatchError(t *testing.T) {
+	result := batchError(fmt.Errorf("can't get wallet 1: %w", domain.ErrWalletNotFound))
+	assert.Equal(t, codes.Code(result.Code), codes.NotFound)
+	assert.Equal(t, result.Reason, "WALLET_NOT_FOUND")
+	assert.Equal(t, result.Message, "can't get wallet 1: wallet not found")
+
+	result = batchError(domain.ErrInsufficientFunds)
+	assert.Equal(t, codes.Code(result.Code), codes.FailedPrecondition)
+	assert.Equal(t, result.Reason, "INSUFFICIENT_FUNDS")
+}
@@ -0,0 +1,95 @@
+package grpc
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ximura/gowallet/internal/core/domain"
+)
+
+var errInvalidPageToken = errors.New("invalid page token")
+
+// page tokens are opaque for clients, internally it is url safe base64 of "<created at unix nano>/<transaction id>"
+func encodeTransactionCursor(c *domain.TransactionCursor) string {
+	if c == nil {
+		return ""
+	}
+
+	raw := strconv.FormatInt(c.CreatedAt.UnixNano(), 10) + "/" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeTransactionCursor(token string) (*domain.TransactionCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errInvalidPageToken
+	}
+
+	createdAt, id, ok := strings.Cut(string(raw), "/")
+	if !ok {
+		return nil, errInvalidPageToken
+	}
+
+	nano, err := strconv.ParseInt(createdAt, 10, 64)
+	if err != nil {
+		return nil, errInvalidPageToken
+	}
+
+	u, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errInvalidPageToken
+	}
+
+	return &domain.TransactionCursor{CreatedAt: time.Unix(0, nano), ID: u}, nil
+}
+
+// wallet page tokens are url safe base64 of "<order>/<currency>/<wallet id>"
+func encodeWalletCursor(c *domain.WalletCursor) string {
+	if c == nil {
+		return ""
+	}
+
+	raw := strconv.Itoa(int(c.Order)) + "/" + string(c.Currency) + "/" + strconv.Itoa(c.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeWalletCursor(token string) (*domain.WalletCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errInvalidPageToken
+	}
+
+	parts := strings.Split(string(raw), "/")
+	if len(parts) != 3 {
+		return nil, errInvalidPageToken
+	}
+
+	order, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return nil, errInvalidPageToken
+	}
+
+	currency := domain.Currency(parts[1])
+	if !currency.Valid() {
+		return nil, errInvalidPageToken
+	}
+
+	id, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return nil, errInvalidPageToken
+	}
+
+	return &domain.WalletCursor{Order: domain.WalletOrder(order), Currency: currency, ID: id}, nil
+}
@@ -0,0 +1,26 @@
+package grpc
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/ximura/gowallet/internal/core/domain"
+	"gotest.tools/v3/assert"
+)
+
+func TestWalletCursor(t *testing.T) {
+	cursor := &domain.WalletCursor{Order: domain.OrderCurrency, Currency: "usd", ID: 42}
+
+	decoded, err := decodeWalletCursor(encodeWalletCursor(cursor))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, decoded, cursor)
+
+	decoded, err = decodeWalletCursor(encodeWalletCursor(nil))
+	assert.NilError(t, err)
+	assert.Assert(t, decoded == nil)
+
+	for _, token := range []string{"not base64!", base64.RawURLEncoding.EncodeToString([]byte("1/usd")), base64.RawURLEncoding.EncodeToString([]byte("1/USD/42"))} {
+		_, err := decodeWalletCursor(token)
+		assert.ErrorIs(t, err, errInvalidPageToken)
+	}
+}
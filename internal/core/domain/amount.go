@@ -0,0 +1,48 @@
+package domain
+
+import "math"
+
+var ErrAmountOverflow = NewError(KindFailedPrecondition, "AMOUNT_OVERFLOW", "amount out of range")
+
+// Amount is signed sum of money in the smallest currency unit (e.g. cents),
+// arithmetic that can go out of range should use checked methods.
+type Amount int64
+
+const (
+	MaxAmount Amount = math.MaxInt64
+	MinAmount Amount = math.MinInt64
+)
+
+// Add returns a + b or ErrAmountOverflow when result is out of range
+func (a Amount) Add(b Amount) (Amount, error) {
+	sum := a + b
+	if (b > 0 && sum < a) || (b < 0 && sum > a) {
+		return 0, ErrAmountOverflow
+	}
+	return sum, nil
+}
+
+// Sub returns a - b or ErrAmountOverflow when result is out of range
+func (a Amount) Sub(b Amount) (Amount, error) {
+	diff := a - b
+	if (b > 0 && diff > a) || (b < 0 && diff < a) {
+		return 0, ErrAmountOverflow
+	}
+	return diff, nil
+}
+
+// Neg returns -a, MinAmount has no positive counterpart
+func (a Amount) Neg() (Amount, error) {
+	if a == MinAmount {
+		return 0, ErrAmountOverflow
+	}
+	return -a, nil
+}
+
+// Abs returns absolute value, MinAmount has no positive counterpart
+func (a Amount) Abs() (Amount, error) {
+	if a < 0 {
+		return a.Neg()
+	}
+	return a, nil
+}
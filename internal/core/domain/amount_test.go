@@ -0,0 +1,51 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/ximura/gowallet/internal/core/domain"
+	"gotest.tools/v3/assert"
+)
+
+func TestAmountArithmetic(t *testing.T) {
+	add := func(a, b domain.Amount) (domain.Amount, error) { return a.Add(b) }
+	sub := func(a, b domain.Amount) (domain.Amount, error) { return a.Sub(b) }
+	neg := func(a, _ domain.Amount) (domain.Amount, error) { return a.Neg() }
+	abs := func(a, _ domain.Amount) (domain.Amount, error) { return a.Abs() }
+
+	tests := map[string]struct {
+		op     func(a, b domain.Amount) (domain.Amount, error)
+		a, b   domain.Amount
+		result domain.Amount
+		err    error
+	}{
+		"add":                {op: add, a: 100, b: -30, result: 70},
+		"add to max":         {op: add, a: domain.MaxAmount - 1, b: 1, result: domain.MaxAmount},
+		"add overflow":       {op: add, a: domain.MaxAmount, b: 1, err: domain.ErrAmountOverflow},
+		"add underflow":      {op: add, a: domain.MinAmount, b: -1, err: domain.ErrAmountOverflow},
+		"sub":                {op: sub, a: 100, b: 130, result: -30},
+		"sub overflow":       {op: sub, a: domain.MaxAmount, b: -1, err: domain.ErrAmountOverflow},
+		"sub underflow":      {op: sub, a: domain.MinAmount + 1, b: 2, err: domain.ErrAmountOverflow},
+		"neg":                {op: neg, a: domain.MaxAmount, result: -domain.MaxAmount},
+		"neg min":            {op: neg, a: domain.MinAmount, err: domain.ErrAmountOverflow},
+		"abs":                {op: abs, a: -40, result: 40},
+		"abs min":            {op: abs, a: domain.MinAmount, err: domain.ErrAmountOverflow},
+		"abs of positive":    {op: abs, a: 40, result: 40},
+		"add zero to max":    {op: add, a: domain.MaxAmount, b: 0, result: domain.MaxAmount},
+		"sub zero from min":  {op: sub, a: domain.MinAmount, b: 0, result: domain.MinAmount},
+		"add opposite signs": {op: add, a: domain.MaxAmount, b: domain.MinAmount, result: -1},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			result, err := tt.op(tt.a, tt.b)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, result, tt.result)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// MaxBatchSize limits number of transactions processed by one batch, batch is applied in a single database transaction
+const MaxBatchSize = 5000
+
+var ErrInvalidBatchSize = NewError(KindInvalidArgument, "INVALID_BATCH_SIZE", fmt.Sprintf("batch should have from 1 to %d transactions", MaxBatchSize))
+var ErrDuplicateBatchKey = NewError(KindInvalidArgument, "DUPLICATE_BATCH_KEY", "idempotency key is used by another transaction of the batch")
+
+type BatchMode int
+
+const (
+	// All transactions of the batch are applied or none of them
+	BatchAtomic BatchMode = iota
+	// Transactions that can be applied are applied, failed ones are reported
+	BatchBestEffort
+)
+
+type BatchOutcome int
+
+const (
+	// Transaction was not applied because batch was rolled back due to failure of another transaction
+	BatchAborted BatchOutcome = iota
+	// Transaction was applied by the batch
+	BatchApplied
+	// Transaction was already processed with the same idempotency key, its stored result is returned
+	BatchReplayed
+	// Transaction can't be applied
+	BatchFailed
+)
+
+// Batch is a list of transactions processed together
+type Batch struct {
+	Mode         BatchMode
+	Transactions []Transaction
+}
+
+// WalletIDs returns ids of wallets the batch is applied to in ascending order, so they can be locked without deadlocks
+func (b Batch) WalletIDs() []int {
+	ids := make([]int, 0, len(b.Transactions))
+	for _, t := range b.Transactions {
+		ids = append(ids, t.WalletID)
+	}
+	slices.Sort(ids)
+
+	return slices.Compact(ids)
+}
+
+// Keys returns idempotency keys of batch transactions
+func (b Batch) Keys() []uuid.UUID {
+	keys := make([]uuid.UUID, 0, len(b.Transactions))
+	for _, t := range b.Transactions {
+		keys = append(keys, t.ID)
+	}
+
+	return keys
+}
+
+// BatchItem is result of a batch transaction
+type BatchItem struct {
+	// Transaction as it was applied or recorded by the first request with the same key
+	Transaction Transaction
+	// Wallet state right after transaction was applied, set for applied and replayed transactions
+	Wallet  Wallet
+	Outcome BatchOutcome
+	// Reason transaction failed, set for failed transactions
+	Err error
+}
+
+// FailedItem returns result of transaction that failed with err
+func FailedItem(t Transaction, err error) BatchItem {
+	return BatchItem{Transaction: t, Outcome: BatchFailed, Err: err}
+}
+
+// CountOutcome returns number of batch items with outcome
+func CountOutcome(items []BatchItem, outcome BatchOutcome) int {
+	count := 0
+	for _, item := range items {
+		if item.Outcome == outcome {
+			count++
+		}
+	}
+
+	return count
+}
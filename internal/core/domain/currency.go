@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+var ErrCurrencyNotFound = NewError(KindNotFound, "CURRENCY_NOT_FOUND", "currency not found")
+var ErrInvalidCurrency = NewError(KindInvalidArgument, "INVALID_CURRENCY", "currency should be three-letter ISO 4217 code")
+
+// Currency is three-letter ISO 4217 code in canonical lowercase form,
+// values that come from outside should be created with ParseCurrency.
+type Currency string
+
+// ParseCurrency returns currency in canonical form, e.g. "USD" and " usd " are both "usd"
+func ParseCurrency(s string) (Currency, error) {
+	code := strings.ToLower(strings.TrimSpace(s))
+	if len(code) != 3 {
+		return "", fmt.Errorf("%w, got %q", ErrInvalidCurrency, s)
+	}
+	for _, r := range code {
+		if r < 'a' || r > 'z' {
+			return "", fmt.Errorf("%w, got %q", ErrInvalidCurrency, s)
+		}
+	}
+
+	return Currency(code), nil
+}
+
+// Valid reports whether currency is in canonical form
+func (c Currency) Valid() bool {
+	parsed, err := ParseCurrency(string(c))
+	return err == nil && parsed == c
+}
+
+// CurrencyInfo describes ISO 4217 currency in the catalog
+type CurrencyInfo struct {
+	// Three-letter alphabetic code, in lowercase
+	Code        Currency
+	NumericCode int
+	// Number of digits after the decimal separator of the minor unit, 2 for usd and 0 for jpy
+	Exponent int
+	// Wallets and transactions can be created only in enabled currencies
+	Enabled bool
+}
+
+// DefaultCurrencies are enabled until the catalog is loaded from storage
+var DefaultCurrencies = []CurrencyInfo{
+	{Code: "usd", NumericCode: 840, Exponent: 2, Enabled: true},
+	{Code: "eur", NumericCode: 978, Exponent: 2, Enabled: true},
+	{Code: "uah", NumericCode: 980, Exponent: 2, Enabled: true},
+	{Code: "jpy", NumericCode: 392, Exponent: 0, Enabled: true},
+}
@@ -0,0 +1,47 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/ximura/gowallet/internal/core/domain"
+	"gotest.tools/v3/assert"
+)
+
+func TestParseCurrency(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		currency domain.Currency
+		err      error
+	}{
+		"canonical":  {input: "usd", currency: "usd"},
+		"upper case": {input: "EUR", currency: "eur"},
+		"mixed case": {input: "jPy", currency: "jpy"},
+		"spaces":     {input: " uah\t", currency: "uah"},
+		"empty":      {input: "", err: domain.ErrInvalidCurrency},
+		"too short":  {input: "us", err: domain.ErrInvalidCurrency},
+		"too long":   {input: "usdt", err: domain.ErrInvalidCurrency},
+		"digits":     {input: "840", err: domain.ErrInvalidCurrency},
+		"non ascii":  {input: "usđ", err: domain.ErrInvalidCurrency},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			currency, err := domain.ParseCurrency(tt.input)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, currency, tt.currency)
+			assert.Assert(t, currency.Valid())
+		})
+	}
+}
+
+func TestCurrencyValid(t *testing.T) {
+	assert.Assert(t, domain.Currency("usd").Valid())
+	assert.Assert(t, !domain.Currency("USD").Valid())
+	assert.Assert(t, !domain.Currency(" usd").Valid())
+	assert.Assert(t, !domain.Currency("").Valid())
+}
@@ -0,0 +1,43 @@
+package domain
+
+// ErrorKind classifies domain errors, transport layer maps it to its status codes
+type ErrorKind int
+
+const (
+	KindInternal ErrorKind = iota
+	// Request is malformed regardless of system state
+	KindInvalidArgument
+	// Requested entity does not exist
+	KindNotFound
+	// Entity with the same key already exists
+	KindAlreadyExists
+	// System is not in a state required for the operation
+	KindFailedPrecondition
+	// Operation was aborted by concurrent update and can be retried
+	KindAborted
+	// Request doesn't have valid credentials
+	KindUnauthenticated
+	// Caller is not allowed to perform operation
+	KindPermissionDenied
+)
+
+// Error is expected failure of domain operation.
+// Reason is stable machine readable code clients can switch on, message is for humans.
+type Error struct {
+	Kind   ErrorKind
+	Reason string
+	msg    string
+}
+
+func NewError(kind ErrorKind, reason, msg string) *Error {
+	return &Error{Kind: kind, Reason: reason, msg: msg}
+}
+
+func (e *Error) Error() string {
+	return e.msg
+}
+
+var ErrWalletNotFound = NewError(KindNotFound, "WALLET_NOT_FOUND", "wallet not found")
+var ErrTransactionNotFound = NewError(KindNotFound, "TRANSACTION_NOT_FOUND", "transaction not found")
+var ErrHoldNotFound = NewError(KindNotFound, "HOLD_NOT_FOUND", "hold not found")
+var ErrConcurrentUpdate = NewError(KindAborted, "CONCURRENT_UPDATE", "aborted by concurrent update")
@@ -0,0 +1,163 @@
+package domain
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidRate = NewError(KindInvalidArgument, "INVALID_RATE", "exchange rate should be positive decimal with at most 9 fractional digits")
+var ErrRateNotAvailable = NewError(KindFailedPrecondition, "RATE_NOT_AVAILABLE", "exchange rate is not available")
+var ErrQuoteNotFound = NewError(KindNotFound, "QUOTE_NOT_FOUND", "quote not found")
+var ErrQuoteExpired = NewError(KindFailedPrecondition, "QUOTE_EXPIRED", "quote expired")
+var ErrQuoteMismatch = NewError(KindFailedPrecondition, "QUOTE_MISMATCH", "quote currencies different from transaction")
+
+// RateScale is number of Rate units in one, rates have 9 fractional digits
+const RateScale = 1_000_000_000
+
+// MaxSpreadBps is upper bound of spread, spread of 100% would make converted amount zero
+const MaxSpreadBps = 10_000
+
+// Rate is fixed-point price of one major unit of base currency in quote currency,
+// e.g. Rate(920_000_000) is 0.92.
+type Rate int64
+
+// ParseRate parses decimal rate, e.g. "0.92" or "41.2"
+func ParseRate(s string) (Rate, error) {
+	value, ok := new(big.Rat).SetString(strings.TrimSpace(s))
+	if !ok || value.Sign() <= 0 {
+		return 0, fmt.Errorf("%w, got %q", ErrInvalidRate, s)
+	}
+
+	value.Mul(value, new(big.Rat).SetInt64(RateScale))
+	if !value.IsInt() || !value.Num().IsInt64() {
+		return 0, fmt.Errorf("%w, got %q", ErrInvalidRate, s)
+	}
+
+	return Rate(value.Num().Int64()), nil
+}
+
+// String formats rate as decimal without trailing zeros
+func (r Rate) String() string {
+	s := new(big.Rat).SetFrac64(int64(r), RateScale).FloatString(9)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// Inverse returns rate of the opposite direction, rounded to Rate precision
+func (r Rate) Inverse() (Rate, error) {
+	if r <= 0 {
+		return 0, ErrInvalidRate
+	}
+
+	inverse := new(big.Int).Mul(big.NewInt(RateScale), big.NewInt(RateScale))
+	inverse.Quo(inverse.Add(inverse, big.NewInt(int64(r)/2)), big.NewInt(int64(r)))
+	if inverse.Sign() == 0 || !inverse.IsInt64() {
+		return 0, fmt.Errorf("%w: rate %s can't be inverted", ErrInvalidRate, r)
+	}
+
+	return Rate(inverse.Int64()), nil
+}
+
+// Quote locks exchange rate between two currencies until it expires
+type Quote struct {
+	ID uuid.UUID
+	// Currency of transaction amount
+	From Currency
+	// Currency of wallet amount is converted to
+	To   Currency
+	Rate Rate
+	// Spread charged on top of the rate, in basis points
+	SpreadBps int
+	ExpiresAt time.Time
+}
+
+// IsValid reports if quote can still be used at given time
+func (q Quote) IsValid(now time.Time) bool {
+	return now.Before(q.ExpiresAt)
+}
+
+// Conversion records exchange of transaction amount to wallet currency
+type Conversion struct {
+	// Amount and currency before conversion, as they were requested
+	FromAmount   Amount
+	FromCurrency Currency
+	// Amount and currency after conversion, applied to wallet
+	ToAmount   Amount
+	ToCurrency Currency
+	Rate       Rate
+	// Spread charged on top of the rate, in basis points
+	SpreadBps int
+	// Quote rate was locked with, uuid.Nil when current rate was used
+	QuoteID uuid.UUID
+}
+
+// Applied reports if amount was converted, conversion requests have only QuoteID set
+func (c *Conversion) Applied() bool {
+	return c != nil && c.ToCurrency != ""
+}
+
+// Convert returns conversion of amount given in minor units of currency with fromExponent digits
+// to currency with toExponent digits. Spread is charged in favour of the service:
+// credited amount is lowered and rounded down, debited amount is raised and rounded up.
+func (q Quote) Convert(amount Amount, fromExponent, toExponent int) (Conversion, error) {
+	if q.SpreadBps < 0 || q.SpreadBps >= MaxSpreadBps {
+		return Conversion{}, fmt.Errorf("%w: spread %d bps", ErrInvalidRate, q.SpreadBps)
+	}
+	if q.Rate <= 0 {
+		return Conversion{}, fmt.Errorf("%w: rate %s", ErrInvalidRate, q.Rate)
+	}
+
+	abs, err := amount.Abs()
+	if err != nil {
+		return Conversion{}, err
+	}
+
+	spread := int64(MaxSpreadBps - q.SpreadBps)
+	if amount < 0 {
+		spread = int64(MaxSpreadBps + q.SpreadBps)
+	}
+
+	num := new(big.Int).SetInt64(int64(abs))
+	num.Mul(num, big.NewInt(int64(q.Rate)))
+	num.Mul(num, big.NewInt(spread))
+	den := new(big.Int).Mul(big.NewInt(RateScale), big.NewInt(MaxSpreadBps))
+	if toExponent > fromExponent {
+		num.Mul(num, pow10(toExponent-fromExponent))
+	} else {
+		den.Mul(den, pow10(fromExponent-toExponent))
+	}
+
+	converted, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if amount < 0 && rem.Sign() != 0 {
+		converted.Add(converted, big.NewInt(1))
+	}
+	if !converted.IsInt64() || Amount(converted.Int64()) > MaxAmount {
+		return Conversion{}, fmt.Errorf("%w: %d %s can't be converted to %s", ErrAmountOverflow, amount, q.From, q.To)
+	}
+
+	result := Amount(converted.Int64())
+	if result == 0 && amount != 0 {
+		return Conversion{}, fmt.Errorf("%w: %d %s is too small to be converted to %s", ErrInvalidAmount, amount, q.From, q.To)
+	}
+	if amount < 0 {
+		result = -result
+	}
+
+	return Conversion{
+		FromAmount:   amount,
+		FromCurrency: q.From,
+		ToAmount:     result,
+		ToCurrency:   q.To,
+		Rate:         q.Rate,
+		SpreadBps:    q.SpreadBps,
+		QuoteID:      q.ID,
+	}, nil
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
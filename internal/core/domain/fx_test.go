@@ -0,0 +1,122 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ximura/gowallet/internal/core/domain"
+	"gotest.tools/v3/assert"
+)
+
+func TestParseRate(t *testing.T) {
+	tests := map[string]struct {
+		input string
+		rate  domain.Rate
+		err   error
+	}{
+		"decimal":          {input: "0.92", rate: 920_000_000},
+		"integer":          {input: "150", rate: 150_000_000_000},
+		"nine digits":      {input: "0.006644518", rate: 6_644_518},
+		"spaces":           {input: " 1.085 ", rate: 1_085_000_000},
+		"too precise":      {input: "0.0000000001", err: domain.ErrInvalidRate},
+		"zero":             {input: "0", err: domain.ErrInvalidRate},
+		"negative":         {input: "-1.5", err: domain.ErrInvalidRate},
+		"not a number":     {input: "rate", err: domain.ErrInvalidRate},
+		"out of fixed max": {input: "10000000000", err: domain.ErrInvalidRate},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			rate, err := domain.ParseRate(tt.input)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, rate, tt.rate)
+		})
+	}
+}
+
+func TestRateString(t *testing.T) {
+	assert.Equal(t, domain.Rate(920_000_000).String(), "0.92")
+	assert.Equal(t, domain.Rate(150_000_000_000).String(), "150")
+	assert.Equal(t, domain.Rate(1).String(), "0.000000001")
+}
+
+func TestRateInverse(t *testing.T) {
+	inverse, err := domain.Rate(1_250_000_000).Inverse()
+	assert.NilError(t, err)
+	assert.Equal(t, inverse, domain.Rate(800_000_000))
+
+	// rounded to the nearest unit
+	inverse, err = domain.Rate(3_000_000_000).Inverse()
+	assert.NilError(t, err)
+	assert.Equal(t, inverse, domain.Rate(333_333_333))
+
+	_, err = domain.Rate(0).Inverse()
+	assert.ErrorIs(t, err, domain.ErrInvalidRate)
+}
+
+func TestQuoteConvert(t *testing.T) {
+	eurUsd := domain.Quote{From: "eur", To: "usd", Rate: 1_085_000_000, SpreadBps: 50}
+	usdJpy := domain.Quote{From: "usd", To: "jpy", Rate: 150_500_000_000}
+	jpyUsd := domain.Quote{From: "jpy", To: "usd", Rate: 6_644_500}
+
+	tests := map[string]struct {
+		quote    domain.Quote
+		amount   domain.Amount
+		from, to int
+		result   domain.Amount
+		err      error
+	}{
+		// 10.00 * 1.085 * 0.995 = 10.79575
+		"credit rounds down": {quote: eurUsd, amount: 1000, from: 2, to: 2, result: 1079},
+		// 10.00 * 1.085 * 1.005 = 10.90425
+		"debit rounds up":         {quote: eurUsd, amount: -1000, from: 2, to: 2, result: -1091},
+		"to zero exponent":        {quote: usdJpy, amount: 1000, from: 2, to: 0, result: 1505},
+		"from zero exponent":      {quote: jpyUsd, amount: 1505, from: 0, to: 2, result: 999},
+		"zero amount":             {quote: eurUsd, amount: 0, from: 2, to: 2, result: 0},
+		"too small":               {quote: jpyUsd, amount: 1, from: 0, to: 2, err: domain.ErrInvalidAmount},
+		"overflow":                {quote: usdJpy, amount: domain.MaxAmount, from: 2, to: 0, err: domain.ErrAmountOverflow},
+		"min amount":              {quote: eurUsd, amount: domain.MinAmount, from: 2, to: 2, err: domain.ErrAmountOverflow},
+		"spread of whole amount":  {quote: domain.Quote{Rate: 1, SpreadBps: domain.MaxSpreadBps}, amount: 1, err: domain.ErrInvalidRate},
+		"not positive rate":       {quote: domain.Quote{Rate: 0}, amount: 1, err: domain.ErrInvalidRate},
+		"negative spread":         {quote: domain.Quote{Rate: 1, SpreadBps: -1}, amount: 1, err: domain.ErrInvalidRate},
+		"same exponent, no scale": {quote: domain.Quote{From: "usd", To: "eur", Rate: domain.RateScale}, amount: -7, from: 2, to: 2, result: -7},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			c, err := tt.quote.Convert(tt.amount, tt.from, tt.to)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, c.ToAmount, tt.result)
+			assert.Equal(t, c.FromAmount, tt.amount)
+			assert.Equal(t, c.FromCurrency, tt.quote.From)
+			assert.Equal(t, c.ToCurrency, tt.quote.To)
+			assert.Equal(t, c.Rate, tt.quote.Rate)
+			assert.Equal(t, c.SpreadBps, tt.quote.SpreadBps)
+		})
+	}
+}
+
+func TestQuoteIsValid(t *testing.T) {
+	now := time.Now()
+	q := domain.Quote{ID: uuid.New(), ExpiresAt: now.Add(time.Second)}
+	assert.Assert(t, q.IsValid(now))
+	assert.Assert(t, !q.IsValid(now.Add(time.Second)))
+}
+
+func TestConversionApplied(t *testing.T) {
+	var none *domain.Conversion
+	assert.Assert(t, !none.Applied())
+	assert.Assert(t, !(&domain.Conversion{QuoteID: uuid.New()}).Applied())
+	assert.Assert(t, (&domain.Conversion{ToAmount: 1, ToCurrency: "usd"}).Applied())
+}
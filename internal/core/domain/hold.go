@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrHoldNotActive = NewError(KindFailedPrecondition, "HOLD_NOT_ACTIVE", "hold is not active")
+
+type HoldStatus string
+
+const (
+	HoldActive   HoldStatus = "active"
+	HoldCaptured HoldStatus = "captured"
+	HoldReleased HoldStatus = "released"
+	HoldExpired  HoldStatus = "expired"
+)
+
+// Hold reserves wallet funds until it is captured, released or expired
+type Hold struct {
+	// Idempotency key, captured funds are recorded as transaction with the same id
+	ID       uuid.UUID
+	WalletID int
+	// Positive amount that is reserved
+	Amount   Amount
+	Currency Currency
+	Status   HoldStatus
+	// Amount that was debited from wallet on capture
+	CapturedAmount Amount
+	ExpiresAt      time.Time
+	CreatedAt      time.Time
+}
+
+// IsActive reports if hold still reserves funds at given time
+func (h Hold) IsActive(now time.Time) bool {
+	return h.Status == HoldActive && now.Before(h.ExpiresAt)
+}
+
+// Capture returns transaction that debits captured amount from wallet
+func (h Hold) Capture(amount Amount) Transaction {
+	return Transaction{
+		ID:       h.ID,
+		WalletID: h.WalletID,
+		Amount:   -amount,
+		Currency: h.Currency,
+	}
+}
@@ -0,0 +1,54 @@
+package domain
+
+var ErrDuplicateTransaction = NewError(KindAlreadyExists, "DUPLICATE_TRANSACTION", "duplicate transaction")
+var ErrIdempotencyKeyReused = NewError(KindAlreadyExists, "IDEMPOTENCY_KEY_REUSED", "idempotency key reused with different parameters")
+
+// Matches reports if stored transaction was recorded for the same request,
+// balance and creation time of stored transaction are not compared.
+// Converted transactions are compared by amount and currency they were requested with.
+func (t Transaction) Matches(stored Transaction) bool {
+	amount, currency := t.Requested()
+	storedAmount, storedCurrency := stored.Requested()
+	return t.WalletID == stored.WalletID &&
+		amount == storedAmount &&
+		currency == storedCurrency &&
+		t.CounterpartyWalletID == stored.CounterpartyWalletID &&
+		t.ReversalOf == stored.ReversalOf
+}
+
+// Matches reports if stored transactions are debit and credit legs of the same transfer
+func (t Transfer) Matches(stored []Transaction) bool {
+	if len(stored) != 2 {
+		return false
+	}
+
+	// legs as they were requested, before credit was converted
+	t.Conversion = nil
+	debit, credit := t.Transactions()
+	for _, s := range stored {
+		if !debit.Matches(s) && !credit.Matches(s) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Matches reports if stored transaction was recorded for the same refund
+func (r Refund) Matches(stored Transaction) bool {
+	amount := stored.Amount
+	if amount < 0 {
+		amount = -amount
+	}
+
+	return r.WalletID == stored.WalletID &&
+		r.TransactionID == stored.ReversalOf &&
+		r.Amount == amount
+}
+
+// Matches reports if stored hold was created for the same request, status and expiration are not compared
+func (h Hold) Matches(stored Hold) bool {
+	return h.WalletID == stored.WalletID &&
+		h.Amount == stored.Amount &&
+		h.Currency == stored.Currency
+}
@@ -0,0 +1,100 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ximura/gowallet/internal/core/domain"
+	"gotest.tools/v3/assert"
+)
+
+func TestTransactionMatches(t *testing.T) {
+	stored := domain.Transaction{ID: uuid.New(), WalletID: 1, Amount: 100, Currency: "usd", Balance: 100}
+
+	tests := map[string]struct {
+		request domain.Transaction
+		result  bool
+	}{
+		"same": {
+			request: domain.Transaction{ID: stored.ID, WalletID: 1, Amount: 100, Currency: "usd"},
+			result:  true,
+		},
+		"wallet": {
+			request: domain.Transaction{ID: stored.ID, WalletID: 2, Amount: 100, Currency: "usd"},
+		},
+		"amount": {
+			request: domain.Transaction{ID: stored.ID, WalletID: 1, Amount: -100, Currency: "usd"},
+		},
+		"currency": {
+			request: domain.Transaction{ID: stored.ID, WalletID: 1, Amount: 100, Currency: "eur"},
+		},
+		"transfer leg": {
+			request: domain.Transaction{ID: stored.ID, WalletID: 1, Amount: 100, Currency: "usd", CounterpartyWalletID: 2},
+		},
+		"conversion request in wallet currency": {
+			request: domain.Transaction{ID: stored.ID, WalletID: 1, Amount: 100, Currency: "usd", Conversion: &domain.Conversion{}},
+			result:  true,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.request.Matches(stored), tt.result)
+		})
+	}
+}
+
+func TestConvertedTransactionMatches(t *testing.T) {
+	stored := domain.Transaction{ID: uuid.New(), WalletID: 1, Amount: 1079, Currency: "usd", Balance: 1079,
+		Conversion: &domain.Conversion{FromAmount: 1000, FromCurrency: "eur", ToAmount: 1079, ToCurrency: "usd", Rate: 1_085_000_000, SpreadBps: 50}}
+
+	// retry is compared by requested amount, rate could change since the first attempt
+	assert.Assert(t, domain.Transaction{ID: stored.ID, WalletID: 1, Amount: 1000, Currency: "eur", Conversion: &domain.Conversion{}}.Matches(stored))
+	assert.Assert(t, !domain.Transaction{ID: stored.ID, WalletID: 1, Amount: 1079, Currency: "usd"}.Matches(stored))
+
+	// retry converted with a different rate
+	retry := stored
+	retry.Amount, retry.Conversion = 1081, &domain.Conversion{FromAmount: 1000, FromCurrency: "eur", ToAmount: 1081, ToCurrency: "usd", Rate: 1_086_000_000}
+	assert.Assert(t, retry.Matches(stored))
+}
+
+func TestTransferMatches(t *testing.T) {
+	transfer := domain.Transfer{ID: uuid.New(), FromWalletID: 1, ToWalletID: 2, Amount: 100, Currency: "usd"}
+	debit, credit := transfer.Transactions()
+
+	assert.Assert(t, transfer.Matches([]domain.Transaction{credit, debit}))
+	assert.Assert(t, !transfer.Matches([]domain.Transaction{debit}))
+
+	other := transfer
+	other.Amount = 50
+	assert.Assert(t, !other.Matches([]domain.Transaction{debit, credit}))
+
+	reversed := transfer
+	reversed.FromWalletID, reversed.ToWalletID = transfer.ToWalletID, transfer.FromWalletID
+	assert.Assert(t, !reversed.Matches([]domain.Transaction{debit, credit}))
+
+	converted := transfer
+	converted.Conversion = &domain.Conversion{FromAmount: 100, FromCurrency: "usd", ToAmount: 92, ToCurrency: "eur", Rate: 920_000_000}
+	debit, credit = converted.Transactions()
+	assert.Equal(t, credit.Amount, domain.Amount(92))
+	assert.Equal(t, credit.Currency, domain.Currency("eur"))
+	retry := transfer
+	retry.Conversion = &domain.Conversion{}
+	assert.Assert(t, retry.Matches([]domain.Transaction{debit, credit}))
+}
+
+func TestRefundMatches(t *testing.T) {
+	refund := domain.Refund{ID: uuid.New(), WalletID: 1, TransactionID: uuid.New(), Amount: 40}
+	stored := domain.Transaction{ID: refund.ID, WalletID: 1, Amount: -40, Currency: "usd", ReversalOf: refund.TransactionID}
+
+	assert.Assert(t, refund.Matches(stored))
+
+	other := refund
+	other.Amount = 30
+	assert.Assert(t, !other.Matches(stored))
+
+	other = refund
+	other.TransactionID = uuid.New()
+	assert.Assert(t, !other.Matches(stored))
+}
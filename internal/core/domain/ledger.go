@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+var ErrUnbalancedEntry = errors.New("unbalanced journal entry")
+var ErrLedgerMismatch = errors.New("wallet balance does not match ledger")
+
+// SystemAccount names ledger accounts that represent money outside of wallets
+type SystemAccount string
+
+const (
+	// Counterpart of funds credited to wallets from outside
+	ExternalFunding SystemAccount = "external_funding"
+	// Counterpart of funds debited from wallets to outside
+	ExternalWithdrawal SystemAccount = "external_withdrawal"
+	// Counterpart of balances that wallets had before the ledger was introduced
+	OpeningBalance SystemAccount = "opening_balance"
+	// Position of currency exchange, receives funds in one currency and pays in another
+	FXConversion SystemAccount = "fx_conversion"
+)
+
+type EntryKind string
+
+const (
+	EntryTransaction EntryKind = "transaction"
+	EntryTransfer    EntryKind = "transfer"
+	EntryReversal    EntryKind = "reversal"
+	// Balance wallet had before the ledger was introduced, it stands for history recorded before it
+	EntryOpeningBalance EntryKind = "opening_balance"
+)
+
+// LedgerAccount is either wallet account or system account in given currency
+type LedgerAccount struct {
+	// Wallet owning the account, zero for system accounts
+	WalletID int
+	System   SystemAccount
+	Currency Currency
+}
+
+func WalletAccount(walletID int, currency Currency) LedgerAccount {
+	return LedgerAccount{WalletID: walletID, Currency: currency}
+}
+
+func SystemLedgerAccount(system SystemAccount, currency Currency) LedgerAccount {
+	return LedgerAccount{System: system, Currency: currency}
+}
+
+func (a LedgerAccount) IsWallet() bool {
+	return a.WalletID != 0
+}
+
+// Posting changes account balance by signed amount,
+// positive amount increases balance of the account.
+type Posting struct {
+	Account LedgerAccount
+	Amount  Amount
+}
+
+// JournalEntry records single money movement as a set of postings,
+// postings of a valid entry sum up to zero in every currency.
+type JournalEntry struct {
+	TransactionID uuid.UUID
+	Kind          EntryKind
+	Postings      []Posting
+}
+
+// NewTransactionEntry moves funds between wallet and outside world,
+// reversal returns funds to the account the original transaction used.
+func NewTransactionEntry(t Transaction) JournalEntry {
+	kind := EntryTransaction
+	credit := t.Amount >= 0
+	if t.ReversalOf != uuid.Nil {
+		kind = EntryReversal
+		credit = !credit
+	}
+
+	external := ExternalFunding
+	if !credit {
+		external = ExternalWithdrawal
+	}
+
+	amount, currency := t.Requested()
+	postings := []Posting{
+		{Account: WalletAccount(t.WalletID, t.Currency), Amount: t.Amount},
+	}
+	postings = append(postings, conversionPostings(t.Conversion)...)
+	postings = append(postings, Posting{Account: SystemLedgerAccount(external, currency), Amount: -amount})
+
+	return JournalEntry{
+		TransactionID: t.ID,
+		Kind:          kind,
+		Postings:      postings,
+	}
+}
+
+// conversionPostings exchange converted amount through FX account of both currencies
+func conversionPostings(c *Conversion) []Posting {
+	if !c.Applied() {
+		return nil
+	}
+
+	return []Posting{
+		{Account: SystemLedgerAccount(FXConversion, c.ToCurrency), Amount: -c.ToAmount},
+		{Account: SystemLedgerAccount(FXConversion, c.FromCurrency), Amount: c.FromAmount},
+	}
+}
+
+// NewTransferEntry moves funds between two wallets
+func NewTransferEntry(t Transfer) JournalEntry {
+	debit, credit := t.Transactions()
+	postings := []Posting{
+		{Account: WalletAccount(debit.WalletID, debit.Currency), Amount: debit.Amount},
+	}
+	postings = append(postings, conversionPostings(credit.Conversion)...)
+	postings = append(postings, Posting{Account: WalletAccount(credit.WalletID, credit.Currency), Amount: credit.Amount})
+
+	return JournalEntry{
+		TransactionID: t.ID,
+		Kind:          EntryTransfer,
+		Postings:      postings,
+	}
+}
+
+func (e JournalEntry) Validate() error {
+	if len(e.Postings) < 2 {
+		return fmt.Errorf("%w: entry needs at least two postings", ErrUnbalancedEntry)
+	}
+
+	sum := make(map[Currency]Amount, 1)
+	for _, p := range e.Postings {
+		sum[p.Account.Currency] += p.Amount
+	}
+	for currency, amount := range sum {
+		if amount != 0 {
+			return fmt.Errorf("%w: %s postings sum up to %d", ErrUnbalancedEntry, currency, amount)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ximura/gowallet/internal/core/domain"
+	"gotest.tools/v3/assert"
+)
+
+func TestJournalEntryValidate(t *testing.T) {
+	tests := map[string]struct {
+		entry domain.JournalEntry
+		err   error
+	}{
+		"credit": {
+			entry: domain.NewTransactionEntry(domain.Transaction{ID: uuid.New(), WalletID: 1, Amount: 100, Currency: "usd"}),
+		},
+		"debit": {
+			entry: domain.NewTransactionEntry(domain.Transaction{ID: uuid.New(), WalletID: 1, Amount: -100, Currency: "usd"}),
+		},
+		"transfer": {
+			entry: domain.NewTransferEntry(domain.Transfer{ID: uuid.New(), FromWalletID: 1, ToWalletID: 2, Amount: 100, Currency: "usd"}),
+		},
+		"converted credit": {
+			entry: domain.NewTransactionEntry(domain.Transaction{ID: uuid.New(), WalletID: 1, Amount: 1079, Currency: "usd",
+				Conversion: &domain.Conversion{FromAmount: 1000, FromCurrency: "eur", ToAmount: 1079, ToCurrency: "usd", Rate: 1_085_000_000, SpreadBps: 50}}),
+		},
+		"converted transfer": {
+			entry: domain.NewTransferEntry(domain.Transfer{ID: uuid.New(), FromWalletID: 1, ToWalletID: 2, Amount: 1000, Currency: "eur",
+				Conversion: &domain.Conversion{FromAmount: 1000, FromCurrency: "eur", ToAmount: 1079, ToCurrency: "usd", Rate: 1_085_000_000, SpreadBps: 50}}),
+		},
+		"single posting": {
+			entry: domain.JournalEntry{Postings: []domain.Posting{
+				{Account: domain.WalletAccount(1, "usd"), Amount: 0},
+			}},
+			err: domain.ErrUnbalancedEntry,
+		},
+		"unbalanced": {
+			entry: domain.JournalEntry{Postings: []domain.Posting{
+				{Account: domain.WalletAccount(1, "usd"), Amount: 100},
+				{Account: domain.SystemLedgerAccount(domain.ExternalFunding, "usd"), Amount: -90},
+			}},
+			err: domain.ErrUnbalancedEntry,
+		},
+		"currency mismatch": {
+			entry: domain.JournalEntry{Postings: []domain.Posting{
+				{Account: domain.WalletAccount(1, "usd"), Amount: 100},
+				{Account: domain.SystemLedgerAccount(domain.ExternalFunding, "eur"), Amount: -100},
+			}},
+			err: domain.ErrUnbalancedEntry,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			err := tt.entry.Validate()
+			if tt.err == nil {
+				assert.NilError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestNewTransactionEntry(t *testing.T) {
+	credit := domain.NewTransactionEntry(domain.Transaction{WalletID: 1, Amount: 100, Currency: "usd"})
+	assert.Equal(t, credit.Postings[1].Account.System, domain.ExternalFunding)
+	assert.Equal(t, credit.Postings[1].Amount, domain.Amount(-100))
+
+	debit := domain.NewTransactionEntry(domain.Transaction{WalletID: 1, Amount: -100, Currency: "usd"})
+	assert.Equal(t, debit.Postings[1].Account.System, domain.ExternalWithdrawal)
+	assert.Equal(t, debit.Postings[1].Amount, domain.Amount(100))
+}
+
+func TestNewReversalEntry(t *testing.T) {
+	// refund of a credit returns funds to the funding account
+	entry := domain.NewTransactionEntry(domain.Transaction{WalletID: 1, Amount: -40, Currency: "usd", ReversalOf: uuid.New()})
+	assert.Equal(t, entry.Kind, domain.EntryReversal)
+	assert.Equal(t, entry.Postings[1].Account.System, domain.ExternalFunding)
+	assert.Equal(t, entry.Postings[1].Amount, domain.Amount(40))
+
+	entry = domain.NewTransactionEntry(domain.Transaction{WalletID: 1, Amount: 40, Currency: "usd", ReversalOf: uuid.New()})
+	assert.Equal(t, entry.Postings[1].Account.System, domain.ExternalWithdrawal)
+	assert.Equal(t, entry.Postings[1].Amount, domain.Amount(-40))
+}
+
+func TestNewConvertedTransactionEntry(t *testing.T) {
+	conversion := &domain.Conversion{FromAmount: 1000, FromCurrency: "eur", ToAmount: 1079, ToCurrency: "usd", Rate: 1_085_000_000, SpreadBps: 50}
+	entry := domain.NewTransactionEntry(domain.Transaction{WalletID: 1, Amount: 1079, Currency: "usd", Conversion: conversion})
+
+	assert.DeepEqual(t, entry.Postings, []domain.Posting{
+		{Account: domain.WalletAccount(1, "usd"), Amount: 1079},
+		{Account: domain.SystemLedgerAccount(domain.FXConversion, "usd"), Amount: -1079},
+		{Account: domain.SystemLedgerAccount(domain.FXConversion, "eur"), Amount: 1000},
+		{Account: domain.SystemLedgerAccount(domain.ExternalFunding, "eur"), Amount: -1000},
+	})
+}
+
+func TestNewConvertedTransferEntry(t *testing.T) {
+	conversion := &domain.Conversion{FromAmount: 1000, FromCurrency: "eur", ToAmount: 1079, ToCurrency: "usd", Rate: 1_085_000_000, SpreadBps: 50}
+	entry := domain.NewTransferEntry(domain.Transfer{FromWalletID: 1, ToWalletID: 2, Amount: 1000, Currency: "eur", Conversion: conversion})
+
+	assert.DeepEqual(t, entry.Postings, []domain.Posting{
+		{Account: domain.WalletAccount(1, "eur"), Amount: -1000},
+		{Account: domain.SystemLedgerAccount(domain.FXConversion, "usd"), Amount: -1079},
+		{Account: domain.SystemLedgerAccount(domain.FXConversion, "eur"), Amount: 1000},
+		{Account: domain.WalletAccount(2, "usd"), Amount: 1079},
+	})
+}
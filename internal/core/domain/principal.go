@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// Role grants principal permissions on service methods
+type Role string
+
+const (
+	// Customer uses wallets of own account, subject of customer principal is account id
+	RoleCustomer Role = "customer"
+	// Operator supports customers, reads any wallet, changes wallet status and refunds transactions
+	RoleOperator Role = "operator"
+	// System is a trusted integration that can call every method
+	RoleSystem Role = "system"
+)
+
+// Valid reports if role is one of known roles
+func (r Role) Valid() bool {
+	switch r {
+	case RoleCustomer, RoleOperator, RoleSystem:
+		return true
+	}
+	return false
+}
+
+// Principal is authenticated caller of the service
+type Principal struct {
+	// Name of API key or subject of JWT
+	Subject string
+	// How principal was authenticated, e.g. api_key or jwt
+	Method string
+	Roles  []Role
+}
+
+// HasRole reports if principal was granted role
+func (p Principal) HasRole(role Role) bool {
+	return slices.Contains(p.Roles, role)
+}
+
+// Account returns account principal acts for, false when subject is not account id
+func (p Principal) Account() (uuid.UUID, bool) {
+	account, err := uuid.Parse(p.Subject)
+	if err != nil || account == uuid.Nil {
+		return uuid.Nil, false
+	}
+
+	return account, true
+}
+
+type principalKey struct{}
+
+// ContextWithPrincipal returns context of request made by principal
+func ContextWithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext returns principal of authenticated request
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
@@ -0,0 +1,35 @@
+package domain
+
+import "github.com/google/uuid"
+
+// BalanceReport compares stored wallet balance with balances recomputed from history
+type BalanceReport struct {
+	WalletID int
+	Account  uuid.UUID
+	Currency Currency
+	// Balance stored on the wallet
+	Balance Amount
+	// Sum of all wallet transactions
+	HistoryBalance Amount
+	// Sum of all postings to wallet ledger account
+	LedgerBalance Amount
+}
+
+func (r BalanceReport) HasDrift() bool {
+	return r.Balance != r.HistoryBalance || r.Balance != r.LedgerBalance
+}
+
+type ReconcileFilter struct {
+	// Check wallets of single account, all wallets when uuid.Nil
+	Account uuid.UUID
+	// Return wallets with id greater than AfterID
+	AfterID int
+	Limit   int
+}
+
+type ReconciliationReport struct {
+	// Number of wallets that were checked
+	Checked int
+	// Wallets with balance different from history
+	Drift []BalanceReport
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+)
+
+var ErrNotRefundable = NewError(KindFailedPrecondition, "NOT_REFUNDABLE", "transaction can't be refunded")
+var ErrRefundExceedsOriginal = NewError(KindFailedPrecondition, "REFUND_EXCEEDS_ORIGINAL", "refund exceeds amount left on original transaction")
+
+// Refund returns part or whole amount of processed transaction
+type Refund struct {
+	// Idempotency key of refund transaction
+	ID       uuid.UUID
+	WalletID int
+	// Transaction that is refunded
+	TransactionID uuid.UUID
+	// Positive amount that is returned
+	Amount Amount
+}
+
+// Reversal returns transaction that moves refunded amount in the opposite direction of original,
+// refunded is amount already returned by previous refunds of the original transaction.
+func (r Refund) Reversal(original Transaction, refunded Amount) (Transaction, error) {
+	// transfers have the other leg on another wallet and refunds are final
+	if original.CounterpartyWalletID != 0 || original.ReversalOf != uuid.Nil {
+		return Transaction{}, ErrNotRefundable
+	}
+
+	amount, err := original.Amount.Abs()
+	if err != nil {
+		return Transaction{}, err
+	}
+	if r.Amount > amount-refunded {
+		return Transaction{}, ErrRefundExceedsOriginal
+	}
+
+	reversal := Transaction{
+		ID:         r.ID,
+		WalletID:   r.WalletID,
+		Amount:     -r.Amount,
+		Currency:   original.Currency,
+		ReversalOf: original.ID,
+	}
+	if original.Amount < 0 {
+		reversal.Amount = r.Amount
+	}
+
+	return reversal, nil
+}
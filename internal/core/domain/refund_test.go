@@ -0,0 +1,77 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ximura/gowallet/internal/core/domain"
+	"gotest.tools/v3/assert"
+)
+
+func TestRefundReversal(t *testing.T) {
+	credit := domain.Transaction{ID: uuid.New(), WalletID: 1, Amount: 100, Currency: "usd"}
+	debit := domain.Transaction{ID: uuid.New(), WalletID: 1, Amount: -100, Currency: "usd"}
+
+	tests := map[string]struct {
+		original domain.Transaction
+		refunded domain.Amount
+		amount   domain.Amount
+		result   domain.Amount
+		err      error
+	}{
+		"credit": {
+			original: credit,
+			amount:   40,
+			result:   -40,
+		},
+		"debit": {
+			original: debit,
+			amount:   40,
+			result:   40,
+		},
+		"rest of partially refunded": {
+			original: debit,
+			refunded: 60,
+			amount:   40,
+			result:   40,
+		},
+		"exceeds original": {
+			original: credit,
+			amount:   101,
+			err:      domain.ErrRefundExceedsOriginal,
+		},
+		"exceeds partially refunded": {
+			original: credit,
+			refunded: 60,
+			amount:   41,
+			err:      domain.ErrRefundExceedsOriginal,
+		},
+		"transfer": {
+			original: domain.Transaction{ID: uuid.New(), WalletID: 1, Amount: -100, Currency: "usd", CounterpartyWalletID: 2},
+			amount:   10,
+			err:      domain.ErrNotRefundable,
+		},
+		"refund": {
+			original: domain.Transaction{ID: uuid.New(), WalletID: 1, Amount: -100, Currency: "usd", ReversalOf: uuid.New()},
+			amount:   10,
+			err:      domain.ErrNotRefundable,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			refund := domain.Refund{ID: uuid.New(), WalletID: 1, TransactionID: tt.original.ID, Amount: tt.amount}
+			reversal, err := refund.Reversal(tt.original, tt.refunded)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, reversal.ID, refund.ID)
+			assert.Equal(t, reversal.Amount, tt.result)
+			assert.Equal(t, reversal.Currency, tt.original.Currency)
+			assert.Equal(t, reversal.ReversalOf, tt.original.ID)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+var ErrWalletFrozen = NewError(KindFailedPrecondition, "WALLET_FROZEN", "wallet is frozen")
+var ErrWalletClosed = NewError(KindFailedPrecondition, "WALLET_CLOSED", "wallet is closed")
+var ErrWalletNotEmpty = NewError(KindFailedPrecondition, "WALLET_NOT_EMPTY", "wallet with funds or active holds can't be closed")
+var ErrInvalidStatusChange = NewError(KindFailedPrecondition, "INVALID_STATUS_CHANGE", "wallet status can't be changed")
+var ErrStatusChangeIncomplete = NewError(KindInvalidArgument, "STATUS_CHANGE_INCOMPLETE", "actor and reason of wallet status change are required")
+
+type WalletStatus string
+
+const (
+	WalletActive WalletStatus = "active"
+	// Frozen wallet rejects debits, and credits too when they are blocked
+	WalletFrozen WalletStatus = "frozen"
+	// Closed wallet rejects all transactions, it can't be reopened
+	WalletClosed WalletStatus = "closed"
+)
+
+// StatusChange records who changed wallet status and why
+type StatusChange struct {
+	WalletID int
+	Status   WalletStatus
+	// Frozen wallet rejects credits as well as debits
+	BlockCredits bool
+	// Who changed the status
+	Actor     string
+	Reason    string
+	CreatedAt time.Time
+}
+
+// IsClosed reports if wallet was closed, wallets created before statuses were introduced are active
+func (w Wallet) IsClosed() bool {
+	return w.Status == WalletClosed
+}
+
+// canAccept checks that wallet status allows transaction amount
+func (w Wallet) canAccept(amount Amount) error {
+	switch {
+	case w.IsClosed():
+		return fmt.Errorf("%w: wallet %d", ErrWalletClosed, w.ID)
+	case w.Status == WalletFrozen && (amount < 0 || w.BlockCredits):
+		return fmt.Errorf("%w: wallet %d", ErrWalletFrozen, w.ID)
+	}
+
+	return nil
+}
+
+// CanChangeStatus checks that wallet can move to status of the change.
+// Frozen wallet can be frozen again to block or unblock credits, wallet can be closed
+// only when it and its pockets have no funds and no active holds.
+func (w Wallet) CanChangeStatus(change StatusChange) error {
+	if w.IsClosed() {
+		return fmt.Errorf("%w: wallet %d", ErrWalletClosed, w.ID)
+	}
+
+	switch change.Status {
+	case WalletFrozen:
+		return nil
+	case WalletActive:
+		if w.Status != WalletFrozen {
+			return fmt.Errorf("%w: wallet %d is not frozen", ErrInvalidStatusChange, w.ID)
+		}
+		return nil
+	case WalletClosed:
+		if w.Amount != 0 || w.Held != 0 {
+			return fmt.Errorf("%w: wallet %d has %d %s, %d held", ErrWalletNotEmpty, w.ID, w.Amount, w.Currency, w.Held)
+		}
+		for _, p := range w.Pockets {
+			if p.Amount != 0 || p.Held != 0 {
+				return fmt.Errorf("%w: pocket %d has %d %s, %d held", ErrWalletNotEmpty, p.WalletID, p.Amount, p.Currency, p.Held)
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("%w: unknown status %q", ErrInvalidStatusChange, change.Status)
+}
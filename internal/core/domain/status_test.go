@@ -0,0 +1,122 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/ximura/gowallet/internal/core/domain"
+	"gotest.tools/v3/assert"
+)
+
+func TestWalletStatusCanApply(t *testing.T) {
+	credit := domain.Transaction{WalletID: 1, Amount: 10, Currency: "usd"}
+	debit := domain.Transaction{WalletID: 1, Amount: -10, Currency: "usd"}
+
+	tests := map[string]struct {
+		wallet      domain.Wallet
+		transaction domain.Transaction
+		err         error
+	}{
+		"active": {
+			wallet:      domain.Wallet{ID: 1, Amount: 100, Currency: "usd", Status: domain.WalletActive},
+			transaction: debit,
+		},
+		"frozen credit": {
+			wallet:      domain.Wallet{ID: 1, Amount: 100, Currency: "usd", Status: domain.WalletFrozen},
+			transaction: credit,
+		},
+		"frozen debit": {
+			wallet:      domain.Wallet{ID: 1, Amount: 100, Currency: "usd", Status: domain.WalletFrozen},
+			transaction: debit,
+			err:         domain.ErrWalletFrozen,
+		},
+		"frozen blocked credit": {
+			wallet:      domain.Wallet{ID: 1, Amount: 100, Currency: "usd", Status: domain.WalletFrozen, BlockCredits: true},
+			transaction: credit,
+			err:         domain.ErrWalletFrozen,
+		},
+		"closed": {
+			wallet:      domain.Wallet{ID: 1, Currency: "usd", Status: domain.WalletClosed},
+			transaction: credit,
+			err:         domain.ErrWalletClosed,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			err := tt.wallet.CanApply(tt.transaction)
+			if tt.err == nil {
+				assert.NilError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestWalletCanChangeStatus(t *testing.T) {
+	tests := map[string]struct {
+		wallet domain.Wallet
+		status domain.WalletStatus
+		err    error
+	}{
+		"freeze": {
+			wallet: domain.Wallet{ID: 1, Amount: 100, Status: domain.WalletActive},
+			status: domain.WalletFrozen,
+		},
+		"freeze frozen": {
+			wallet: domain.Wallet{ID: 1, Status: domain.WalletFrozen},
+			status: domain.WalletFrozen,
+		},
+		"unfreeze": {
+			wallet: domain.Wallet{ID: 1, Status: domain.WalletFrozen},
+			status: domain.WalletActive,
+		},
+		"unfreeze active": {
+			wallet: domain.Wallet{ID: 1, Status: domain.WalletActive},
+			status: domain.WalletActive,
+			err:    domain.ErrInvalidStatusChange,
+		},
+		"close": {
+			wallet: domain.Wallet{ID: 1, Status: domain.WalletFrozen},
+			status: domain.WalletClosed,
+		},
+		"close with funds": {
+			wallet: domain.Wallet{ID: 1, Amount: 1, Status: domain.WalletActive},
+			status: domain.WalletClosed,
+			err:    domain.ErrWalletNotEmpty,
+		},
+		"close with held funds": {
+			wallet: domain.Wallet{ID: 1, Held: 1, Status: domain.WalletActive},
+			status: domain.WalletClosed,
+			err:    domain.ErrWalletNotEmpty,
+		},
+		"close with pocket funds": {
+			wallet: domain.Wallet{ID: 1, Status: domain.WalletActive, Pockets: []domain.Pocket{{WalletID: 2, Currency: "eur", Amount: 1}}},
+			status: domain.WalletClosed,
+			err:    domain.ErrWalletNotEmpty,
+		},
+		"closed": {
+			wallet: domain.Wallet{ID: 1, Status: domain.WalletClosed},
+			status: domain.WalletFrozen,
+			err:    domain.ErrWalletClosed,
+		},
+		"unknown status": {
+			wallet: domain.Wallet{ID: 1, Status: domain.WalletActive},
+			status: "deleted",
+			err:    domain.ErrInvalidStatusChange,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			err := tt.wallet.CanChangeStatus(domain.StatusChange{WalletID: tt.wallet.ID, Status: tt.status})
+			if tt.err == nil {
+				assert.NilError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Sign selects transactions by direction of the money movement
+type Sign int
+
+const (
+	SignAny Sign = iota
+	// Transactions that added funds to the wallet
+	SignCredit
+	// Transactions that removed funds from the wallet
+	SignDebit
+)
+
+// TransactionCursor points to the last transaction of a page,
+// transactions are ordered from newest to oldest
+type TransactionCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+type TransactionFilter struct {
+	WalletID int
+	// Lower bound (inclusive) of transaction time, ignored when zero
+	From time.Time
+	// Upper bound (exclusive) of transaction time, ignored when zero
+	To   time.Time
+	Sign Sign
+	// Maximum number of transactions to return
+	Limit int
+	// Return transactions older than cursor, starts from newest when nil
+	After *TransactionCursor
+}
+
+type TransactionPage struct {
+	Transactions []Transaction
+	// Cursor of the next page, nil when there are no more transactions
+	Next *TransactionCursor
+}
@@ -1,19 +1,226 @@
 package domain
 
-import "github.com/google/uuid"
+import (
+	"fmt"
+	"time"
 
-type Currency string
+	"github.com/google/uuid"
+)
+
+var ErrInvalidAmount = NewError(KindInvalidArgument, "INVALID_AMOUNT", "invalid transaction amount")
+var ErrInsufficientFunds = NewError(KindFailedPrecondition, "INSUFFICIENT_FUNDS", "insufficient funds")
+var ErrCurrencyMismatch = NewError(KindFailedPrecondition, "CURRENCY_MISMATCH", "wallet currency different from transaction")
 
 type Transaction struct {
 	ID       uuid.UUID
 	WalletID int
-	Amount   int
+	Amount   Amount
+	Currency Currency
+	// Wallet balance right after the transaction was applied
+	Balance Amount
+	// Other side of a transfer, zero for regular transactions
+	CounterpartyWalletID int
+	// Transaction that is refunded by this one, zero for regular transactions
+	ReversalOf uuid.UUID
+	// Requests set it to allow conversion to wallet currency, optionally with QuoteID of locked rate.
+	// Applied conversion is recorded when amount was converted, nil otherwise.
+	Conversion *Conversion
+	CreatedAt  time.Time
+}
+
+// Requested returns amount and currency transaction was requested with, before conversion
+func (t Transaction) Requested() (Amount, Currency) {
+	if t.Conversion.Applied() {
+		return t.Conversion.FromAmount, t.Conversion.FromCurrency
+	}
+
+	return t.Amount, t.Currency
+}
+
+// Transfer moves funds between two wallets, amount is in currency of source wallet
+type Transfer struct {
+	// Idempotency key, shared by debit and credit transactions
+	ID           uuid.UUID
+	FromWalletID int
+	ToWalletID   int
+	// Positive amount that is moved
+	Amount   Amount
 	Currency Currency
+	// Conversion of credited amount to currency of destination wallet, works as Transaction.Conversion
+	Conversion *Conversion
+}
+
+// Transactions returns debit and credit legs of the transfer
+func (t Transfer) Transactions() (Transaction, Transaction) {
+	debit := Transaction{
+		ID:                   t.ID,
+		WalletID:             t.FromWalletID,
+		Amount:               -t.Amount,
+		Currency:             t.Currency,
+		CounterpartyWalletID: t.ToWalletID,
+	}
+	credit := Transaction{
+		ID:                   t.ID,
+		WalletID:             t.ToWalletID,
+		Amount:               t.Amount,
+		Currency:             t.Currency,
+		CounterpartyWalletID: t.FromWalletID,
+	}
+	if t.Conversion.Applied() {
+		credit.Amount = t.Conversion.ToAmount
+		credit.Currency = t.Conversion.ToCurrency
+		credit.Conversion = t.Conversion
+	}
+
+	return debit, credit
 }
 
 type Wallet struct {
+	ID      int
+	Account uuid.UUID
+	// Ledger balance, includes funds reserved by holds
+	Amount   Amount
+	Currency Currency
+	// Funds reserved by active holds
+	Held Amount
+	// Multi-currency wallet keeps balances of other currencies in pockets
+	MultiCurrency bool
+	// Multi-currency wallet the pocket belongs to, zero for wallets
+	ParentID int
+	// Balances of multi-currency wallet in other currencies, ordered by currency
+	Pockets []Pocket
+	// Status is shared by multi-currency wallet and its pockets
+	Status WalletStatus
+	// Frozen wallet rejects credits as well as debits
+	BlockCredits bool
+	// Version increases with every change of wallet or its pockets
+	Version int64
+}
+
+// WalletChange notifies that wallet row was updated, ParentID is set for pockets
+type WalletChange struct {
+	WalletID int
+	ParentID int
+	Version  int64
+}
+
+// WalletOrder selects order of listed wallets, every order ends with wallet id so pages are stable
+type WalletOrder int
+
+const (
+	// Oldest wallets first
+	OrderOldest WalletOrder = iota
+	// Newest wallets first
+	OrderNewest
+	// Wallets ordered by currency, oldest first within a currency
+	OrderCurrency
+)
+
+// WalletCursor points to the last wallet of a page listed in Order
+type WalletCursor struct {
+	Order    WalletOrder
+	Currency Currency
 	ID       int
-	Account  uuid.UUID
-	Amount   int
+}
+
+type WalletFilter struct {
+	Account uuid.UUID
+	// Return wallets holding currency as base currency or in a pocket, ignored when empty
+	Currency Currency
+	Order    WalletOrder
+	// Maximum number of wallets to return
+	Limit int
+	// Return wallets following cursor, starts from the first wallet when nil
+	After *WalletCursor
+}
+
+type WalletPage struct {
+	Wallets []Wallet
+	// Cursor of the next page, nil when there are no more wallets
+	Next *WalletCursor
+}
+
+// Pocket is balance of multi-currency wallet in one currency.
+// Pocket is stored as child wallet, transactions in pocket currency are applied to it.
+type Pocket struct {
+	// Child wallet holding the balance
+	WalletID int
 	Currency Currency
+	Amount   Amount
+	Held     Amount
+}
+
+// Available returns balance that can be spent
+func (w Wallet) Available() Amount {
+	return w.Amount - w.Held
+}
+
+// Available returns pocket balance that can be spent
+func (p Pocket) Available() Amount {
+	return p.Amount - p.Held
+}
+
+// IsPocket reports if wallet holds balance of multi-currency wallet
+func (w Wallet) IsPocket() bool {
+	return w.ParentID != 0
+}
+
+// Pocket returns balance of multi-currency wallet in currency other than wallet one
+func (w Wallet) Pocket(currency Currency) (Pocket, bool) {
+	for _, p := range w.Pockets {
+		if p.Currency == currency {
+			return p, true
+		}
+	}
+
+	return Pocket{}, false
+}
+
+// PocketWallet returns child wallet holding balance of multi-currency wallet in currency
+func (w Wallet) PocketWallet(currency Currency) (Wallet, bool) {
+	p, ok := w.Pocket(currency)
+	if !ok {
+		return Wallet{}, false
+	}
+
+	return Wallet{
+		ID:           p.WalletID,
+		Account:      w.Account,
+		Amount:       p.Amount,
+		Currency:     p.Currency,
+		Held:         p.Held,
+		ParentID:     w.ID,
+		Status:       w.Status,
+		BlockCredits: w.BlockCredits,
+	}, true
+}
+
+// AsPocket returns balance of child wallet as pocket of its parent
+func (w Wallet) AsPocket() Pocket {
+	return Pocket{WalletID: w.ID, Currency: w.Currency, Amount: w.Amount, Held: w.Held}
+}
+
+// CanApply checks that transaction can be applied to the wallet,
+// debits can't spend funds reserved by holds and can't be applied to frozen or closed wallet.
+func (w Wallet) CanApply(t Transaction) error {
+	if err := w.canAccept(t.Amount); err != nil {
+		return err
+	}
+	if w.Currency != t.Currency {
+		return fmt.Errorf("%w, %s != %s", ErrCurrencyMismatch, w.Currency, t.Currency)
+	}
+
+	// amount has to be negatable to be recorded in the ledger
+	if _, err := t.Amount.Neg(); err != nil {
+		return err
+	}
+	if _, err := w.Amount.Add(t.Amount); err != nil {
+		return fmt.Errorf("%w: wallet %d balance %d can't change by %d", err, w.ID, w.Amount, t.Amount)
+	}
+
+	if t.Amount < 0 && w.Available()+t.Amount < 0 {
+		return ErrInsufficientFunds
+	}
+
+	return nil
 }
@@ -0,0 +1,72 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/ximura/gowallet/internal/core/domain"
+	"gotest.tools/v3/assert"
+)
+
+func TestWalletCanApply(t *testing.T) {
+	wallet := domain.Wallet{ID: 1, Amount: 100, Held: 30, Currency: "usd"}
+
+	tests := map[string]struct {
+		transaction domain.Transaction
+		err         error
+	}{
+		"credit": {
+			transaction: domain.Transaction{WalletID: 1, Amount: 10, Currency: "usd"},
+		},
+		"debit available": {
+			transaction: domain.Transaction{WalletID: 1, Amount: -70, Currency: "usd"},
+		},
+		"debit held funds": {
+			transaction: domain.Transaction{WalletID: 1, Amount: -71, Currency: "usd"},
+			err:         domain.ErrInsufficientFunds,
+		},
+		"balance overflow": {
+			transaction: domain.Transaction{WalletID: 1, Amount: domain.MaxAmount, Currency: "usd"},
+			err:         domain.ErrAmountOverflow,
+		},
+		"min amount": {
+			transaction: domain.Transaction{WalletID: 1, Amount: domain.MinAmount, Currency: "usd"},
+			err:         domain.ErrAmountOverflow,
+		},
+		"currency": {
+			transaction: domain.Transaction{WalletID: 1, Amount: 10, Currency: "eur"},
+			err:         domain.ErrCurrencyMismatch,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			err := wallet.CanApply(tt.transaction)
+			if tt.err == nil {
+				assert.NilError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestWalletPocketWallet(t *testing.T) {
+	wallet := domain.Wallet{
+		ID:            1,
+		Currency:      "eur",
+		MultiCurrency: true,
+		Pockets:       []domain.Pocket{{WalletID: 2, Currency: "usd", Amount: 100, Held: 30}},
+	}
+
+	pocket, ok := wallet.PocketWallet("usd")
+	assert.Assert(t, ok)
+	assert.Assert(t, pocket.IsPocket())
+	assert.Equal(t, pocket.ID, 2)
+	assert.Equal(t, pocket.ParentID, 1)
+	assert.Equal(t, pocket.Available(), domain.Amount(70))
+	assert.DeepEqual(t, pocket.AsPocket(), wallet.Pockets[0])
+
+	_, ok = wallet.PocketWallet("jpy")
+	assert.Assert(t, !ok)
+}
@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
@@ -36,6 +37,37 @@ func (m *MockWalletRepository) EXPECT() *MockWalletRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CaptureHold mocks base method.
+func (m *MockWalletRepository) CaptureHold(arg0 context.Context, arg1 domain.Hold, arg2 domain.Amount) (domain.Hold, domain.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CaptureHold", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.Hold)
+	ret1, _ := ret[1].(domain.Wallet)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CaptureHold indicates an expected call of CaptureHold.
+func (mr *MockWalletRepositoryMockRecorder) CaptureHold(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CaptureHold", reflect.TypeOf((*MockWalletRepository)(nil).CaptureHold), arg0, arg1, arg2)
+}
+
+// ChangeStatus mocks base method.
+func (m *MockWalletRepository) ChangeStatus(arg0 context.Context, arg1 domain.StatusChange) (domain.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeStatus", arg0, arg1)
+	ret0, _ := ret[0].(domain.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeStatus indicates an expected call of ChangeStatus.
+func (mr *MockWalletRepositoryMockRecorder) ChangeStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeStatus", reflect.TypeOf((*MockWalletRepository)(nil).ChangeStatus), arg0, arg1)
+}
+
 // Create mocks base method.
 func (m *MockWalletRepository) Create(arg0 context.Context, arg1 uuid.UUID, arg2 domain.Currency) (domain.Wallet, error) {
 	m.ctrl.T.Helper()
@@ -51,6 +83,66 @@ func (mr *MockWalletRepositoryMockRecorder) Create(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWalletRepository)(nil).Create), arg0, arg1, arg2)
 }
 
+// CreateHold mocks base method.
+func (m *MockWalletRepository) CreateHold(arg0 context.Context, arg1 domain.Hold) (domain.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHold", arg0, arg1)
+	ret0, _ := ret[0].(domain.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHold indicates an expected call of CreateHold.
+func (mr *MockWalletRepositoryMockRecorder) CreateHold(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHold", reflect.TypeOf((*MockWalletRepository)(nil).CreateHold), arg0, arg1)
+}
+
+// CreateMultiCurrency mocks base method.
+func (m *MockWalletRepository) CreateMultiCurrency(arg0 context.Context, arg1 uuid.UUID, arg2 domain.Currency) (domain.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMultiCurrency", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMultiCurrency indicates an expected call of CreateMultiCurrency.
+func (mr *MockWalletRepositoryMockRecorder) CreateMultiCurrency(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMultiCurrency", reflect.TypeOf((*MockWalletRepository)(nil).CreateMultiCurrency), arg0, arg1, arg2)
+}
+
+// ExpireHolds mocks base method.
+func (m *MockWalletRepository) ExpireHolds(arg0 context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireHolds", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireHolds indicates an expected call of ExpireHolds.
+func (mr *MockWalletRepositoryMockRecorder) ExpireHolds(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireHolds", reflect.TypeOf((*MockWalletRepository)(nil).ExpireHolds), arg0)
+}
+
+// FindTransactions mocks base method.
+func (m *MockWalletRepository) FindTransactions(arg0 context.Context, arg1 uuid.UUID) ([]domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTransactions", arg0, arg1)
+	ret0, _ := ret[0].([]domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTransactions indicates an expected call of FindTransactions.
+func (mr *MockWalletRepositoryMockRecorder) FindTransactions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTransactions", reflect.TypeOf((*MockWalletRepository)(nil).FindTransactions), arg0, arg1)
+}
+
 // Get mocks base method.
 func (m *MockWalletRepository) Get(arg0 context.Context, arg1 int) (domain.Wallet, error) {
 	m.ctrl.T.Helper()
@@ -66,23 +158,23 @@ func (mr *MockWalletRepositoryMockRecorder) Get(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockWalletRepository)(nil).Get), arg0, arg1)
 }
 
-// HasTransaction mocks base method.
-func (m *MockWalletRepository) HasTransaction(arg0 context.Context, arg1 domain.Transaction) (bool, error) {
+// GetHold mocks base method.
+func (m *MockWalletRepository) GetHold(arg0 context.Context, arg1 uuid.UUID) (domain.Hold, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HasTransaction", arg0, arg1)
-	ret0, _ := ret[0].(bool)
+	ret := m.ctrl.Call(m, "GetHold", arg0, arg1)
+	ret0, _ := ret[0].(domain.Hold)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// HasTransaction indicates an expected call of HasTransaction.
-func (mr *MockWalletRepositoryMockRecorder) HasTransaction(arg0, arg1 interface{}) *gomock.Call {
+// GetHold indicates an expected call of GetHold.
+func (mr *MockWalletRepositoryMockRecorder) GetHold(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasTransaction", reflect.TypeOf((*MockWalletRepository)(nil).HasTransaction), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHold", reflect.TypeOf((*MockWalletRepository)(nil).GetHold), arg0, arg1)
 }
 
 // List mocks base method.
-func (m *MockWalletRepository) List(arg0 context.Context, arg1 uuid.UUID) ([]domain.Wallet, error) {
+func (m *MockWalletRepository) List(arg0 context.Context, arg1 domain.WalletFilter) ([]domain.Wallet, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "List", arg0, arg1)
 	ret0, _ := ret[0].([]domain.Wallet)
@@ -96,6 +188,36 @@ func (mr *MockWalletRepositoryMockRecorder) List(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockWalletRepository)(nil).List), arg0, arg1)
 }
 
+// ListTransactions mocks base method.
+func (m *MockWalletRepository) ListTransactions(arg0 context.Context, arg1 domain.TransactionFilter) ([]domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransactions", arg0, arg1)
+	ret0, _ := ret[0].([]domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransactions indicates an expected call of ListTransactions.
+func (mr *MockWalletRepositoryMockRecorder) ListTransactions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransactions", reflect.TypeOf((*MockWalletRepository)(nil).ListTransactions), arg0, arg1)
+}
+
+// ProcessBatch mocks base method.
+func (m *MockWalletRepository) ProcessBatch(arg0 context.Context, arg1 domain.Batch) ([]domain.BatchItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessBatch", arg0, arg1)
+	ret0, _ := ret[0].([]domain.BatchItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProcessBatch indicates an expected call of ProcessBatch.
+func (mr *MockWalletRepositoryMockRecorder) ProcessBatch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessBatch", reflect.TypeOf((*MockWalletRepository)(nil).ProcessBatch), arg0, arg1)
+}
+
 // ProcessTransaction mocks base method.
 func (m *MockWalletRepository) ProcessTransaction(arg0 context.Context, arg1 domain.Transaction) (domain.Wallet, error) {
 	m.ctrl.T.Helper()
@@ -110,3 +232,343 @@ func (mr *MockWalletRepositoryMockRecorder) ProcessTransaction(arg0, arg1 interf
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessTransaction", reflect.TypeOf((*MockWalletRepository)(nil).ProcessTransaction), arg0, arg1)
 }
+
+// Refund mocks base method.
+func (m *MockWalletRepository) Refund(arg0 context.Context, arg1 domain.Refund) (domain.Transaction, domain.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refund", arg0, arg1)
+	ret0, _ := ret[0].(domain.Transaction)
+	ret1, _ := ret[1].(domain.Wallet)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Refund indicates an expected call of Refund.
+func (mr *MockWalletRepositoryMockRecorder) Refund(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refund", reflect.TypeOf((*MockWalletRepository)(nil).Refund), arg0, arg1)
+}
+
+// ReleaseHold mocks base method.
+func (m *MockWalletRepository) ReleaseHold(arg0 context.Context, arg1 uuid.UUID) (domain.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseHold", arg0, arg1)
+	ret0, _ := ret[0].(domain.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseHold indicates an expected call of ReleaseHold.
+func (mr *MockWalletRepositoryMockRecorder) ReleaseHold(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseHold", reflect.TypeOf((*MockWalletRepository)(nil).ReleaseHold), arg0, arg1)
+}
+
+// Transfer mocks base method.
+func (m *MockWalletRepository) Transfer(arg0 context.Context, arg1 domain.Transfer) (domain.Wallet, domain.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transfer", arg0, arg1)
+	ret0, _ := ret[0].(domain.Wallet)
+	ret1, _ := ret[1].(domain.Wallet)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Transfer indicates an expected call of Transfer.
+func (mr *MockWalletRepositoryMockRecorder) Transfer(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transfer", reflect.TypeOf((*MockWalletRepository)(nil).Transfer), arg0, arg1)
+}
+
+// MockReconciliationRepository is a mock of ReconciliationRepository interface.
+type MockReconciliationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReconciliationRepositoryMockRecorder
+}
+
+// MockReconciliationRepositoryMockRecorder is the mock recorder for MockReconciliationRepository.
+type MockReconciliationRepositoryMockRecorder struct {
+	mock *MockReconciliationRepository
+}
+
+// NewMockReconciliationRepository creates a new mock instance.
+func NewMockReconciliationRepository(ctrl *gomock.Controller) *MockReconciliationRepository {
+	mock := &MockReconciliationRepository{ctrl: ctrl}
+	mock.recorder = &MockReconciliationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReconciliationRepository) EXPECT() *MockReconciliationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// RecomputeBalances mocks base method.
+func (m *MockReconciliationRepository) RecomputeBalances(arg0 context.Context, arg1 domain.ReconcileFilter) ([]domain.BalanceReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecomputeBalances", arg0, arg1)
+	ret0, _ := ret[0].([]domain.BalanceReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecomputeBalances indicates an expected call of RecomputeBalances.
+func (mr *MockReconciliationRepositoryMockRecorder) RecomputeBalances(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecomputeBalances", reflect.TypeOf((*MockReconciliationRepository)(nil).RecomputeBalances), arg0, arg1)
+}
+
+// MockCurrencyRepository is a mock of CurrencyRepository interface.
+type MockCurrencyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCurrencyRepositoryMockRecorder
+}
+
+// MockCurrencyRepositoryMockRecorder is the mock recorder for MockCurrencyRepository.
+type MockCurrencyRepositoryMockRecorder struct {
+	mock *MockCurrencyRepository
+}
+
+// NewMockCurrencyRepository creates a new mock instance.
+func NewMockCurrencyRepository(ctrl *gomock.Controller) *MockCurrencyRepository {
+	mock := &MockCurrencyRepository{ctrl: ctrl}
+	mock.recorder = &MockCurrencyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCurrencyRepository) EXPECT() *MockCurrencyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ListCurrencies mocks base method.
+func (m *MockCurrencyRepository) ListCurrencies(arg0 context.Context) ([]domain.CurrencyInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCurrencies", arg0)
+	ret0, _ := ret[0].([]domain.CurrencyInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCurrencies indicates an expected call of ListCurrencies.
+func (mr *MockCurrencyRepositoryMockRecorder) ListCurrencies(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCurrencies", reflect.TypeOf((*MockCurrencyRepository)(nil).ListCurrencies), arg0)
+}
+
+// SetCurrencyEnabled mocks base method.
+func (m *MockCurrencyRepository) SetCurrencyEnabled(arg0 context.Context, arg1 domain.Currency, arg2 bool) (domain.CurrencyInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCurrencyEnabled", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.CurrencyInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCurrencyEnabled indicates an expected call of SetCurrencyEnabled.
+func (mr *MockCurrencyRepositoryMockRecorder) SetCurrencyEnabled(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCurrencyEnabled", reflect.TypeOf((*MockCurrencyRepository)(nil).SetCurrencyEnabled), arg0, arg1, arg2)
+}
+
+// MockQuoteRepository is a mock of QuoteRepository interface.
+type MockQuoteRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockQuoteRepositoryMockRecorder
+}
+
+// MockQuoteRepositoryMockRecorder is the mock recorder for MockQuoteRepository.
+type MockQuoteRepositoryMockRecorder struct {
+	mock *MockQuoteRepository
+}
+
+// NewMockQuoteRepository creates a new mock instance.
+func NewMockQuoteRepository(ctrl *gomock.Controller) *MockQuoteRepository {
+	mock := &MockQuoteRepository{ctrl: ctrl}
+	mock.recorder = &MockQuoteRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQuoteRepository) EXPECT() *MockQuoteRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateQuote mocks base method.
+func (m *MockQuoteRepository) CreateQuote(arg0 context.Context, arg1 domain.Quote) (domain.Quote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateQuote", arg0, arg1)
+	ret0, _ := ret[0].(domain.Quote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateQuote indicates an expected call of CreateQuote.
+func (mr *MockQuoteRepositoryMockRecorder) CreateQuote(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateQuote", reflect.TypeOf((*MockQuoteRepository)(nil).CreateQuote), arg0, arg1)
+}
+
+// DeleteExpiredQuotes mocks base method.
+func (m *MockQuoteRepository) DeleteExpiredQuotes(arg0 context.Context, arg1 time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredQuotes", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredQuotes indicates an expected call of DeleteExpiredQuotes.
+func (mr *MockQuoteRepositoryMockRecorder) DeleteExpiredQuotes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredQuotes", reflect.TypeOf((*MockQuoteRepository)(nil).DeleteExpiredQuotes), arg0, arg1)
+}
+
+// GetQuote mocks base method.
+func (m *MockQuoteRepository) GetQuote(arg0 context.Context, arg1 uuid.UUID) (domain.Quote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuote", arg0, arg1)
+	ret0, _ := ret[0].(domain.Quote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuote indicates an expected call of GetQuote.
+func (mr *MockQuoteRepositoryMockRecorder) GetQuote(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuote", reflect.TypeOf((*MockQuoteRepository)(nil).GetQuote), arg0, arg1)
+}
+
+// MockRateProvider is a mock of RateProvider interface.
+type MockRateProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateProviderMockRecorder
+}
+
+// MockRateProviderMockRecorder is the mock recorder for MockRateProvider.
+type MockRateProviderMockRecorder struct {
+	mock *MockRateProvider
+}
+
+// NewMockRateProvider creates a new mock instance.
+func NewMockRateProvider(ctrl *gomock.Controller) *MockRateProvider {
+	mock := &MockRateProvider{ctrl: ctrl}
+	mock.recorder = &MockRateProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateProvider) EXPECT() *MockRateProviderMockRecorder {
+	return m.recorder
+}
+
+// Rate mocks base method.
+func (m *MockRateProvider) Rate(arg0 context.Context, arg1, arg2 domain.Currency) (domain.Rate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.Rate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rate indicates an expected call of Rate.
+func (mr *MockRateProviderMockRecorder) Rate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rate", reflect.TypeOf((*MockRateProvider)(nil).Rate), arg0, arg1, arg2)
+}
+
+// MockWalletFeed is a mock of WalletFeed interface.
+type MockWalletFeed struct {
+	ctrl     *gomock.Controller
+	recorder *MockWalletFeedMockRecorder
+}
+
+// MockWalletFeedMockRecorder is the mock recorder for MockWalletFeed.
+type MockWalletFeedMockRecorder struct {
+	mock *MockWalletFeed
+}
+
+// NewMockWalletFeed creates a new mock instance.
+func NewMockWalletFeed(ctrl *gomock.Controller) *MockWalletFeed {
+	mock := &MockWalletFeed{ctrl: ctrl}
+	mock.recorder = &MockWalletFeedMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWalletFeed) EXPECT() *MockWalletFeedMockRecorder {
+	return m.recorder
+}
+
+// Subscribe mocks base method.
+func (m *MockWalletFeed) Subscribe(arg0 context.Context, arg1 int) <-chan struct{} {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", arg0, arg1)
+	ret0, _ := ret[0].(<-chan struct{})
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockWalletFeedMockRecorder) Subscribe(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockWalletFeed)(nil).Subscribe), arg0, arg1)
+}
+
+// MockWalletMetrics is a mock of WalletMetrics interface.
+type MockWalletMetrics struct {
+	ctrl     *gomock.Controller
+	recorder *MockWalletMetricsMockRecorder
+}
+
+// MockWalletMetricsMockRecorder is the mock recorder for MockWalletMetrics.
+type MockWalletMetricsMockRecorder struct {
+	mock *MockWalletMetrics
+}
+
+// NewMockWalletMetrics creates a new mock instance.
+func NewMockWalletMetrics(ctrl *gomock.Controller) *MockWalletMetrics {
+	mock := &MockWalletMetrics{ctrl: ctrl}
+	mock.recorder = &MockWalletMetricsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWalletMetrics) EXPECT() *MockWalletMetricsMockRecorder {
+	return m.recorder
+}
+
+// DuplicateKey mocks base method.
+func (m *MockWalletMetrics) DuplicateKey() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DuplicateKey")
+}
+
+// DuplicateKey indicates an expected call of DuplicateKey.
+func (mr *MockWalletMetricsMockRecorder) DuplicateKey() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DuplicateKey", reflect.TypeOf((*MockWalletMetrics)(nil).DuplicateKey))
+}
+
+// TransactionProcessed mocks base method.
+func (m *MockWalletMetrics) TransactionProcessed(arg0 domain.Transaction) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TransactionProcessed", arg0)
+}
+
+// TransactionProcessed indicates an expected call of TransactionProcessed.
+func (mr *MockWalletMetricsMockRecorder) TransactionProcessed(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransactionProcessed", reflect.TypeOf((*MockWalletMetrics)(nil).TransactionProcessed), arg0)
+}
+
+// TransactionRejected mocks base method.
+func (m *MockWalletMetrics) TransactionRejected(reason string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TransactionRejected", reason)
+}
+
+// TransactionRejected indicates an expected call of TransactionRejected.
+func (mr *MockWalletMetricsMockRecorder) TransactionRejected(reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransactionRejected", reflect.TypeOf((*MockWalletMetrics)(nil).TransactionRejected), reason)
+}
@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/ximura/gowallet/internal/core/domain"
@@ -11,12 +12,80 @@ import (
 type WalletRepository interface {
 	// Creates new wallet for account
 	Create(context.Context, uuid.UUID, domain.Currency) (domain.Wallet, error)
-	// Return  list of wallets linked to account
-	List(context.Context, uuid.UUID) ([]domain.Wallet, error)
-	// Return current state of account wallet
+	// Creates new multi-currency wallet for account with base currency
+	CreateMultiCurrency(context.Context, uuid.UUID, domain.Currency) (domain.Wallet, error)
+	// Return page of account wallets matching filter with their pockets, in filter order
+	List(context.Context, domain.WalletFilter) ([]domain.Wallet, error)
+	// Return current state of account wallet, multi-currency wallet is returned with its pockets
 	Get(context.Context, int) (domain.Wallet, error)
-	// Check if a transaction with the same id was already processed
-	HasTransaction(context.Context, domain.Transaction) (bool, error)
-	// Execute transaction for account wallet
+	// Return transactions recorded with idempotency key, transfer has a transaction per wallet
+	FindTransactions(context.Context, uuid.UUID) ([]domain.Transaction, error)
+	// Execute transaction for account wallet after checking currency and available funds of locked wallet,
+	// returns domain.ErrDuplicateTransaction when key was already used.
+	// Credit of multi-currency wallet in currency it has no pocket for creates the pocket in the same transaction.
 	ProcessTransaction(context.Context, domain.Transaction) (domain.Wallet, error)
+	// Execute transactions of batch in a single database transaction, returns result of every transaction in batch order.
+	// Transactions with used idempotency keys are replayed, atomic batch with failed transactions is rolled back.
+	// Credit of multi-currency wallet in currency it has no pocket for creates the pocket in the same transaction.
+	ProcessBatch(context.Context, domain.Batch) ([]domain.BatchItem, error)
+	// Move funds between wallets in a single database transaction, pocket of destination is created as for credit
+	Transfer(context.Context, domain.Transfer) (domain.Wallet, domain.Wallet, error)
+	// Return wallet transactions matching filter, newest first
+	ListTransactions(context.Context, domain.TransactionFilter) ([]domain.Transaction, error)
+	// Record reversal of original transaction, refund total is checked against original amount
+	Refund(context.Context, domain.Refund) (domain.Transaction, domain.Wallet, error)
+	// Reserve available wallet funds
+	CreateHold(context.Context, domain.Hold) (domain.Hold, error)
+	// Return hold by id
+	GetHold(context.Context, uuid.UUID) (domain.Hold, error)
+	// Debit captured amount from wallet and close active hold
+	CaptureHold(context.Context, domain.Hold, domain.Amount) (domain.Hold, domain.Wallet, error)
+	// Close active hold without debiting wallet
+	ReleaseHold(context.Context, uuid.UUID) (domain.Hold, error)
+	// Close active holds that are past expiration time, returns number of expired holds
+	ExpireHolds(context.Context) (int, error)
+	// Change status of locked wallet and its pockets, records who changed it and why
+	ChangeStatus(context.Context, domain.StatusChange) (domain.Wallet, error)
+}
+
+type ReconciliationRepository interface {
+	// Return stored and recomputed balances of wallets ordered by id
+	RecomputeBalances(context.Context, domain.ReconcileFilter) ([]domain.BalanceReport, error)
+}
+
+type CurrencyRepository interface {
+	// Return currency catalog ordered by code
+	ListCurrencies(context.Context) ([]domain.CurrencyInfo, error)
+	// Enable or disable currency, returns domain.ErrCurrencyNotFound for unknown code
+	SetCurrencyEnabled(context.Context, domain.Currency, bool) (domain.CurrencyInfo, error)
+}
+
+type QuoteRepository interface {
+	// Store quote so transactions can reference it until it expires
+	CreateQuote(context.Context, domain.Quote) (domain.Quote, error)
+	// Return quote by id, returns domain.ErrQuoteNotFound for unknown id
+	GetQuote(context.Context, uuid.UUID) (domain.Quote, error)
+	// Delete quotes expired before time that no transaction references, returns number of deleted quotes
+	DeleteExpiredQuotes(context.Context, time.Time) (int, error)
+}
+
+type RateProvider interface {
+	// Return mid-market rate of one unit of the first currency in the second one,
+	// returns domain.ErrRateNotAvailable for unknown pair
+	Rate(context.Context, domain.Currency, domain.Currency) (domain.Rate, error)
+}
+
+type WalletFeed interface {
+	// Subscribe returns channel that receives a value when wallet or its pockets may have changed,
+	// channel is closed when context is done
+	Subscribe(context.Context, int) <-chan struct{}
+}
+
+type WalletMetrics interface {
+	// TransactionProcessed records transaction applied to wallet, amount is in wallet currency
+	TransactionProcessed(domain.Transaction)
+	// TransactionRejected records reason of domain error transaction failed with
+	TransactionRejected(reason string)
+	// DuplicateKey records request with idempotency key that was already processed
+	DuplicateKey()
 }
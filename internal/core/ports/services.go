@@ -10,10 +10,53 @@ import (
 type WalletService interface {
 	// Creates new wallet for account
 	Create(context.Context, uuid.UUID, domain.Currency) (domain.Wallet, error)
+	// Creates new multi-currency wallet for account, other currencies are kept in pockets
+	CreateMultiCurrency(context.Context, uuid.UUID, domain.Currency) (domain.Wallet, error)
 	// Return current state of account wallet
 	Get(context.Context, int) (domain.Wallet, error)
-	// Return  list of wallets linked to account
-	List(context.Context, uuid.UUID) ([]domain.Wallet, error)
+	// Return page of wallets linked to account
+	List(context.Context, domain.WalletFilter) (domain.WalletPage, error)
 	// Execute transaction for account wallet
 	ProcessTransaction(context.Context, domain.Transaction) (domain.Wallet, error)
+	// Execute transactions together, returns result of every transaction in batch order
+	ProcessBatch(context.Context, domain.Batch) ([]domain.BatchItem, error)
+	// Move funds between wallets, returns updated source and destination wallets
+	Transfer(context.Context, domain.Transfer) (domain.Wallet, domain.Wallet, error)
+	// Send current wallet state and then state after every change, newer than given version, until context is done
+	WatchWallet(context.Context, int, int64, func(domain.Wallet) error) error
+	// Return page of wallet transactions history, newest first
+	ListTransactions(context.Context, domain.TransactionFilter) (domain.TransactionPage, error)
+	// Return part of processed transaction amount, returns refund transaction and updated wallet
+	Refund(context.Context, domain.Refund) (domain.Transaction, domain.Wallet, error)
+	// Reserve wallet funds until hold is captured, released or expired
+	Hold(context.Context, domain.Hold) (domain.Hold, error)
+	// Debit reserved funds from wallet, zero amount captures whole hold
+	CaptureHold(context.Context, uuid.UUID, domain.Amount) (domain.Hold, domain.Wallet, error)
+	// Return reserved funds to available balance
+	ReleaseHold(context.Context, uuid.UUID) (domain.Hold, error)
+	// Stop debits of wallet, and credits when change blocks them
+	FreezeWallet(context.Context, domain.StatusChange) (domain.Wallet, error)
+	// Allow transactions of frozen wallet again
+	UnfreezeWallet(context.Context, domain.StatusChange) (domain.Wallet, error)
+	// Retire wallet without funds and active holds, closed wallet rejects all transactions
+	CloseWallet(context.Context, domain.StatusChange) (domain.Wallet, error)
+}
+
+type ReconciliationService interface {
+	// Recompute wallet balances of account from history, uuid.Nil checks all wallets
+	Reconcile(context.Context, uuid.UUID) (domain.ReconciliationReport, error)
+}
+
+type CurrencyService interface {
+	// Return currency catalog ordered by code
+	ListCurrencies(context.Context) ([]domain.CurrencyInfo, error)
+	// Return currency by code, returns domain.ErrCurrencyNotFound for unknown code
+	GetCurrency(context.Context, domain.Currency) (domain.CurrencyInfo, error)
+	// Enable or disable currency for new wallets and transactions
+	SetCurrencyEnabled(context.Context, domain.Currency, bool) (domain.CurrencyInfo, error)
+}
+
+type FXService interface {
+	// Lock current rate of the first currency in the second one for a limited time
+	Quote(context.Context, domain.Currency, domain.Currency) (domain.Quote, error)
 }
@@ -0,0 +1,159 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+// CertReloader serves certificate and client CA bundle read from files,
+// files are read again when their modification time or size changes.
+type CertReloader struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	// stamps of files at the last load attempt, broken files are not read again until they change
+	stamps []fileStamp
+}
+
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// NewCertReloader returns reloader of certificate and key files, client certificates are verified
+// against CA bundle when clientCAFile is set
+func NewCertReloader(certFile, keyFile, clientCAFile string) (*CertReloader, error) {
+	r := &CertReloader{certFile: certFile, keyFile: keyFile, clientCAFile: clientCAFile}
+	if err := r.Load(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Load reads files again, certificate and CAs loaded before are kept when files are invalid
+func (r *CertReloader) Load() error {
+	// files are stamped before they are read, so change made during load is picked by the next check
+	stamps, err := r.stat()
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	r.stamps = stamps
+	r.mu.Unlock()
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("can't load TLS certificate: %w", err)
+	}
+
+	var clientCAs *x509.CertPool
+	if r.clientCAFile != "" {
+		data, err := os.ReadFile(r.clientCAFile)
+		if err != nil {
+			return fmt.Errorf("can't read client CA file: %w", err)
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(data) {
+			return fmt.Errorf("client CA file %s has no PEM certificates", r.clientCAFile)
+		}
+	}
+
+	r.mu.Lock()
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	r.mu.Unlock()
+	return nil
+}
+
+// Changed reports if any file changed since the last load
+func (r *CertReloader) Changed() (bool, error) {
+	stamps, err := r.stat()
+	if err != nil {
+		return false, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return !slices.Equal(stamps, r.stamps), nil
+}
+
+// RunReload checks files every interval and loads them when they change, until context is canceled
+func (r *CertReloader) RunReload(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			changed, err := r.Changed()
+			if err != nil {
+				log.Println(fmt.Errorf("can't check TLS files %w", err))
+				continue
+			}
+			if !changed {
+				continue
+			}
+			if err := r.Load(); err != nil {
+				log.Println(fmt.Errorf("keeping previous TLS certificate %w", err))
+				continue
+			}
+			log.Println("reloaded TLS certificate")
+		}
+	}
+}
+
+// TLSConfig returns server configuration that uses files loaded last by every new connection,
+// client certificate is required when client CA file is set
+func (r *CertReloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+
+			config := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*r.cert},
+				// returned config replaces the one grpc credentials added HTTP/2 protocol to
+				NextProtos: []string{"h2"},
+			}
+			if r.clientCAs != nil {
+				config.ClientAuth = tls.RequireAndVerifyClientCert
+				config.ClientCAs = r.clientCAs
+			}
+			return config, nil
+		},
+	}
+}
+
+func (r *CertReloader) stat() ([]fileStamp, error) {
+	files := []string{r.certFile, r.keyFile, r.clientCAFile}
+	stamps := make([]fileStamp, 0, len(files))
+	for _, file := range files {
+		if file == "" {
+			continue
+		}
+		// Stat follows symlinks, so swap of mounted secret directory is seen as change
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, fmt.Errorf("can't stat TLS file: %w", err)
+		}
+		stamps = append(stamps, fileStamp{modTime: info.ModTime(), size: info.Size()})
+	}
+
+	return stamps, nil
+}
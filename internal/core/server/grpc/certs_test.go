@@ -0,0 +1,186 @@
+package grpc_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ximura/gowallet/internal/core/server/grpc"
+	"gotest.tools/v3/assert"
+)
+
+type certificate struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	tls  tls.Certificate
+}
+
+// issue creates certificate signed by parent, self-signed CA when parent is nil
+func issue(t *testing.T, name string, parent *certificate) *certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	assert.NilError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	assert.NilError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NilError(t, err)
+	return &certificate{cert: cert, key: key, tls: tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}}
+}
+
+// write stores certificate and key as PEM files, modification time is moved forward so change is seen
+func (c *certificate) write(t *testing.T, certFile, keyFile string, modTime time.Time) {
+	t.Helper()
+	writePEM(t, certFile, "CERTIFICATE", c.cert.Raw, modTime)
+	if keyFile != "" {
+		der, err := x509.MarshalECPrivateKey(c.key)
+		assert.NilError(t, err)
+		writePEM(t, keyFile, "EC PRIVATE KEY", der, modTime)
+	}
+}
+
+func writePEM(t *testing.T, path, kind string, der []byte, modTime time.Time) {
+	t.Helper()
+	assert.NilError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: kind, Bytes: der}), 0o600))
+	assert.NilError(t, os.Chtimes(path, modTime, modTime))
+}
+
+// handshake connects client to server and returns certificate server presented
+func handshake(server, client *tls.Config) (*x509.Certificate, error) {
+	serverConn, clientConn := net.Pipe()
+	defer serverConn.Close()
+	defer clientConn.Close()
+
+	go func() {
+		// accepted connection is kept open until client closes it
+		conn := tls.Server(serverConn, server)
+		if conn.Handshake() == nil {
+			_, _ = io.Copy(io.Discard, conn)
+		}
+		serverConn.Close()
+	}()
+
+	conn := tls.Client(clientConn, client)
+	if err := conn.Handshake(); err != nil {
+		return nil, err
+	}
+	// client learns that its certificate was rejected on the first read in TLS 1.3
+	conn.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+	if _, err := conn.Read(make([]byte, 1)); err != nil {
+		if ne, ok := err.(net.Error); !ok || !ne.Timeout() {
+			return nil, err
+		}
+	}
+	return conn.ConnectionState().PeerCertificates[0], nil
+}
+
+func TestCertReloader(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key")
+	ca := issue(t, "ca", nil)
+	first := issue(t, "wallet", ca)
+	modTime := time.Now().Add(-time.Minute)
+	first.write(t, certFile, keyFile, modTime)
+
+	reloader, err := grpc.NewCertReloader(certFile, keyFile, "")
+	assert.NilError(t, err)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	client := &tls.Config{RootCAs: roots, ServerName: "wallet"}
+
+	served, err := handshake(reloader.TLSConfig(), client)
+	assert.NilError(t, err)
+	assert.Assert(t, served.Equal(first.cert))
+
+	changed, err := reloader.Changed()
+	assert.NilError(t, err)
+	assert.Assert(t, !changed)
+
+	// renewed certificate is served by new connections
+	second := issue(t, "wallet", ca)
+	second.write(t, certFile, keyFile, modTime.Add(time.Second))
+	changed, err = reloader.Changed()
+	assert.NilError(t, err)
+	assert.Assert(t, changed)
+	assert.NilError(t, reloader.Load())
+
+	served, err = handshake(reloader.TLSConfig(), client)
+	assert.NilError(t, err)
+	assert.Assert(t, served.Equal(second.cert))
+
+	// broken file keeps certificate loaded before
+	assert.NilError(t, os.WriteFile(keyFile, []byte("broken"), 0o600))
+	assert.ErrorContains(t, reloader.Load(), "can't load TLS certificate")
+	changed, err = reloader.Changed()
+	assert.NilError(t, err)
+	assert.Assert(t, !changed)
+
+	served, err = handshake(reloader.TLSConfig(), client)
+	assert.NilError(t, err)
+	assert.Assert(t, served.Equal(second.cert))
+}
+
+func TestCertReloaderMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile, caFile := filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key"), filepath.Join(dir, "ca.crt")
+	ca := issue(t, "ca", nil)
+	modTime := time.Now().Add(-time.Minute)
+	issue(t, "wallet", ca).write(t, certFile, keyFile, modTime)
+	ca.write(t, caFile, "", modTime)
+
+	reloader, err := grpc.NewCertReloader(certFile, keyFile, caFile)
+	assert.NilError(t, err)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+
+	tests := map[string]struct {
+		certs []tls.Certificate
+		ok    bool
+	}{
+		"client certificate of CA":       {certs: []tls.Certificate{issue(t, "payroll", ca).tls}, ok: true},
+		"without client certificate":     {},
+		"client certificate of other CA": {certs: []tls.Certificate{issue(t, "payroll", issue(t, "other", nil)).tls}},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			_, err := handshake(reloader.TLSConfig(), &tls.Config{RootCAs: roots, ServerName: "wallet", Certificates: tt.certs})
+			if tt.ok {
+				assert.NilError(t, err)
+				return
+			}
+			assert.Assert(t, err != nil)
+		})
+	}
+}
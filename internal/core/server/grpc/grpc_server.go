@@ -2,37 +2,109 @@ package grpc
 
 import (
 	"context"
-	"fmt"
 	"log"
 	"net"
+	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
 type GRPCService struct {
-	port int
+	addr            string
+	requestTimeout  time.Duration
+	shutdownTimeout time.Duration
+	serverOptions   []grpc.ServerOption
+	unary           []grpc.UnaryServerInterceptor
+	stream          []grpc.StreamServerInterceptor
+	// nil when services are always SERVING
+	healthCheck    func(context.Context) error
+	healthInterval time.Duration
+	// nil when metrics are not recorded
+	metrics *serverMetrics
 
 	server *grpc.Server
+	health *health.Server
 }
 
-// NewGRPCService creates a new GRPCService instance.
-func NewGRPCService(port int) *GRPCService {
-	return &GRPCService{
-		port:   port,
-		server: grpc.NewServer(),
+type Option func(*GRPCService)
+
+// WithRequestTimeout sets deadline of requests that came without shorter one
+func WithRequestTimeout(timeout time.Duration) Option {
+	return func(gs *GRPCService) {
+		gs.requestTimeout = timeout
+	}
+}
+
+// WithShutdownTimeout limits time running requests have to finish on Close
+func WithShutdownTimeout(timeout time.Duration) Option {
+	return func(gs *GRPCService) {
+		gs.shutdownTimeout = timeout
+	}
+}
+
+// WithTLS serves connections over TLS
+func WithTLS(creds credentials.TransportCredentials) Option {
+	return func(gs *GRPCService) {
+		gs.serverOptions = append(gs.serverOptions, grpc.Creds(creds))
+	}
+}
+
+// WithInterceptors runs interceptors for requests in order they were added, after request timeout is set
+func WithInterceptors(unary grpc.UnaryServerInterceptor, stream grpc.StreamServerInterceptor) Option {
+	return func(gs *GRPCService) {
+		gs.unary = append(gs.unary, unary)
+		gs.stream = append(gs.stream, stream)
+	}
+}
+
+// NewGRPCService creates a new GRPCService instance listening on addr.
+func NewGRPCService(addr string, opts ...Option) *GRPCService {
+	gs := &GRPCService{
+		addr: addr,
+	}
+	for _, opt := range opts {
+		opt(gs)
 	}
+
+	if gs.requestTimeout > 0 {
+		gs.unary = append([]grpc.UnaryServerInterceptor{gs.timeoutInterceptor}, gs.unary...)
+	}
+	if gs.metrics != nil {
+		// duration includes the whole chain
+		gs.unary = append([]grpc.UnaryServerInterceptor{gs.metrics.unaryInterceptor}, gs.unary...)
+		gs.stream = append([]grpc.StreamServerInterceptor{gs.metrics.streamInterceptor}, gs.stream...)
+	}
+	gs.serverOptions = append(gs.serverOptions, grpc.ChainUnaryInterceptor(gs.unary...), grpc.ChainStreamInterceptor(gs.stream...))
+	gs.server = grpc.NewServer(gs.serverOptions...)
+
+	gs.health = health.NewServer()
+	healthpb.RegisterHealthServer(gs.server, gs.health)
+	gs.health.SetServingStatus(LivenessService, healthpb.HealthCheckResponse_SERVING)
+	if gs.healthCheck != nil {
+		// not ready until the first check passes
+		gs.health.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+	return gs
 }
 
 func (gs *GRPCService) Run(ctx context.Context) error {
 	lc := &net.ListenConfig{}
-	listAddr := fmt.Sprintf(":%d", gs.port)
-	lis, err := lc.Listen(ctx, "tcp", listAddr)
+	lis, err := lc.Listen(ctx, "tcp", gs.addr)
 	if err != nil {
 		return err
 	}
 	defer lis.Close()
 	reflection.Register(gs.server)
+	// services are registered, so their status can be set
+	if gs.healthCheck != nil {
+		go gs.runHealthCheck(ctx)
+	} else {
+		gs.setServing(true)
+	}
 
 	// Shutdown the server when the context is canceled
 	go func() {
@@ -40,7 +112,7 @@ func (gs *GRPCService) Run(ctx context.Context) error {
 		gs.Close()
 	}()
 
-	log.Println("Serving GRPC Service on " + listAddr)
+	log.Println("Serving GRPC Service on " + gs.addr)
 	if err := gs.server.Serve(lis); err != nil {
 		return err
 	}
@@ -48,11 +120,37 @@ func (gs *GRPCService) Run(ctx context.Context) error {
 	return nil
 }
 
+// Close reports services NOT_SERVING and waits for running requests to finish,
+// connections are closed when shutdown timeout passes
 func (gs *GRPCService) Close() error {
-	gs.server.GracefulStop()
+	gs.health.Shutdown()
+	if gs.shutdownTimeout <= 0 {
+		gs.server.GracefulStop()
+		return nil
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		gs.server.GracefulStop()
+		close(stopped)
+	}()
+
+	timer := time.NewTimer(gs.shutdownTimeout)
+	defer timer.Stop()
+	select {
+	case <-stopped:
+	case <-timer.C:
+		gs.server.Stop()
+	}
 	return nil
 }
 
 func (gs *GRPCService) Register(serviceRegister func(server *grpc.Server)) {
 	serviceRegister(gs.server)
 }
+
+func (gs *GRPCService) timeoutInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, cancel := context.WithTimeout(ctx, gs.requestTimeout)
+	defer cancel()
+	return handler(ctx, req)
+}
@@ -0,0 +1,72 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// HealthService is name of the standard health checking service, it doesn't require credentials
+var HealthService = healthpb.Health_ServiceDesc.ServiceName
+
+// LivenessService is SERVING while server runs regardless of health check, so liveness probe doesn't restart
+// server that waits for its dependencies. Empty service name and registered services report readiness.
+const LivenessService = "liveness"
+
+// WithHealthCheck makes services SERVING only while check passes, check runs every interval
+func WithHealthCheck(check func(context.Context) error, interval time.Duration) Option {
+	return func(gs *GRPCService) {
+		gs.healthCheck = check
+		gs.healthInterval = interval
+	}
+}
+
+// runHealthCheck updates status of services with result of health check until context is canceled
+func (gs *GRPCService) runHealthCheck(ctx context.Context) {
+	ticker := time.NewTicker(gs.healthInterval)
+	defer ticker.Stop()
+
+	var last error
+	for first := true; ; first = false {
+		checkCtx, cancel := context.WithTimeout(ctx, gs.healthInterval)
+		err := gs.healthCheck(checkCtx)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+
+		// only changes are logged, so failing check doesn't flood the log
+		switch {
+		case err != nil && (first || last == nil || err.Error() != last.Error()):
+			log.Println(fmt.Errorf("health check failed, services are not serving %w", err))
+		case err == nil && (first || last != nil):
+			log.Println("health check passed, services are serving")
+		}
+		last = err
+		gs.setServing(err == nil)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// setServing sets status of readiness and of every registered service
+func (gs *GRPCService) setServing(serving bool) {
+	status := healthpb.HealthCheckResponse_NOT_SERVING
+	if serving {
+		status = healthpb.HealthCheckResponse_SERVING
+	}
+
+	gs.health.SetServingStatus("", status)
+	for name := range gs.server.GetServiceInfo() {
+		if name != HealthService {
+			gs.health.SetServingStatus(name, status)
+		}
+	}
+}
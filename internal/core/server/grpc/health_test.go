@@ -0,0 +1,84 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ximura/gowallet/internal/core/server/grpc"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/poll"
+)
+
+func TestHealth(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	addr := lis.Addr().String()
+	lis.Close()
+
+	var failing atomic.Bool
+	failing.Store(true)
+	check := func(context.Context) error {
+		if failing.Load() {
+			return errors.New("database is unreachable")
+		}
+		return nil
+	}
+	server := grpc.NewGRPCService(addr, grpc.WithHealthCheck(check, 10*time.Millisecond), grpc.WithShutdownTimeout(100*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stopped := make(chan error)
+	go func() { stopped <- server.Run(ctx) }()
+
+	conn, err := grpclib.NewClient(addr, grpclib.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NilError(t, err)
+	defer conn.Close()
+	client := healthpb.NewHealthClient(conn)
+
+	expect := func(service string, want healthpb.HealthCheckResponse_ServingStatus) {
+		t.Helper()
+		poll.WaitOn(t, func(poll.LogT) poll.Result {
+			resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+			if err != nil {
+				return poll.Continue("check of %q failed: %v", service, err)
+			}
+			if resp.GetStatus() != want {
+				return poll.Continue("%q is %v", service, resp.GetStatus())
+			}
+			return poll.Success()
+		}, poll.WithTimeout(5*time.Second), poll.WithDelay(5*time.Millisecond))
+	}
+
+	// server is alive but not ready while its dependencies fail
+	expect(grpc.LivenessService, healthpb.HealthCheckResponse_SERVING)
+	expect("", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	failing.Store(false)
+	expect("", healthpb.HealthCheckResponse_SERVING)
+	expect("grpc.reflection.v1.ServerReflection", healthpb.HealthCheckResponse_SERVING)
+
+	failing.Store(true)
+	expect("grpc.reflection.v1.ServerReflection", healthpb.HealthCheckResponse_NOT_SERVING)
+	failing.Store(false)
+	expect("", healthpb.HealthCheckResponse_SERVING)
+
+	// watchers learn about shutdown before connections are closed
+	watch, err := client.Watch(context.Background(), &healthpb.HealthCheckRequest{})
+	assert.NilError(t, err)
+	resp, err := watch.Recv()
+	assert.NilError(t, err)
+	assert.Equal(t, resp.GetStatus(), healthpb.HealthCheckResponse_SERVING)
+
+	cancel()
+	resp, err = watch.Recv()
+	assert.NilError(t, err)
+	assert.Equal(t, resp.GetStatus(), healthpb.HealthCheckResponse_NOT_SERVING)
+	assert.NilError(t, <-stopped)
+}
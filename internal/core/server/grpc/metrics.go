@@ -0,0 +1,69 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// serverMetrics count handled requests and their duration per method
+type serverMetrics struct {
+	handled  *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+// WithMetrics registers metrics of requests in reg, they include requests rejected by other interceptors
+func WithMetrics(reg prometheus.Registerer) Option {
+	return func(gs *GRPCService) {
+		labels := []string{"grpc_type", "grpc_service", "grpc_method"}
+		m := &serverMetrics{
+			handled: prometheus.NewCounterVec(prometheus.CounterOpts{
+				Name: "grpc_server_handled_total",
+				Help: "Total number of RPCs completed on the server, regardless of success or failure.",
+			}, append(labels, "grpc_code")),
+			duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+				Name:    "grpc_server_handling_seconds",
+				Help:    "Histogram of response latency (seconds) of RPCs handled by the server.",
+				Buckets: prometheus.DefBuckets,
+			}, labels),
+		}
+		reg.MustRegister(m.handled, m.duration)
+		gs.metrics = m
+	}
+}
+
+func (m *serverMetrics) unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	m.observe("unary", info.FullMethod, start, err)
+	return resp, err
+}
+
+func (m *serverMetrics) streamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	m.observe(streamType(info), info.FullMethod, start, err)
+	return err
+}
+
+func (m *serverMetrics) observe(kind, fullMethod string, start time.Time, err error) {
+	// full method is "/package.Service/Method"
+	service, method, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	m.handled.WithLabelValues(kind, service, method, status.Code(err).String()).Inc()
+	m.duration.WithLabelValues(kind, service, method).Observe(time.Since(start).Seconds())
+}
+
+func streamType(info *grpc.StreamServerInfo) string {
+	switch {
+	case info.IsClientStream && info.IsServerStream:
+		return "bidi_stream"
+	case info.IsClientStream:
+		return "client_stream"
+	default:
+		return "server_stream"
+	}
+}
@@ -0,0 +1,56 @@
+package grpc_test
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/ximura/gowallet/internal/core/server/grpc"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/poll"
+)
+
+func TestMetrics(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	addr := lis.Addr().String()
+	lis.Close()
+
+	registry := prometheus.NewRegistry()
+	server := grpc.NewGRPCService(addr, grpc.WithMetrics(registry), grpc.WithShutdownTimeout(100*time.Millisecond))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go server.Run(ctx)
+
+	conn, err := grpclib.NewClient(addr, grpclib.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NilError(t, err)
+	defer conn.Close()
+	client := healthpb.NewHealthClient(conn)
+
+	poll.WaitOn(t, func(poll.LogT) poll.Result {
+		if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{}); err != nil {
+			return poll.Continue("check failed: %v", err)
+		}
+		return poll.Success()
+	}, poll.WithDelay(5*time.Millisecond))
+	_, err = client.Check(ctx, &healthpb.HealthCheckRequest{Service: "unknown"})
+	assert.ErrorContains(t, err, "unknown service")
+
+	expected := `
+# HELP grpc_server_handled_total Total number of RPCs completed on the server, regardless of success or failure.
+# TYPE grpc_server_handled_total counter
+grpc_server_handled_total{grpc_code="NotFound",grpc_method="Check",grpc_service="grpc.health.v1.Health",grpc_type="unary"} 1
+grpc_server_handled_total{grpc_code="OK",grpc_method="Check",grpc_service="grpc.health.v1.Health",grpc_type="unary"} 1
+`
+	assert.NilError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected), "grpc_server_handled_total"))
+	count, err := testutil.GatherAndCount(registry, "grpc_server_handling_seconds")
+	assert.NilError(t, err)
+	assert.Equal(t, count, 1)
+}
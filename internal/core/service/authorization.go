@@ -0,0 +1,295 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ximura/gowallet/internal/core/domain"
+	"github.com/ximura/gowallet/internal/core/ports"
+)
+
+var _ ports.WalletService = (*AuthorizedWalletService)(nil)
+var _ ports.CurrencyService = (*AuthorizedCurrencyService)(nil)
+var _ ports.FXService = (*AuthorizedFXService)(nil)
+
+var ErrPermissionDenied = domain.NewError(domain.KindPermissionDenied, "PERMISSION_DENIED", "permission denied")
+
+// scope is the set of wallets role can use method on
+type scope int
+
+const (
+	scopeNone scope = iota
+	// wallets of account principal acts for
+	scopeOwn
+	scopeAll
+)
+
+// everyRole lets customers use method on wallets of their account, operators and systems on any wallet
+var everyRole = map[domain.Role]scope{domain.RoleCustomer: scopeOwn, domain.RoleOperator: scopeAll, domain.RoleSystem: scopeAll}
+
+// permissions grant roles methods of the service, principal with several roles has the widest scope of them
+var permissions = map[string]map[domain.Role]scope{
+	"Create":              everyRole,
+	"CreateMultiCurrency": everyRole,
+	"Get":                 everyRole,
+	"List":                everyRole,
+	"WatchWallet":         everyRole,
+	"ListTransactions":    everyRole,
+	"Transfer":            {domain.RoleCustomer: scopeOwn, domain.RoleSystem: scopeAll},
+	"ProcessTransaction":  {domain.RoleSystem: scopeAll},
+	"ProcessBatch":        {domain.RoleSystem: scopeAll},
+	"Refund":              {domain.RoleOperator: scopeAll, domain.RoleSystem: scopeAll},
+	"Hold":                {domain.RoleSystem: scopeAll},
+	"CaptureHold":         {domain.RoleSystem: scopeAll},
+	"ReleaseHold":         {domain.RoleSystem: scopeAll},
+	"FreezeWallet":        {domain.RoleOperator: scopeAll, domain.RoleSystem: scopeAll},
+	"UnfreezeWallet":      {domain.RoleOperator: scopeAll, domain.RoleSystem: scopeAll},
+	"CloseWallet":         {domain.RoleOperator: scopeAll, domain.RoleSystem: scopeAll},
+	"ListCurrencies":      everyRole,
+	"GetCurrency":         everyRole,
+	"SetCurrencyEnabled":  {domain.RoleOperator: scopeAll, domain.RoleSystem: scopeAll},
+	"Quote":               {domain.RoleCustomer: scopeOwn, domain.RoleSystem: scopeAll},
+}
+
+// authorize returns scope of wallets principal of context can use method on
+func authorize(ctx context.Context, method string) (domain.Principal, scope, error) {
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if !ok {
+		return domain.Principal{}, scopeNone, fmt.Errorf("%w: %s requires authenticated caller", ErrPermissionDenied, method)
+	}
+
+	granted := scopeNone
+	for _, role := range principal.Roles {
+		granted = max(granted, permissions[method][role])
+	}
+	if granted == scopeNone {
+		return domain.Principal{}, scopeNone, fmt.Errorf("%w: %s can't call %s", ErrPermissionDenied, principal.Subject, method)
+	}
+
+	return principal, granted, nil
+}
+
+// authorizeAccount checks that principal can use method on wallets of account
+func authorizeAccount(ctx context.Context, method string, account uuid.UUID) error {
+	principal, granted, err := authorize(ctx, method)
+	if err != nil || granted == scopeAll {
+		return err
+	}
+
+	if own, ok := principal.Account(); !ok || own != account {
+		return fmt.Errorf("%w: %s can't call %s for account %s", ErrPermissionDenied, principal.Subject, method, account)
+	}
+	return nil
+}
+
+// AuthorizedWalletService checks permissions of the caller before passing request to wallet service.
+// Customers act for the account that is the subject of their principal and use only its wallets.
+type AuthorizedWalletService struct {
+	next ports.WalletService
+}
+
+func NewAuthorizedWalletService(next ports.WalletService) *AuthorizedWalletService {
+	return &AuthorizedWalletService{next: next}
+}
+
+// authorizeWallet checks that principal can use method on wallet, wallet is read only for customers
+func (a *AuthorizedWalletService) authorizeWallet(ctx context.Context, method string, walletID int) error {
+	_, granted, err := authorize(ctx, method)
+	if err != nil || granted == scopeAll {
+		return err
+	}
+
+	wallet, err := a.next.Get(ctx, walletID)
+	if err != nil {
+		return err
+	}
+	return authorizeAccount(ctx, method, wallet.Account)
+}
+
+// authorizeStatusChange checks that principal can change status of wallet and records principal as actor
+// of the change, actor sent by client is not trusted
+func (a *AuthorizedWalletService) authorizeStatusChange(ctx context.Context, method string, change domain.StatusChange) (domain.StatusChange, error) {
+	if err := a.authorizeWallet(ctx, method, change.WalletID); err != nil {
+		return domain.StatusChange{}, err
+	}
+
+	principal, _ := domain.PrincipalFromContext(ctx)
+	change.Actor = principal.Subject
+	return change, nil
+}
+
+func (a *AuthorizedWalletService) Create(ctx context.Context, account uuid.UUID, currency domain.Currency) (domain.Wallet, error) {
+	if err := authorizeAccount(ctx, "Create", account); err != nil {
+		return domain.Wallet{}, err
+	}
+	return a.next.Create(ctx, account, currency)
+}
+
+func (a *AuthorizedWalletService) CreateMultiCurrency(ctx context.Context, account uuid.UUID, currency domain.Currency) (domain.Wallet, error) {
+	if err := authorizeAccount(ctx, "CreateMultiCurrency", account); err != nil {
+		return domain.Wallet{}, err
+	}
+	return a.next.CreateMultiCurrency(ctx, account, currency)
+}
+
+func (a *AuthorizedWalletService) Get(ctx context.Context, id int) (domain.Wallet, error) {
+	if _, _, err := authorize(ctx, "Get"); err != nil {
+		return domain.Wallet{}, err
+	}
+
+	// owner is known only after wallet is read
+	wallet, err := a.next.Get(ctx, id)
+	if err != nil {
+		return domain.Wallet{}, err
+	}
+	if err := authorizeAccount(ctx, "Get", wallet.Account); err != nil {
+		return domain.Wallet{}, err
+	}
+	return wallet, nil
+}
+
+func (a *AuthorizedWalletService) List(ctx context.Context, filter domain.WalletFilter) (domain.WalletPage, error) {
+	if err := authorizeAccount(ctx, "List", filter.Account); err != nil {
+		return domain.WalletPage{}, err
+	}
+	return a.next.List(ctx, filter)
+}
+
+func (a *AuthorizedWalletService) ProcessTransaction(ctx context.Context, transaction domain.Transaction) (domain.Wallet, error) {
+	if err := a.authorizeWallet(ctx, "ProcessTransaction", transaction.WalletID); err != nil {
+		return domain.Wallet{}, err
+	}
+	return a.next.ProcessTransaction(ctx, transaction)
+}
+
+func (a *AuthorizedWalletService) ProcessBatch(ctx context.Context, batch domain.Batch) ([]domain.BatchItem, error) {
+	for _, t := range batch.Transactions {
+		if err := a.authorizeWallet(ctx, "ProcessBatch", t.WalletID); err != nil {
+			return nil, err
+		}
+	}
+	return a.next.ProcessBatch(ctx, batch)
+}
+
+// Transfer checks only source wallet, funds can be sent to wallet of any account
+func (a *AuthorizedWalletService) Transfer(ctx context.Context, transfer domain.Transfer) (domain.Wallet, domain.Wallet, error) {
+	if err := a.authorizeWallet(ctx, "Transfer", transfer.FromWalletID); err != nil {
+		return domain.Wallet{}, domain.Wallet{}, err
+	}
+	return a.next.Transfer(ctx, transfer)
+}
+
+// WatchWallet checks permission once, wallet doesn't change its account
+func (a *AuthorizedWalletService) WatchWallet(ctx context.Context, walletID int, fromVersion int64, send func(domain.Wallet) error) error {
+	if err := a.authorizeWallet(ctx, "WatchWallet", walletID); err != nil {
+		return err
+	}
+	return a.next.WatchWallet(ctx, walletID, fromVersion, send)
+}
+
+func (a *AuthorizedWalletService) ListTransactions(ctx context.Context, filter domain.TransactionFilter) (domain.TransactionPage, error) {
+	if err := a.authorizeWallet(ctx, "ListTransactions", filter.WalletID); err != nil {
+		return domain.TransactionPage{}, err
+	}
+	return a.next.ListTransactions(ctx, filter)
+}
+
+func (a *AuthorizedWalletService) Refund(ctx context.Context, refund domain.Refund) (domain.Transaction, domain.Wallet, error) {
+	if err := a.authorizeWallet(ctx, "Refund", refund.WalletID); err != nil {
+		return domain.Transaction{}, domain.Wallet{}, err
+	}
+	return a.next.Refund(ctx, refund)
+}
+
+func (a *AuthorizedWalletService) Hold(ctx context.Context, hold domain.Hold) (domain.Hold, error) {
+	if err := a.authorizeWallet(ctx, "Hold", hold.WalletID); err != nil {
+		return domain.Hold{}, err
+	}
+	return a.next.Hold(ctx, hold)
+}
+
+func (a *AuthorizedWalletService) CaptureHold(ctx context.Context, id uuid.UUID, amount domain.Amount) (domain.Hold, domain.Wallet, error) {
+	// holds are managed only by roles with access to all wallets
+	if _, _, err := authorize(ctx, "CaptureHold"); err != nil {
+		return domain.Hold{}, domain.Wallet{}, err
+	}
+	return a.next.CaptureHold(ctx, id, amount)
+}
+
+func (a *AuthorizedWalletService) ReleaseHold(ctx context.Context, id uuid.UUID) (domain.Hold, error) {
+	if _, _, err := authorize(ctx, "ReleaseHold"); err != nil {
+		return domain.Hold{}, err
+	}
+	return a.next.ReleaseHold(ctx, id)
+}
+
+func (a *AuthorizedWalletService) FreezeWallet(ctx context.Context, change domain.StatusChange) (domain.Wallet, error) {
+	change, err := a.authorizeStatusChange(ctx, "FreezeWallet", change)
+	if err != nil {
+		return domain.Wallet{}, err
+	}
+	return a.next.FreezeWallet(ctx, change)
+}
+
+func (a *AuthorizedWalletService) UnfreezeWallet(ctx context.Context, change domain.StatusChange) (domain.Wallet, error) {
+	change, err := a.authorizeStatusChange(ctx, "UnfreezeWallet", change)
+	if err != nil {
+		return domain.Wallet{}, err
+	}
+	return a.next.UnfreezeWallet(ctx, change)
+}
+
+func (a *AuthorizedWalletService) CloseWallet(ctx context.Context, change domain.StatusChange) (domain.Wallet, error) {
+	change, err := a.authorizeStatusChange(ctx, "CloseWallet", change)
+	if err != nil {
+		return domain.Wallet{}, err
+	}
+	return a.next.CloseWallet(ctx, change)
+}
+
+// AuthorizedCurrencyService checks permissions of the caller before passing request to currency service
+type AuthorizedCurrencyService struct {
+	next ports.CurrencyService
+}
+
+func NewAuthorizedCurrencyService(next ports.CurrencyService) *AuthorizedCurrencyService {
+	return &AuthorizedCurrencyService{next: next}
+}
+
+func (a *AuthorizedCurrencyService) ListCurrencies(ctx context.Context) ([]domain.CurrencyInfo, error) {
+	if _, _, err := authorize(ctx, "ListCurrencies"); err != nil {
+		return nil, err
+	}
+	return a.next.ListCurrencies(ctx)
+}
+
+func (a *AuthorizedCurrencyService) GetCurrency(ctx context.Context, currency domain.Currency) (domain.CurrencyInfo, error) {
+	if _, _, err := authorize(ctx, "GetCurrency"); err != nil {
+		return domain.CurrencyInfo{}, err
+	}
+	return a.next.GetCurrency(ctx, currency)
+}
+
+func (a *AuthorizedCurrencyService) SetCurrencyEnabled(ctx context.Context, currency domain.Currency, enabled bool) (domain.CurrencyInfo, error) {
+	if _, _, err := authorize(ctx, "SetCurrencyEnabled"); err != nil {
+		return domain.CurrencyInfo{}, err
+	}
+	return a.next.SetCurrencyEnabled(ctx, currency, enabled)
+}
+
+// AuthorizedFXService checks permissions of the caller before passing request to FX service
+type AuthorizedFXService struct {
+	next ports.FXService
+}
+
+func NewAuthorizedFXService(next ports.FXService) *AuthorizedFXService {
+	return &AuthorizedFXService{next: next}
+}
+
+func (a *AuthorizedFXService) Quote(ctx context.Context, from, to domain.Currency) (domain.Quote, error) {
+	if _, _, err := authorize(ctx, "Quote"); err != nil {
+		return domain.Quote{}, err
+	}
+	return a.next.Quote(ctx, from, to)
+}
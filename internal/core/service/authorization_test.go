@@ -0,0 +1,233 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/ximura/gowallet/internal/core/domain"
+	"github.com/ximura/gowallet/internal/core/ports/mocks"
+	"github.com/ximura/gowallet/internal/core/service"
+	"gotest.tools/v3/assert"
+)
+
+func TestAuthorizedWalletService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	account, other := uuid.New(), uuid.New()
+	customer := &domain.Principal{Subject: account.String(), Roles: []domain.Role{domain.RoleCustomer}}
+	operator := &domain.Principal{Subject: "support", Roles: []domain.Role{domain.RoleOperator}}
+	system := &domain.Principal{Subject: "payroll", Roles: []domain.Role{domain.RoleSystem}}
+	own := domain.Wallet{ID: 1, Account: account, Currency: "usd"}
+	foreign := domain.Wallet{ID: 2, Account: other, Currency: "usd"}
+	credit := domain.Transaction{ID: uuid.New(), WalletID: 1, Amount: 10, Currency: "usd"}
+
+	tests := map[string]struct {
+		principal *domain.Principal
+		call      func(ctx context.Context, s *service.AuthorizedWalletService) error
+		mocks     func(m *mocks.MockWalletRepository)
+		err       error
+	}{
+		"customer gets own wallet": {
+			principal: customer,
+			call:      get(1),
+			mocks: func(m *mocks.MockWalletRepository) {
+				m.EXPECT().Get(gomock.Any(), 1).Return(own, nil)
+			},
+		},
+		"customer gets wallet of other account": {
+			principal: customer,
+			call:      get(2),
+			mocks: func(m *mocks.MockWalletRepository) {
+				m.EXPECT().Get(gomock.Any(), 2).Return(foreign, nil)
+			},
+			err: service.ErrPermissionDenied,
+		},
+		"operator gets any wallet": {
+			principal: operator,
+			call:      get(2),
+			mocks: func(m *mocks.MockWalletRepository) {
+				m.EXPECT().Get(gomock.Any(), 2).Return(foreign, nil)
+			},
+		},
+		"customer creates wallet of own account": {
+			principal: customer,
+			call: func(ctx context.Context, s *service.AuthorizedWalletService) error {
+				_, err := s.Create(ctx, account, "usd")
+				return err
+			},
+			mocks: func(m *mocks.MockWalletRepository) {
+				m.EXPECT().Create(gomock.Any(), account, domain.Currency("usd")).Return(own, nil)
+			},
+		},
+		"customer creates wallet of other account": {
+			principal: customer,
+			call: func(ctx context.Context, s *service.AuthorizedWalletService) error {
+				_, err := s.Create(ctx, other, "usd")
+				return err
+			},
+			mocks: func(m *mocks.MockWalletRepository) {},
+			err:   service.ErrPermissionDenied,
+		},
+		"customer lists wallets of other account": {
+			principal: customer,
+			call: func(ctx context.Context, s *service.AuthorizedWalletService) error {
+				_, err := s.List(ctx, domain.WalletFilter{Account: other})
+				return err
+			},
+			mocks: func(m *mocks.MockWalletRepository) {},
+			err:   service.ErrPermissionDenied,
+		},
+		"customer transfers from wallet of other account": {
+			principal: customer,
+			call: func(ctx context.Context, s *service.AuthorizedWalletService) error {
+				_, _, err := s.Transfer(ctx, domain.Transfer{ID: uuid.New(), FromWalletID: 2, ToWalletID: 1, Amount: 10, Currency: "usd"})
+				return err
+			},
+			mocks: func(m *mocks.MockWalletRepository) {
+				m.EXPECT().Get(gomock.Any(), 2).Return(foreign, nil)
+			},
+			err: service.ErrPermissionDenied,
+		},
+		"customer processes transaction": {
+			principal: customer,
+			call:      process(credit),
+			mocks:     func(m *mocks.MockWalletRepository) {},
+			err:       service.ErrPermissionDenied,
+		},
+		"operator processes transaction": {
+			principal: operator,
+			call:      process(credit),
+			mocks:     func(m *mocks.MockWalletRepository) {},
+			err:       service.ErrPermissionDenied,
+		},
+		"system processes transaction": {
+			principal: system,
+			call:      process(credit),
+			mocks: func(m *mocks.MockWalletRepository) {
+				m.EXPECT().Get(gomock.Any(), 1).Return(own, nil)
+				m.EXPECT().FindTransactions(gomock.Any(), credit.ID).Return(nil, nil)
+				m.EXPECT().ProcessTransaction(gomock.Any(), credit).Return(own, nil)
+			},
+		},
+		"customer freezes own wallet": {
+			principal: customer,
+			call: func(ctx context.Context, s *service.AuthorizedWalletService) error {
+				_, err := s.FreezeWallet(ctx, domain.StatusChange{WalletID: 1, Actor: "me", Reason: "lost card"})
+				return err
+			},
+			mocks: func(m *mocks.MockWalletRepository) {},
+			err:   service.ErrPermissionDenied,
+		},
+		"operator is recorded as actor of status change": {
+			principal: operator,
+			call: func(ctx context.Context, s *service.AuthorizedWalletService) error {
+				_, err := s.FreezeWallet(ctx, domain.StatusChange{WalletID: 2, Actor: "someone else", Reason: "compromised"})
+				return err
+			},
+			mocks: func(m *mocks.MockWalletRepository) {
+				change := domain.StatusChange{WalletID: 2, Status: domain.WalletFrozen, Actor: "support", Reason: "compromised"}
+				m.EXPECT().ChangeStatus(gomock.Any(), change).Return(foreign, nil)
+			},
+		},
+		"system closing wallet without actor is recorded as actor": {
+			principal: system,
+			call: func(ctx context.Context, s *service.AuthorizedWalletService) error {
+				_, err := s.CloseWallet(ctx, domain.StatusChange{WalletID: 2, Reason: "account closed"})
+				return err
+			},
+			mocks: func(m *mocks.MockWalletRepository) {
+				change := domain.StatusChange{WalletID: 2, Status: domain.WalletClosed, Actor: "payroll", Reason: "account closed"}
+				m.EXPECT().ChangeStatus(gomock.Any(), change).Return(foreign, nil)
+			},
+		},
+		"customer with subject that is not account": {
+			principal: &domain.Principal{Subject: "alice", Roles: []domain.Role{domain.RoleCustomer}},
+			call:      get(1),
+			mocks: func(m *mocks.MockWalletRepository) {
+				m.EXPECT().Get(gomock.Any(), 1).Return(own, nil)
+			},
+			err: service.ErrPermissionDenied,
+		},
+		"principal without roles": {
+			principal: &domain.Principal{Subject: account.String()},
+			call:      get(1),
+			mocks:     func(m *mocks.MockWalletRepository) {},
+			err:       service.ErrPermissionDenied,
+		},
+		"no principal": {
+			call:  get(1),
+			mocks: func(m *mocks.MockWalletRepository) {},
+			err:   service.ErrPermissionDenied,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			repository := mocks.NewMockWalletRepository(ctrl)
+			tt.mocks(repository)
+			wallet := service.NewWalletService(repository)
+			authorized := service.NewAuthorizedWalletService(&wallet)
+
+			ctx := context.Background()
+			if tt.principal != nil {
+				ctx = domain.ContextWithPrincipal(ctx, *tt.principal)
+			}
+			assert.ErrorIs(t, tt.call(ctx, authorized), tt.err)
+		})
+	}
+}
+
+func get(id int) func(ctx context.Context, s *service.AuthorizedWalletService) error {
+	return func(ctx context.Context, s *service.AuthorizedWalletService) error {
+		_, err := s.Get(ctx, id)
+		return err
+	}
+}
+
+func process(t domain.Transaction) func(ctx context.Context, s *service.AuthorizedWalletService) error {
+	return func(ctx context.Context, s *service.AuthorizedWalletService) error {
+		_, err := s.ProcessTransaction(ctx, t)
+		return err
+	}
+}
+
+func TestAuthorizedCurrencyService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repository := mocks.NewMockCurrencyRepository(ctrl)
+	currencies := service.NewAuthorizedCurrencyService(service.NewCurrencyRegistry(repository))
+
+	customer := domain.ContextWithPrincipal(context.Background(), domain.Principal{Subject: uuid.NewString(), Roles: []domain.Role{domain.RoleCustomer}})
+	_, err := currencies.GetCurrency(customer, "usd")
+	assert.NilError(t, err)
+	_, err = currencies.SetCurrencyEnabled(customer, "usd", false)
+	assert.ErrorIs(t, err, service.ErrPermissionDenied)
+
+	operator := domain.ContextWithPrincipal(context.Background(), domain.Principal{Subject: "support", Roles: []domain.Role{domain.RoleCustomer, domain.RoleOperator}})
+	repository.EXPECT().SetCurrencyEnabled(gomock.Any(), domain.Currency("usd"), false).Return(domain.CurrencyInfo{Code: "usd"}, nil)
+	_, err = currencies.SetCurrencyEnabled(operator, "usd", false)
+	assert.NilError(t, err)
+}
+
+func TestAuthorizedFXService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	rates := mocks.NewMockRateProvider(ctrl)
+	quotes := mocks.NewMockQuoteRepository(ctrl)
+	fx := service.NewAuthorizedFXService(service.NewFXService(rates, quotes, service.NewCurrencyRegistry(nil)))
+
+	// quotes are stored, so callers that can't convert funds don't get them
+	for _, roles := range [][]domain.Role{nil, {domain.RoleOperator}} {
+		ctx := domain.ContextWithPrincipal(context.Background(), domain.Principal{Subject: "support", Roles: roles})
+		_, err := fx.Quote(ctx, "eur", "usd")
+		assert.ErrorIs(t, err, service.ErrPermissionDenied)
+	}
+
+	customer := domain.ContextWithPrincipal(context.Background(), domain.Principal{Subject: uuid.NewString(), Roles: []domain.Role{domain.RoleCustomer}})
+	rates.EXPECT().Rate(gomock.Any(), domain.Currency("eur"), domain.Currency("usd")).Return(eurUsd, nil)
+	quotes.EXPECT().CreateQuote(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, quote domain.Quote) (domain.Quote, error) {
+		return quote, nil
+	})
+	_, err := fx.Quote(customer, "eur", "usd")
+	assert.NilError(t, err)
+}
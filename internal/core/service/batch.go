@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ximura/gowallet/internal/core/domain"
+)
+
+// ProcessBatch executes transactions of batch in a single database transaction. Atomic batch is applied
+// only when every transaction can be applied, best effort batch applies transactions that can be applied.
+// Transactions are routed to pockets, converted and replayed like in ProcessTransaction.
+func (w *WalletService) ProcessBatch(ctx context.Context, batch domain.Batch) ([]domain.BatchItem, error) {
+	items, err := w.processBatch(ctx, batch)
+	if err != nil {
+		w.recordRejected(err)
+		return nil, err
+	}
+
+	for _, item := range items {
+		switch item.Outcome {
+		case domain.BatchApplied:
+			w.metrics.TransactionProcessed(item.Transaction)
+		case domain.BatchReplayed:
+			w.metrics.DuplicateKey()
+		case domain.BatchFailed:
+			w.recordRejected(item.Err)
+		}
+	}
+	return items, nil
+}
+
+func (w *WalletService) processBatch(ctx context.Context, batch domain.Batch) ([]domain.BatchItem, error) {
+	if len(batch.Transactions) == 0 || len(batch.Transactions) > domain.MaxBatchSize {
+		return nil, domain.ErrInvalidBatchSize
+	}
+
+	items := make([]domain.BatchItem, len(batch.Transactions))
+	// transactions passed to repository and their positions in the batch
+	pending := domain.Batch{Mode: batch.Mode}
+	var positions []int
+	keys := make(map[uuid.UUID]bool, len(batch.Transactions))
+	wallets := make(map[int]domain.Wallet)
+	for i, t := range batch.Transactions {
+		if keys[t.ID] {
+			items[i] = domain.FailedItem(t, domain.ErrDuplicateBatchKey)
+			continue
+		}
+		keys[t.ID] = true
+
+		prepared, done, err := w.prepareBatchTransaction(ctx, wallets, t)
+		if err != nil {
+			return nil, err
+		}
+		if done != nil {
+			items[i] = *done
+			continue
+		}
+		pending.Transactions = append(pending.Transactions, prepared)
+		positions = append(positions, i)
+	}
+
+	if batch.Mode == domain.BatchAtomic && domain.CountOutcome(items, domain.BatchFailed) > 0 {
+		// nothing is applied, transactions that were not processed yet stay aborted
+		for _, i := range positions {
+			items[i] = domain.BatchItem{Transaction: batch.Transactions[i], Outcome: domain.BatchAborted}
+		}
+		return items, nil
+	}
+	if len(pending.Transactions) == 0 {
+		return items, nil
+	}
+
+	results, err := w.repo.ProcessBatch(ctx, pending)
+	if err != nil {
+		return nil, fmt.Errorf("can't process batch: %w", err)
+	}
+
+	for j, item := range results {
+		if batch.Mode == domain.BatchBestEffort && errors.Is(item.Err, ErrDuplicateTransaction) {
+			// transaction with the same key was processed concurrently
+			item, err = w.replayBatchTransaction(ctx, item.Transaction)
+			if err != nil {
+				return nil, err
+			}
+		}
+		items[positions[j]] = item
+	}
+
+	return items, nil
+}
+
+// prepareBatchTransaction routes transaction to wallet or pocket and converts it. Result of transaction
+// that failed or was replayed is returned as done, returned error fails the whole batch.
+func (w *WalletService) prepareBatchTransaction(ctx context.Context, wallets map[int]domain.Wallet, t domain.Transaction) (domain.Transaction, *domain.BatchItem, error) {
+	prepared, done, err := w.prepareTransaction(ctx, wallets, t)
+	var domainErr *domain.Error
+	if errors.As(err, &domainErr) {
+		failed := domain.FailedItem(t, err)
+		return domain.Transaction{}, &failed, nil
+	}
+
+	return prepared, done, err
+}
+
+func (w *WalletService) prepareTransaction(ctx context.Context, wallets map[int]domain.Wallet, t domain.Transaction) (domain.Transaction, *domain.BatchItem, error) {
+	currency, err := w.supportedCurrency(t.Currency)
+	if err != nil {
+		return domain.Transaction{}, nil, err
+	}
+	t.Currency = currency
+
+	// batch often has many transactions of the same wallet
+	wallet, ok := wallets[t.WalletID]
+	if !ok {
+		wallet, err = w.repo.Get(ctx, t.WalletID)
+		if err != nil {
+			return domain.Transaction{}, nil, fmt.Errorf("can't get wallet %d: %w", t.WalletID, err)
+		}
+	}
+
+	// missing pocket is created in batch transaction, so it's rolled back with atomic batch that failed
+	target := routeWallet(wallet, t.Currency)
+	wallets[t.WalletID] = wallet
+	t.WalletID = target.ID
+
+	if t.Conversion == nil || createsPocket(target, t) {
+		return t, nil, nil
+	}
+
+	// converted transaction is replayed before conversion, quote could expire since the first attempt
+	stored, err := w.findTransactions(ctx, t.ID)
+	if err != nil {
+		return domain.Transaction{}, nil, err
+	}
+	if len(stored) > 0 {
+		wallet, err := w.replayTransaction(ctx, t, stored)
+		if err != nil {
+			return domain.Transaction{}, nil, err
+		}
+		return domain.Transaction{}, &domain.BatchItem{Transaction: stored[0], Wallet: wallet, Outcome: domain.BatchReplayed}, nil
+	}
+
+	t, err = w.convertTransaction(ctx, target, t)
+	if err != nil {
+		return domain.Transaction{}, nil, err
+	}
+
+	return t, nil, nil
+}
+
+// replayBatchTransaction returns result of transaction processed with the same idempotency key
+func (w *WalletService) replayBatchTransaction(ctx context.Context, t domain.Transaction) (domain.BatchItem, error) {
+	stored, err := w.findTransactions(ctx, t.ID)
+	if err != nil {
+		return domain.BatchItem{}, err
+	}
+
+	wallet, err := w.replayTransaction(ctx, t, stored)
+	if errors.Is(err, ErrIdempotencyKeyReused) {
+		return domain.FailedItem(t, err), nil
+	}
+	if err != nil {
+		return domain.BatchItem{}, err
+	}
+
+	return domain.BatchItem{Transaction: stored[0], Wallet: wallet, Outcome: domain.BatchReplayed}, nil
+}
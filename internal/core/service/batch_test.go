@@ -0,0 +1,113 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/ximura/gowallet/internal/core/domain"
+	"github.com/ximura/gowallet/internal/core/ports/mocks"
+	"github.com/ximura/gowallet/internal/core/service"
+	"gotest.tools/v3/assert"
+)
+
+func TestProcessBatch(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	wallet := domain.Wallet{ID: 1, Amount: 100, Currency: "usd"}
+	first := domain.Transaction{ID: uuid.New(), WalletID: 1, Amount: 10, Currency: "USD"}
+	second := domain.Transaction{ID: uuid.New(), WalletID: 1, Amount: 20, Currency: "usd"}
+	// normalized transactions passed to repository
+	firstUSD, secondUSD := first, second
+	firstUSD.Currency = "usd"
+
+	tests := map[string]struct {
+		batch    domain.Batch
+		outcomes []domain.BatchOutcome
+		errs     []error
+		err      error
+		mocks    func(m *mocks.MockWalletRepository)
+	}{
+		"empty": {
+			batch: domain.Batch{},
+			err:   domain.ErrInvalidBatchSize,
+			mocks: func(m *mocks.MockWalletRepository) {},
+		},
+		"atomic": {
+			batch:    domain.Batch{Mode: domain.BatchAtomic, Transactions: []domain.Transaction{first, second}},
+			outcomes: []domain.BatchOutcome{domain.BatchApplied, domain.BatchApplied},
+			errs:     []error{nil, nil},
+			mocks: func(m *mocks.MockWalletRepository) {
+				// wallet is read once for the whole batch
+				m.EXPECT().Get(ctx, 1).Return(wallet, nil)
+				m.EXPECT().ProcessBatch(ctx, domain.Batch{Mode: domain.BatchAtomic, Transactions: []domain.Transaction{firstUSD, secondUSD}}).
+					Return([]domain.BatchItem{{Outcome: domain.BatchApplied}, {Outcome: domain.BatchApplied}}, nil)
+			},
+		},
+		"atomic with invalid transaction": {
+			batch:    domain.Batch{Mode: domain.BatchAtomic, Transactions: []domain.Transaction{first, {ID: uuid.New(), WalletID: 1, Amount: 10, Currency: "xxx"}}},
+			outcomes: []domain.BatchOutcome{domain.BatchAborted, domain.BatchFailed},
+			errs:     []error{nil, service.ErrUnsuportedCurrency},
+			mocks: func(m *mocks.MockWalletRepository) {
+				m.EXPECT().Get(ctx, 1).Return(wallet, nil)
+			},
+		},
+		"best effort": {
+			batch:    domain.Batch{Mode: domain.BatchBestEffort, Transactions: []domain.Transaction{first, {ID: uuid.New(), WalletID: 2, Amount: 10, Currency: "usd"}, first}},
+			outcomes: []domain.BatchOutcome{domain.BatchReplayed, domain.BatchFailed, domain.BatchFailed},
+			errs:     []error{nil, domain.ErrWalletNotFound, domain.ErrDuplicateBatchKey},
+			mocks: func(m *mocks.MockWalletRepository) {
+				m.EXPECT().Get(ctx, 1).Return(wallet, nil)
+				m.EXPECT().Get(ctx, 2).Return(domain.Wallet{}, domain.ErrWalletNotFound)
+				m.EXPECT().ProcessBatch(ctx, domain.Batch{Mode: domain.BatchBestEffort, Transactions: []domain.Transaction{firstUSD}}).
+					Return([]domain.BatchItem{domain.FailedItem(firstUSD, domain.ErrDuplicateTransaction)}, nil)
+				// transaction was processed concurrently
+				stored := firstUSD
+				stored.Balance = 110
+				m.EXPECT().FindTransactions(ctx, first.ID).Return([]domain.Transaction{stored}, nil)
+				m.EXPECT().Get(ctx, 1).Return(wallet, nil)
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			repository := mocks.NewMockWalletRepository(ctrl)
+			tt.mocks(repository)
+			wallet := service.NewWalletService(repository)
+
+			items, err := wallet.ProcessBatch(ctx, tt.batch)
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, len(items), len(tt.outcomes))
+			for i, item := range items {
+				assert.Equal(t, item.Outcome, tt.outcomes[i], "transaction %d", i)
+				if tt.errs[i] != nil {
+					assert.ErrorIs(t, item.Err, tt.errs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestProcessBatchPocket(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	repository := mocks.NewMockWalletRepository(ctrl)
+	wallet := service.NewWalletService(repository)
+
+	multi := domain.Wallet{ID: 1, Currency: "usd", MultiCurrency: true, Pockets: []domain.Pocket{{WalletID: 5, Currency: "eur"}}}
+	first := domain.Transaction{ID: uuid.New(), WalletID: 1, Amount: 10, Currency: "eur"}
+	second := domain.Transaction{ID: uuid.New(), WalletID: 1, Amount: 20, Currency: "uah"}
+
+	// credit in currency without pocket is passed to repository with wallet, pocket is created in batch transaction
+	repository.EXPECT().Get(ctx, 1).Return(multi, nil)
+	first.WalletID = 5
+	repository.EXPECT().ProcessBatch(ctx, domain.Batch{Transactions: []domain.Transaction{first, second}}).
+		Return([]domain.BatchItem{{Outcome: domain.BatchApplied}, {Outcome: domain.BatchApplied}}, nil)
+
+	first.WalletID = 1
+	_, err := wallet.ProcessBatch(ctx, domain.Batch{Transactions: []domain.Transaction{first, second}})
+	assert.NilError(t, err)
+}
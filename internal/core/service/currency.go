@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ximura/gowallet/internal/core/domain"
+	"github.com/ximura/gowallet/internal/core/ports"
+)
+
+var _ ports.CurrencyService = (*CurrencyRegistry)(nil)
+
+var errReadOnlyCatalog = errors.New("currency catalog has no storage and can't be changed")
+
+// CurrencyRegistry keeps currency catalog in memory,
+// catalog is read from repository on Load and changes are written to repository first.
+type CurrencyRegistry struct {
+	repo ports.CurrencyRepository
+
+	mu         sync.RWMutex
+	currencies map[domain.Currency]domain.CurrencyInfo
+}
+
+// NewCurrencyRegistry returns registry of domain.DefaultCurrencies,
+// registry without repository can't be loaded or changed.
+func NewCurrencyRegistry(repo ports.CurrencyRepository) *CurrencyRegistry {
+	r := &CurrencyRegistry{repo: repo}
+	r.replace(domain.DefaultCurrencies)
+	return r
+}
+
+// Load replaces cached catalog with the one from repository
+func (r *CurrencyRegistry) Load(ctx context.Context) error {
+	if r.repo == nil {
+		return errReadOnlyCatalog
+	}
+
+	currencies, err := r.repo.ListCurrencies(ctx)
+	if err != nil {
+		return fmt.Errorf("can't load currencies: %w", err)
+	}
+
+	r.replace(currencies)
+	return nil
+}
+
+// RunRefresh reloads catalog every interval until context is canceled,
+// so changes made through other instances become visible.
+func (r *CurrencyRegistry) RunRefresh(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Load(ctx); err != nil {
+				log.Println(fmt.Errorf("failed to refresh currencies %w", err))
+			}
+		}
+	}
+}
+
+func (r *CurrencyRegistry) ListCurrencies(ctx context.Context) ([]domain.CurrencyInfo, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]domain.CurrencyInfo, 0, len(r.currencies))
+	for _, c := range r.currencies {
+		result = append(result, c)
+	}
+	slices.SortFunc(result, func(a, b domain.CurrencyInfo) int {
+		return strings.Compare(string(a.Code), string(b.Code))
+	})
+
+	return result, nil
+}
+
+func (r *CurrencyRegistry) GetCurrency(ctx context.Context, code domain.Currency) (domain.CurrencyInfo, error) {
+	c, ok := r.lookup(code)
+	if !ok {
+		return domain.CurrencyInfo{}, domain.ErrCurrencyNotFound
+	}
+	return c, nil
+}
+
+func (r *CurrencyRegistry) SetCurrencyEnabled(ctx context.Context, code domain.Currency, enabled bool) (domain.CurrencyInfo, error) {
+	if r.repo == nil {
+		return domain.CurrencyInfo{}, errReadOnlyCatalog
+	}
+
+	code, err := domain.ParseCurrency(string(code))
+	if err != nil {
+		return domain.CurrencyInfo{}, err
+	}
+
+	c, err := r.repo.SetCurrencyEnabled(ctx, code, enabled)
+	if err != nil {
+		return domain.CurrencyInfo{}, err
+	}
+
+	r.mu.Lock()
+	r.currencies[c.Code] = c
+	r.mu.Unlock()
+	return c, nil
+}
+
+// Supported reports whether new wallets and transactions can use the currency
+func (r *CurrencyRegistry) Supported(code domain.Currency) bool {
+	c, ok := r.lookup(code)
+	return ok && c.Enabled
+}
+
+func (r *CurrencyRegistry) lookup(code domain.Currency) (domain.CurrencyInfo, bool) {
+	code, err := domain.ParseCurrency(string(code))
+	if err != nil {
+		return domain.CurrencyInfo{}, false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c, ok := r.currencies[code]
+	return c, ok
+}
+
+func (r *CurrencyRegistry) replace(currencies []domain.CurrencyInfo) {
+	m := make(map[domain.Currency]domain.CurrencyInfo, len(currencies))
+	for _, c := range currencies {
+		m[c.Code] = c
+	}
+
+	r.mu.Lock()
+	r.currencies = m
+	r.mu.Unlock()
+}
@@ -0,0 +1,86 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ximura/gowallet/internal/core/domain"
+	"github.com/ximura/gowallet/internal/core/ports/mocks"
+	"github.com/ximura/gowallet/internal/core/service"
+	"gotest.tools/v3/assert"
+)
+
+func TestCurrencyRegistry(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	tErr := errors.New("test error")
+	catalog := []domain.CurrencyInfo{
+		{Code: "jpy", NumericCode: 392, Exponent: 0, Enabled: true},
+		{Code: "gbp", NumericCode: 826, Exponent: 2, Enabled: false},
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		registry := service.NewCurrencyRegistry(nil)
+		assert.Assert(t, registry.Supported("usd"))
+		assert.Assert(t, registry.Supported("JPY"))
+		assert.Assert(t, !registry.Supported("gbp"))
+
+		_, err := registry.SetCurrencyEnabled(ctx, "gbp", true)
+		assert.ErrorContains(t, err, "can't be changed")
+	})
+
+	t.Run("load", func(t *testing.T) {
+		repository := mocks.NewMockCurrencyRepository(ctrl)
+		repository.EXPECT().ListCurrencies(ctx).Return(catalog, nil)
+		registry := service.NewCurrencyRegistry(repository)
+		assert.NilError(t, registry.Load(ctx))
+
+		assert.Assert(t, !registry.Supported("usd"))
+		assert.Assert(t, !registry.Supported("gbp"))
+		jpy, err := registry.GetCurrency(ctx, "JPY")
+		assert.NilError(t, err)
+		assert.Equal(t, jpy.Exponent, 0)
+
+		_, err = registry.GetCurrency(ctx, "usd")
+		assert.ErrorIs(t, err, domain.ErrCurrencyNotFound)
+
+		list, err := registry.ListCurrencies(ctx)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, list, []domain.CurrencyInfo{catalog[1], catalog[0]})
+	})
+
+	t.Run("load error keeps catalog", func(t *testing.T) {
+		repository := mocks.NewMockCurrencyRepository(ctrl)
+		repository.EXPECT().ListCurrencies(ctx).Return(nil, tErr)
+		registry := service.NewCurrencyRegistry(repository)
+
+		assert.ErrorIs(t, registry.Load(ctx), tErr)
+		assert.Assert(t, registry.Supported("usd"))
+	})
+
+	t.Run("enable", func(t *testing.T) {
+		repository := mocks.NewMockCurrencyRepository(ctrl)
+		repository.EXPECT().ListCurrencies(ctx).Return(catalog, nil)
+		enabled := catalog[1]
+		enabled.Enabled = true
+		repository.EXPECT().SetCurrencyEnabled(ctx, domain.Currency("gbp"), true).Return(enabled, nil)
+		registry := service.NewCurrencyRegistry(repository)
+		assert.NilError(t, registry.Load(ctx))
+
+		c, err := registry.SetCurrencyEnabled(ctx, "GBP", true)
+		assert.NilError(t, err)
+		assert.Equal(t, c, enabled)
+		assert.Assert(t, registry.Supported("gbp"))
+	})
+
+	t.Run("enable unknown", func(t *testing.T) {
+		repository := mocks.NewMockCurrencyRepository(ctrl)
+		repository.EXPECT().SetCurrencyEnabled(ctx, domain.Currency("xxx"), true).Return(domain.CurrencyInfo{}, domain.ErrCurrencyNotFound)
+		registry := service.NewCurrencyRegistry(repository)
+
+		_, err := registry.SetCurrencyEnabled(ctx, "xxx", true)
+		assert.ErrorIs(t, err, domain.ErrCurrencyNotFound)
+	})
+}
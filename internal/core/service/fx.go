@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ximura/gowallet/internal/core/domain"
+	"github.com/ximura/gowallet/internal/core/ports"
+)
+
+var _ ports.FXService = (*FXService)(nil)
+
+var ErrSameCurrency = domain.NewError(domain.KindInvalidArgument, "SAME_CURRENCY", "currencies of exchange are the same")
+
+// DefaultQuoteTTL is time quoted rate stays locked
+const DefaultQuoteTTL = 30 * time.Second
+
+// quoteRetention is time expired quote is kept for, so that conversion checked
+// just before quote expired is recorded before the quote is deleted
+const quoteRetention = time.Hour
+
+// FXService converts amounts between currencies with rates of provider,
+// spread is charged on top of every rate.
+type FXService struct {
+	rates      ports.RateProvider
+	quotes     ports.QuoteRepository
+	currencies *CurrencyRegistry
+	spreadBps  int
+	quoteTTL   time.Duration
+}
+
+type FXOption func(*FXService)
+
+// WithSpread sets spread charged on conversions, in basis points
+func WithSpread(bps int) FXOption {
+	return func(f *FXService) {
+		f.spreadBps = bps
+	}
+}
+
+// WithQuoteTTL sets time quoted rate stays locked
+func WithQuoteTTL(ttl time.Duration) FXOption {
+	return func(f *FXService) {
+		f.quoteTTL = ttl
+	}
+}
+
+func NewFXService(rates ports.RateProvider, quotes ports.QuoteRepository, currencies *CurrencyRegistry, opts ...FXOption) *FXService {
+	f := &FXService{
+		rates:      rates,
+		quotes:     quotes,
+		currencies: currencies,
+		quoteTTL:   DefaultQuoteTTL,
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	return f
+}
+
+func (f *FXService) Quote(ctx context.Context, from, to domain.Currency) (domain.Quote, error) {
+	quote, err := f.currentQuote(ctx, from, to)
+	if err != nil {
+		return domain.Quote{}, err
+	}
+
+	quote.ID = uuid.New()
+	quote.ExpiresAt = time.Now().Add(f.quoteTTL)
+	return f.quotes.CreateQuote(ctx, quote)
+}
+
+// Convert exchanges amount with rate locked by quote, current rate is used when quoteID is uuid.Nil
+func (f *FXService) Convert(ctx context.Context, amount domain.Amount, from, to domain.Currency, quoteID uuid.UUID) (domain.Conversion, error) {
+	var quote domain.Quote
+	if quoteID == uuid.Nil {
+		q, err := f.currentQuote(ctx, from, to)
+		if err != nil {
+			return domain.Conversion{}, err
+		}
+		quote = q
+	} else {
+		q, err := f.quotes.GetQuote(ctx, quoteID)
+		if err != nil {
+			return domain.Conversion{}, fmt.Errorf("can't get quote %s: %w", quoteID, err)
+		}
+		if q.From != from || q.To != to {
+			return domain.Conversion{}, fmt.Errorf("%w, quote is for %s to %s", domain.ErrQuoteMismatch, q.From, q.To)
+		}
+		if !q.IsValid(time.Now()) {
+			return domain.Conversion{}, domain.ErrQuoteExpired
+		}
+		quote = q
+	}
+
+	fromInfo, err := f.currencies.GetCurrency(ctx, from)
+	if err != nil {
+		return domain.Conversion{}, fmt.Errorf("%w: %s", err, from)
+	}
+	toInfo, err := f.currencies.GetCurrency(ctx, to)
+	if err != nil {
+		return domain.Conversion{}, fmt.Errorf("%w: %s", err, to)
+	}
+
+	return quote.Convert(amount, fromInfo.Exponent, toInfo.Exponent)
+}
+
+// DeleteExpiredQuotes deletes quotes that can't be used anymore,
+// quotes of converted transactions are kept with them.
+func (f *FXService) DeleteExpiredQuotes(ctx context.Context) (int, error) {
+	return f.quotes.DeleteExpiredQuotes(ctx, time.Now().Add(-quoteRetention))
+}
+
+// RunQuoteCleanup deletes expired quotes every interval until context is canceled
+func (f *FXService) RunQuoteCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			count, err := f.DeleteExpiredQuotes(ctx)
+			if err != nil {
+				log.Println(fmt.Errorf("failed to delete expired quotes %w", err))
+				continue
+			}
+			if count > 0 {
+				log.Printf("deleted %d expired quotes\n", count)
+			}
+		}
+	}
+}
+
+// currentQuote returns quote of current rate that is not stored
+func (f *FXService) currentQuote(ctx context.Context, from, to domain.Currency) (domain.Quote, error) {
+	for _, c := range []domain.Currency{from, to} {
+		if !f.currencies.Supported(c) {
+			return domain.Quote{}, fmt.Errorf("%w: %s", ErrUnsuportedCurrency, c)
+		}
+	}
+	if from == to {
+		return domain.Quote{}, ErrSameCurrency
+	}
+
+	rate, err := f.rates.Rate(ctx, from, to)
+	if err != nil {
+		return domain.Quote{}, fmt.Errorf("can't get %s/%s rate: %w", from, to, err)
+	}
+
+	return domain.Quote{
+		From:      from,
+		To:        to,
+		Rate:      rate,
+		SpreadBps: f.spreadBps,
+	}, nil
+}
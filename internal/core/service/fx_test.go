@@ -0,0 +1,269 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/ximura/gowallet/internal/core/domain"
+	"github.com/ximura/gowallet/internal/core/ports/mocks"
+	"github.com/ximura/gowallet/internal/core/service"
+	"gotest.tools/v3/assert"
+)
+
+const eurUsd = domain.Rate(1_085_000_000)
+
+func TestFXQuote(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+
+	tests := map[string]struct {
+		from, to domain.Currency
+		err      error
+		mocks    func(r *mocks.MockRateProvider, q *mocks.MockQuoteRepository)
+	}{
+		"Ok": {
+			from: "eur",
+			to:   "usd",
+			mocks: func(r *mocks.MockRateProvider, q *mocks.MockQuoteRepository) {
+				r.EXPECT().Rate(ctx, domain.Currency("eur"), domain.Currency("usd")).Return(eurUsd, nil)
+				q.EXPECT().CreateQuote(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, quote domain.Quote) (domain.Quote, error) {
+					assert.Assert(t, quote.ID != uuid.Nil)
+					assert.Equal(t, quote.Rate, eurUsd)
+					assert.Equal(t, quote.SpreadBps, 50)
+					assert.Assert(t, time.Until(quote.ExpiresAt) > 50*time.Second)
+					return quote, nil
+				})
+			},
+		},
+		"unsupported currency": {
+			from:  "gbp",
+			to:    "usd",
+			err:   service.ErrUnsuportedCurrency,
+			mocks: func(r *mocks.MockRateProvider, q *mocks.MockQuoteRepository) {},
+		},
+		"same currency": {
+			from:  "usd",
+			to:    "usd",
+			err:   service.ErrSameCurrency,
+			mocks: func(r *mocks.MockRateProvider, q *mocks.MockQuoteRepository) {},
+		},
+		"rate not available": {
+			from: "eur",
+			to:   "uah",
+			err:  domain.ErrRateNotAvailable,
+			mocks: func(r *mocks.MockRateProvider, q *mocks.MockQuoteRepository) {
+				r.EXPECT().Rate(ctx, domain.Currency("eur"), domain.Currency("uah")).Return(domain.Rate(0), domain.ErrRateNotAvailable)
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			rates := mocks.NewMockRateProvider(ctrl)
+			quotes := mocks.NewMockQuoteRepository(ctrl)
+			tt.mocks(rates, quotes)
+			fx := service.NewFXService(rates, quotes, service.NewCurrencyRegistry(nil),
+				service.WithSpread(50), service.WithQuoteTTL(time.Minute))
+
+			_, err := fx.Quote(ctx, tt.from, tt.to)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NilError(t, err)
+			}
+		})
+	}
+}
+
+func TestFXConvert(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	quoteID := uuid.New()
+	quote := domain.Quote{ID: quoteID, From: "eur", To: "usd", Rate: eurUsd, SpreadBps: 0, ExpiresAt: time.Now().Add(time.Minute)}
+
+	tests := map[string]struct {
+		quoteID uuid.UUID
+		result  domain.Conversion
+		err     error
+		mocks   func(r *mocks.MockRateProvider, q *mocks.MockQuoteRepository)
+	}{
+		"current rate": {
+			result: domain.Conversion{FromAmount: 1000, FromCurrency: "eur", ToAmount: 1079, ToCurrency: "usd", Rate: eurUsd, SpreadBps: 50},
+			mocks: func(r *mocks.MockRateProvider, q *mocks.MockQuoteRepository) {
+				r.EXPECT().Rate(ctx, domain.Currency("eur"), domain.Currency("usd")).Return(eurUsd, nil)
+			},
+		},
+		"locked rate": {
+			quoteID: quoteID,
+			result:  domain.Conversion{FromAmount: 1000, FromCurrency: "eur", ToAmount: 1085, ToCurrency: "usd", Rate: eurUsd, QuoteID: quoteID},
+			mocks: func(r *mocks.MockRateProvider, q *mocks.MockQuoteRepository) {
+				q.EXPECT().GetQuote(ctx, quoteID).Return(quote, nil)
+			},
+		},
+		"quote not found": {
+			quoteID: quoteID,
+			err:     domain.ErrQuoteNotFound,
+			mocks: func(r *mocks.MockRateProvider, q *mocks.MockQuoteRepository) {
+				q.EXPECT().GetQuote(ctx, quoteID).Return(domain.Quote{}, domain.ErrQuoteNotFound)
+			},
+		},
+		"quote expired": {
+			quoteID: quoteID,
+			err:     domain.ErrQuoteExpired,
+			mocks: func(r *mocks.MockRateProvider, q *mocks.MockQuoteRepository) {
+				expired := quote
+				expired.ExpiresAt = time.Now().Add(-time.Second)
+				q.EXPECT().GetQuote(ctx, quoteID).Return(expired, nil)
+			},
+		},
+		"quote of other currencies": {
+			quoteID: quoteID,
+			err:     domain.ErrQuoteMismatch,
+			mocks: func(r *mocks.MockRateProvider, q *mocks.MockQuoteRepository) {
+				other := quote
+				other.From, other.To = "usd", "eur"
+				q.EXPECT().GetQuote(ctx, quoteID).Return(other, nil)
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			rates := mocks.NewMockRateProvider(ctrl)
+			quotes := mocks.NewMockQuoteRepository(ctrl)
+			tt.mocks(rates, quotes)
+			fx := service.NewFXService(rates, quotes, service.NewCurrencyRegistry(nil), service.WithSpread(50))
+
+			c, err := fx.Convert(ctx, 1000, "eur", "usd", tt.quoteID)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, c, tt.result)
+		})
+	}
+}
+
+func TestProcessConvertedTransaction(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	request := domain.Transaction{ID: uuid.New(), WalletID: 1, Amount: 1000, Currency: "eur", Conversion: &domain.Conversion{}}
+	converted := request
+	converted.Amount = 1085
+	converted.Currency = "usd"
+	converted.Conversion = &domain.Conversion{FromAmount: 1000, FromCurrency: "eur", ToAmount: 1085, ToCurrency: "usd", Rate: eurUsd}
+
+	tests := map[string]struct {
+		withFX  bool
+		request domain.Transaction
+		err     error
+		mocks   func(m *mocks.MockWalletRepository, r *mocks.MockRateProvider)
+	}{
+		"converted": {
+			withFX:  true,
+			request: request,
+			mocks: func(m *mocks.MockWalletRepository, r *mocks.MockRateProvider) {
+				m.EXPECT().FindTransactions(ctx, request.ID).Return(nil, nil)
+				m.EXPECT().Get(ctx, request.WalletID).Return(domain.Wallet{ID: 1, Currency: "usd"}, nil)
+				r.EXPECT().Rate(ctx, domain.Currency("eur"), domain.Currency("usd")).Return(eurUsd, nil)
+				m.EXPECT().ProcessTransaction(ctx, converted).Return(domain.Wallet{ID: 1, Currency: "usd", Amount: 1085}, nil)
+			},
+		},
+		"wallet currency": {
+			withFX:  true,
+			request: request,
+			mocks: func(m *mocks.MockWalletRepository, r *mocks.MockRateProvider) {
+				plain := request
+				plain.Conversion = nil
+				m.EXPECT().FindTransactions(ctx, request.ID).Return(nil, nil)
+				m.EXPECT().Get(ctx, request.WalletID).Return(domain.Wallet{ID: 1, Currency: "eur"}, nil)
+				m.EXPECT().ProcessTransaction(ctx, plain).Return(domain.Wallet{ID: 1, Currency: "eur", Amount: 1000}, nil)
+			},
+		},
+		"replay of converted": {
+			withFX:  true,
+			request: request,
+			mocks: func(m *mocks.MockWalletRepository, r *mocks.MockRateProvider) {
+				stored := converted
+				stored.Balance = 1085
+				m.EXPECT().Get(ctx, request.WalletID).Return(domain.Wallet{ID: 1, Currency: "usd", Amount: 2000}, nil).Times(2)
+				m.EXPECT().FindTransactions(ctx, request.ID).Return([]domain.Transaction{stored}, nil)
+			},
+		},
+		"conversion not configured": {
+			request: request,
+			err:     domain.ErrRateNotAvailable,
+			mocks: func(m *mocks.MockWalletRepository, r *mocks.MockRateProvider) {
+				m.EXPECT().FindTransactions(ctx, request.ID).Return(nil, nil)
+				m.EXPECT().Get(ctx, request.WalletID).Return(domain.Wallet{ID: 1, Currency: "usd"}, nil)
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			repository := mocks.NewMockWalletRepository(ctrl)
+			rates := mocks.NewMockRateProvider(ctrl)
+			tt.mocks(repository, rates)
+			var opts []service.Option
+			if tt.withFX {
+				opts = append(opts, service.WithFX(service.NewFXService(rates, mocks.NewMockQuoteRepository(ctrl), service.NewCurrencyRegistry(nil))))
+			}
+			wallet := service.NewWalletService(repository, opts...)
+
+			_, err := wallet.ProcessTransaction(ctx, tt.request)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NilError(t, err)
+			}
+		})
+	}
+}
+
+func TestConvertedTransfer(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	repository := mocks.NewMockWalletRepository(ctrl)
+	rates := mocks.NewMockRateProvider(ctrl)
+	fx := service.NewFXService(rates, mocks.NewMockQuoteRepository(ctrl), service.NewCurrencyRegistry(nil))
+	wallet := service.NewWalletService(repository, service.WithFX(fx))
+
+	transfer := domain.Transfer{ID: uuid.New(), FromWalletID: 1, ToWalletID: 2, Amount: 1000, Currency: "eur", Conversion: &domain.Conversion{}}
+	converted := transfer
+	converted.Conversion = &domain.Conversion{FromAmount: 1000, FromCurrency: "eur", ToAmount: 1085, ToCurrency: "usd", Rate: eurUsd}
+
+	repository.EXPECT().Get(ctx, transfer.FromWalletID).Return(domain.Wallet{ID: 1, Currency: "eur"}, nil)
+	repository.EXPECT().Get(ctx, transfer.ToWalletID).Return(domain.Wallet{ID: 2, Currency: "usd"}, nil)
+	repository.EXPECT().FindTransactions(ctx, transfer.ID).Return(nil, nil)
+	rates.EXPECT().Rate(ctx, domain.Currency("eur"), domain.Currency("usd")).Return(eurUsd, nil)
+	repository.EXPECT().Transfer(ctx, converted).Return(domain.Wallet{ID: 1}, domain.Wallet{ID: 2}, nil)
+
+	_, _, err := wallet.Transfer(ctx, transfer)
+	assert.NilError(t, err)
+}
+
+func TestFXDeleteExpiredQuotes(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	quotes := mocks.NewMockQuoteRepository(ctrl)
+	fx := service.NewFXService(mocks.NewMockRateProvider(ctrl), quotes, service.NewCurrencyRegistry(nil))
+
+	// quotes that expired recently are kept for conversions that are being recorded
+	quotes.EXPECT().DeleteExpiredQuotes(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, before time.Time) (int, error) {
+		assert.Assert(t, time.Since(before) >= time.Hour)
+		assert.Assert(t, time.Since(before) < time.Hour+time.Minute)
+		return 2, nil
+	})
+
+	count, err := fx.DeleteExpiredQuotes(ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, count, 2)
+}
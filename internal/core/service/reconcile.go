@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ximura/gowallet/internal/core/domain"
+	"github.com/ximura/gowallet/internal/core/ports"
+)
+
+var _ ports.ReconciliationService = (*ReconciliationService)(nil)
+
+// number of wallets loaded from repository at once
+const reconcileBatchSize = 1000
+
+type ReconciliationService struct {
+	repo ports.ReconciliationRepository
+}
+
+func NewReconciliationService(repo ports.ReconciliationRepository) ReconciliationService {
+	return ReconciliationService{repo: repo}
+}
+
+func (s *ReconciliationService) Reconcile(ctx context.Context, account uuid.UUID) (domain.ReconciliationReport, error) {
+	var report domain.ReconciliationReport
+	filter := domain.ReconcileFilter{
+		Account: account,
+		Limit:   reconcileBatchSize,
+	}
+
+	for {
+		balances, err := s.repo.RecomputeBalances(ctx, filter)
+		if err != nil {
+			return domain.ReconciliationReport{}, fmt.Errorf("can't recompute balances after wallet %d: %w", filter.AfterID, err)
+		}
+
+		for _, b := range balances {
+			if b.HasDrift() {
+				report.Drift = append(report.Drift, b)
+			}
+		}
+		report.Checked += len(balances)
+
+		if len(balances) < filter.Limit {
+			return report, nil
+		}
+		filter.AfterID = balances[len(balances)-1].WalletID
+	}
+}
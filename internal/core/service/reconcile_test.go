@@ -0,0 +1,78 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/ximura/gowallet/internal/core/domain"
+	"github.com/ximura/gowallet/internal/core/ports/mocks"
+	"github.com/ximura/gowallet/internal/core/service"
+	"gotest.tools/v3/assert"
+)
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	tErr := errors.New("test error")
+	account := uuid.New()
+
+	fullBatch := make([]domain.BalanceReport, 1000)
+	for i := range fullBatch {
+		fullBatch[i] = domain.BalanceReport{WalletID: i + 1, Balance: 10, HistoryBalance: 10, LedgerBalance: 10}
+	}
+	drift := domain.BalanceReport{WalletID: 1001, Balance: 10, HistoryBalance: 5, LedgerBalance: 10}
+
+	tests := map[string]struct {
+		err     error
+		checked int
+		drift   []domain.BalanceReport
+		mocks   func(m *mocks.MockReconciliationRepository)
+	}{
+		"no drift": {
+			checked: 1,
+			mocks: func(m *mocks.MockReconciliationRepository) {
+				m.EXPECT().RecomputeBalances(ctx, domain.ReconcileFilter{Account: account, Limit: 1000}).
+					Return([]domain.BalanceReport{{WalletID: 1, Balance: 10, HistoryBalance: 10, LedgerBalance: 10}}, nil)
+			},
+		},
+		"drift in second batch": {
+			checked: 1001,
+			drift:   []domain.BalanceReport{drift},
+			mocks: func(m *mocks.MockReconciliationRepository) {
+				m.EXPECT().RecomputeBalances(ctx, domain.ReconcileFilter{Account: account, Limit: 1000}).
+					Return(fullBatch, nil)
+				m.EXPECT().RecomputeBalances(ctx, domain.ReconcileFilter{Account: account, AfterID: 1000, Limit: 1000}).
+					Return([]domain.BalanceReport{drift}, nil)
+			},
+		},
+		"repository error": {
+			err: fmt.Errorf("can't recompute balances after wallet %d: %w", 0, tErr),
+			mocks: func(m *mocks.MockReconciliationRepository) {
+				m.EXPECT().RecomputeBalances(ctx, gomock.Any()).Return(nil, tErr)
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			repository := mocks.NewMockReconciliationRepository(ctrl)
+			tt.mocks(repository)
+			reconciliation := service.NewReconciliationService(repository)
+
+			report, err := reconciliation.Reconcile(ctx, account)
+			if tt.err != nil {
+				assert.ErrorContains(t, err, tt.err.Error())
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.Equal(t, report.Checked, tt.checked)
+			assert.DeepEqual(t, report.Drift, tt.drift)
+		})
+	}
+}
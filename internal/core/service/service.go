@@ -4,8 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"slices"
+	"log"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/ximura/gowallet/internal/core/domain"
@@ -14,67 +15,724 @@ import (
 
 var _ ports.WalletService = (*WalletService)(nil)
 
-var ErrInvalitTransactionAmount = errors.New("invalid transaction amount")
-var ErrDuplicateTransaction = errors.New("duplicate transaction")
-var ErrUnsuportedCurrency = errors.New("unsupported currency")
+var ErrInvalitTransactionAmount = domain.ErrInvalidAmount
+var ErrDuplicateTransaction = domain.ErrDuplicateTransaction
+var ErrIdempotencyKeyReused = domain.ErrIdempotencyKeyReused
+var ErrUnsuportedCurrency = domain.NewError(domain.KindInvalidArgument, "UNSUPPORTED_CURRENCY", "unsupported currency")
+var ErrInvalidTimeRange = domain.NewError(domain.KindInvalidArgument, "INVALID_TIME_RANGE", "invalid time range")
+var ErrWatchNotAvailable = domain.NewError(domain.KindFailedPrecondition, "WATCH_NOT_AVAILABLE", "wallet changes are not available")
+var ErrSameWallet = domain.NewError(domain.KindInvalidArgument, "SAME_WALLET", "transfer source and destination are the same wallet")
 
-var SupportedCurrency = []string{"usd", "eur", "uah", "jpy"}
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 500
+)
+
+// DefaultHoldTTL is time after which not captured holds stop reserving funds
+const DefaultHoldTTL = 7 * 24 * time.Hour
 
 type WalletService struct {
-	repo ports.WalletRepository
+	repo       ports.WalletRepository
+	holdTTL    time.Duration
+	currencies *CurrencyRegistry
+	// converts transactions to wallet currency, nil when conversion is not configured
+	fx *FXService
+	// notifies about committed wallet changes, nil when watching is not configured
+	feed    ports.WalletFeed
+	metrics ports.WalletMetrics
+}
+
+type Option func(*WalletService)
+
+// WithHoldTTL sets time after which not captured holds expire
+func WithHoldTTL(ttl time.Duration) Option {
+	return func(w *WalletService) {
+		w.holdTTL = ttl
+	}
+}
+
+// WithCurrencyRegistry sets catalog of currencies wallets can use, domain.DefaultCurrencies by default
+func WithCurrencyRegistry(currencies *CurrencyRegistry) Option {
+	return func(w *WalletService) {
+		w.currencies = currencies
+	}
+}
+
+// WithFX enables conversion of transactions and transfers to wallet currency
+func WithFX(fx *FXService) Option {
+	return func(w *WalletService) {
+		w.fx = fx
+	}
 }
 
-func NewWalletService(repo ports.WalletRepository) WalletService {
-	return WalletService{repo: repo}
+// WithFeed enables watching wallet changes
+func WithFeed(feed ports.WalletFeed) Option {
+	return func(w *WalletService) {
+		w.feed = feed
+	}
+}
+
+// WithMetrics records processed, rejected and replayed transactions
+func WithMetrics(metrics ports.WalletMetrics) Option {
+	return func(w *WalletService) {
+		w.metrics = metrics
+	}
+}
+
+func NewWalletService(repo ports.WalletRepository, opts ...Option) WalletService {
+	w := WalletService{
+		repo:       repo,
+		holdTTL:    DefaultHoldTTL,
+		currencies: NewCurrencyRegistry(nil),
+		metrics:    noMetrics{},
+	}
+	for _, opt := range opts {
+		opt(&w)
+	}
+
+	return w
 }
 
 func (w *WalletService) Create(ctx context.Context, account uuid.UUID, currency domain.Currency) (domain.Wallet, error) {
-	if !isCurrencySupported(currency) {
-		return domain.Wallet{}, ErrUnsuportedCurrency
+	currency, err := w.supportedCurrency(currency)
+	if err != nil {
+		return domain.Wallet{}, err
 	}
 
 	return w.repo.Create(ctx, account, currency)
 }
 
+func (w *WalletService) CreateMultiCurrency(ctx context.Context, account uuid.UUID, currency domain.Currency) (domain.Wallet, error) {
+	currency, err := w.supportedCurrency(currency)
+	if err != nil {
+		return domain.Wallet{}, err
+	}
+
+	return w.repo.CreateMultiCurrency(ctx, account, currency)
+}
+
 func (w *WalletService) Get(ctx context.Context, id int) (domain.Wallet, error) {
 	return w.repo.Get(ctx, id)
 }
 
-func (w *WalletService) List(ctx context.Context, account uuid.UUID) ([]domain.Wallet, error) {
-	return w.repo.List(ctx, account)
+func (w *WalletService) List(ctx context.Context, filter domain.WalletFilter) (domain.WalletPage, error) {
+	if filter.Currency != "" {
+		currency, err := domain.ParseCurrency(string(filter.Currency))
+		if err != nil {
+			return domain.WalletPage{}, err
+		}
+		filter.Currency = currency
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+	limit = min(limit, MaxPageSize)
+
+	// fetch one extra wallet to find out if there is a next page
+	filter.Limit = limit + 1
+	wallets, err := w.repo.List(ctx, filter)
+	if err != nil {
+		return domain.WalletPage{}, fmt.Errorf("can't list wallets of account %s: %w", filter.Account, err)
+	}
+
+	page := domain.WalletPage{Wallets: wallets}
+	if len(wallets) > limit {
+		page.Wallets = wallets[:limit]
+		last := page.Wallets[limit-1]
+		page.Next = &domain.WalletCursor{Order: filter.Order, Currency: last.Currency, ID: last.ID}
+	}
+
+	return page, nil
 }
 
 func (w *WalletService) ProcessTransaction(ctx context.Context, transaction domain.Transaction) (domain.Wallet, error) {
-	if !isCurrencySupported(transaction.Currency) {
-		return domain.Wallet{}, ErrUnsuportedCurrency
+	wallet, err := w.processTransaction(ctx, transaction)
+	w.recordRejected(err)
+	return wallet, err
+}
+
+func (w *WalletService) processTransaction(ctx context.Context, transaction domain.Transaction) (domain.Wallet, error) {
+	currency, err := w.supportedCurrency(transaction.Currency)
+	if err != nil {
+		return domain.Wallet{}, err
+	}
+	transaction.Currency = currency
+
+	target, err := w.targetWallet(ctx, transaction.WalletID, transaction.Currency)
+	if err != nil {
+		return domain.Wallet{}, err
+	}
+	transaction.WalletID = target.ID
+
+	stored, err := w.findTransactions(ctx, transaction.ID)
+	if err != nil {
+		return domain.Wallet{}, err
+	}
+	if len(stored) > 0 {
+		w.metrics.DuplicateKey()
+		return w.replayTransaction(ctx, transaction, stored)
 	}
 
-	ok, err := w.repo.HasTransaction(ctx, transaction)
+	newPocket := createsPocket(target, transaction)
+	if !newPocket {
+		transaction, err = w.convertTransaction(ctx, target, transaction)
+		if err != nil {
+			return domain.Wallet{}, err
+		}
+	}
+
+	// currency and available funds are checked by repository against locked wallet
+	wallet, err := w.repo.ProcessTransaction(ctx, transaction)
+	if errors.Is(err, ErrDuplicateTransaction) {
+		// request with the same key was processed concurrently
+		w.metrics.DuplicateKey()
+		stored, err := w.findTransactions(ctx, transaction.ID)
+		if err != nil {
+			return domain.Wallet{}, err
+		}
+		if newPocket {
+			// concurrent request could apply the credit to pocket it created
+			target, err = w.targetWallet(ctx, target.ID, transaction.Currency)
+			if err != nil {
+				return domain.Wallet{}, err
+			}
+			transaction.WalletID = target.ID
+		}
+		return w.replayTransaction(ctx, transaction, stored)
+	}
 	if err != nil {
-		return domain.Wallet{}, fmt.Errorf("can't get transaction: %w", err)
+		return domain.Wallet{}, err
 	}
-	if ok {
-		return domain.Wallet{}, ErrDuplicateTransaction
+
+	w.metrics.TransactionProcessed(transaction)
+	return wallet, nil
+}
+
+// replayTransaction returns result of transaction processed with the same idempotency key
+func (w *WalletService) replayTransaction(ctx context.Context, transaction domain.Transaction, stored []domain.Transaction) (domain.Wallet, error) {
+	if len(stored) != 1 || !transaction.Matches(stored[0]) {
+		return domain.Wallet{}, ErrIdempotencyKeyReused
 	}
 
-	wallet, err := w.repo.Get(ctx, transaction.WalletID)
+	return w.walletAfter(ctx, stored[0])
+}
+
+func (w *WalletService) Transfer(ctx context.Context, transfer domain.Transfer) (domain.Wallet, domain.Wallet, error) {
+	from, to, err := w.transfer(ctx, transfer)
+	w.recordRejected(err)
+	return from, to, err
+}
+
+func (w *WalletService) transfer(ctx context.Context, transfer domain.Transfer) (domain.Wallet, domain.Wallet, error) {
+	currency, err := w.supportedCurrency(transfer.Currency)
 	if err != nil {
-		return domain.Wallet{}, fmt.Errorf("can't get wallet %d: %w", transaction.WalletID, err)
+		return domain.Wallet{}, domain.Wallet{}, err
+	}
+	transfer.Currency = currency
+
+	if transfer.Amount <= 0 {
+		return domain.Wallet{}, domain.Wallet{}, ErrInvalitTransactionAmount
 	}
 
-	if wallet.Currency != transaction.Currency {
-		return domain.Wallet{}, fmt.Errorf("wallet currency different from transaction, %s != %s", wallet.Currency, transaction.Currency)
+	if transfer.FromWalletID == transfer.ToWalletID {
+		return domain.Wallet{}, domain.Wallet{}, ErrSameWallet
 	}
 
-	nAmount := wallet.Amount + transaction.Amount
-	if nAmount < 0 {
-		return domain.Wallet{}, ErrInvalitTransactionAmount
+	source, err := w.targetWallet(ctx, transfer.FromWalletID, transfer.Currency)
+	if err != nil {
+		return domain.Wallet{}, domain.Wallet{}, err
+	}
+	destination, err := w.targetWallet(ctx, transfer.ToWalletID, transfer.Currency)
+	if err != nil {
+		return domain.Wallet{}, domain.Wallet{}, err
 	}
+	transfer.FromWalletID, transfer.ToWalletID = source.ID, destination.ID
 
-	return w.repo.ProcessTransaction(ctx, transaction)
+	stored, err := w.findTransactions(ctx, transfer.ID)
+	if err != nil {
+		return domain.Wallet{}, domain.Wallet{}, err
+	}
+	if len(stored) > 0 {
+		w.metrics.DuplicateKey()
+		return w.replayTransfer(ctx, transfer, stored)
+	}
+
+	_, credit := transfer.Transactions()
+	newPocket := createsPocket(destination, credit)
+	if !newPocket {
+		transfer, err = w.convertTransfer(ctx, destination, transfer)
+		if err != nil {
+			return domain.Wallet{}, domain.Wallet{}, err
+		}
+	}
+
+	from, to, err := w.repo.Transfer(ctx, transfer)
+	if errors.Is(err, ErrDuplicateTransaction) {
+		w.metrics.DuplicateKey()
+		stored, err := w.findTransactions(ctx, transfer.ID)
+		if err != nil {
+			return domain.Wallet{}, domain.Wallet{}, err
+		}
+		if newPocket {
+			// concurrent request could credit pocket of destination it created
+			destination, err = w.targetWallet(ctx, destination.ID, transfer.Currency)
+			if err != nil {
+				return domain.Wallet{}, domain.Wallet{}, err
+			}
+			transfer.ToWalletID = destination.ID
+		}
+		return w.replayTransfer(ctx, transfer, stored)
+	}
+	if err != nil {
+		return domain.Wallet{}, domain.Wallet{}, err
+	}
+
+	debit, credit := transfer.Transactions()
+	w.metrics.TransactionProcessed(debit)
+	w.metrics.TransactionProcessed(credit)
+	return from, to, nil
+}
+
+// replayTransfer returns source and destination wallets of transfer processed with the same idempotency key
+func (w *WalletService) replayTransfer(ctx context.Context, transfer domain.Transfer, stored []domain.Transaction) (domain.Wallet, domain.Wallet, error) {
+	if !transfer.Matches(stored) {
+		return domain.Wallet{}, domain.Wallet{}, ErrIdempotencyKeyReused
+	}
+
+	debit, credit := stored[0], stored[1]
+	if debit.WalletID != transfer.FromWalletID {
+		debit, credit = credit, debit
+	}
+
+	from, err := w.walletAfter(ctx, debit)
+	if err != nil {
+		return domain.Wallet{}, domain.Wallet{}, err
+	}
+
+	to, err := w.walletAfter(ctx, credit)
+	if err != nil {
+		return domain.Wallet{}, domain.Wallet{}, err
+	}
+
+	return from, to, nil
+}
+
+func (w *WalletService) Refund(ctx context.Context, refund domain.Refund) (domain.Transaction, domain.Wallet, error) {
+	reversal, wallet, err := w.refund(ctx, refund)
+	w.recordRejected(err)
+	return reversal, wallet, err
+}
+
+func (w *WalletService) refund(ctx context.Context, refund domain.Refund) (domain.Transaction, domain.Wallet, error) {
+	if refund.Amount <= 0 {
+		return domain.Transaction{}, domain.Wallet{}, ErrInvalitTransactionAmount
+	}
+
+	stored, err := w.findTransactions(ctx, refund.ID)
+	if err != nil {
+		return domain.Transaction{}, domain.Wallet{}, err
+	}
+	if len(stored) > 0 {
+		w.metrics.DuplicateKey()
+		return w.replayRefund(ctx, refund, stored)
+	}
+
+	// amount left after previous refunds and available funds are checked by repository under lock
+	reversal, wallet, err := w.repo.Refund(ctx, refund)
+	if errors.Is(err, ErrDuplicateTransaction) {
+		w.metrics.DuplicateKey()
+		stored, err := w.findTransactions(ctx, refund.ID)
+		if err != nil {
+			return domain.Transaction{}, domain.Wallet{}, err
+		}
+		return w.replayRefund(ctx, refund, stored)
+	}
+	if err != nil {
+		return domain.Transaction{}, domain.Wallet{}, err
+	}
+
+	w.metrics.TransactionProcessed(reversal)
+	return reversal, wallet, nil
+}
+
+// replayRefund returns refund transaction processed with the same idempotency key
+func (w *WalletService) replayRefund(ctx context.Context, refund domain.Refund, stored []domain.Transaction) (domain.Transaction, domain.Wallet, error) {
+	if len(stored) != 1 || !refund.Matches(stored[0]) {
+		return domain.Transaction{}, domain.Wallet{}, ErrIdempotencyKeyReused
+	}
+
+	wallet, err := w.walletAfter(ctx, stored[0])
+	if err != nil {
+		return domain.Transaction{}, domain.Wallet{}, err
+	}
+
+	return stored[0], wallet, nil
+}
+
+// targetWallet returns wallet transaction in currency is applied to. Multi-currency wallet keeps
+// other currencies in pockets. Transaction in currency without pocket is applied to the wallet itself,
+// so that debit can be converted or rejected as currency mismatch and credit creates the pocket.
+func (w *WalletService) targetWallet(ctx context.Context, walletID int, currency domain.Currency) (domain.Wallet, error) {
+	wallet, err := w.repo.Get(ctx, walletID)
+	if err != nil {
+		return domain.Wallet{}, fmt.Errorf("can't get wallet %d: %w", walletID, err)
+	}
+
+	return routeWallet(wallet, currency), nil
 }
 
-func isCurrencySupported(currency domain.Currency) bool {
-	c := strings.ToLower(string(currency))
-	return slices.Contains(SupportedCurrency, c)
+// routeWallet returns wallet or pocket of wallet transaction in currency is applied to
+func routeWallet(wallet domain.Wallet, currency domain.Currency) domain.Wallet {
+	if !wallet.MultiCurrency || wallet.Currency == currency {
+		return wallet
+	}
+
+	if pocket, ok := wallet.PocketWallet(currency); ok {
+		return pocket
+	}
+
+	return wallet
 }
+
+// createsPocket reports whether transaction creates pocket of its wallet. Pocket isn't created here,
+// repository creates it in transaction that applies the credit, so rejected credit leaves no pocket.
+// Credit to new pocket needs no conversion.
+func createsPocket(wallet domain.Wallet, transaction domain.Transaction) bool {
+	return wallet.MultiCurrency && wallet.Currency != transaction.Currency && transaction.Amount > 0
+}
+
+// convertTransaction exchanges amount to wallet currency when request allows conversion
+func (w *WalletService) convertTransaction(ctx context.Context, wallet domain.Wallet, transaction domain.Transaction) (domain.Transaction, error) {
+	conversion, err := w.convert(ctx, wallet, transaction.Amount, transaction.Currency, transaction.Conversion)
+	if err != nil {
+		return domain.Transaction{}, err
+	}
+
+	transaction.Conversion = conversion
+	if conversion != nil {
+		transaction.Amount = conversion.ToAmount
+		transaction.Currency = conversion.ToCurrency
+	}
+
+	return transaction, nil
+}
+
+// convertTransfer exchanges credited amount to destination wallet currency when request allows conversion
+func (w *WalletService) convertTransfer(ctx context.Context, destination domain.Wallet, transfer domain.Transfer) (domain.Transfer, error) {
+	conversion, err := w.convert(ctx, destination, transfer.Amount, transfer.Currency, transfer.Conversion)
+	if err != nil {
+		return domain.Transfer{}, err
+	}
+
+	transfer.Conversion = conversion
+	return transfer, nil
+}
+
+// convert returns conversion of amount to currency of wallet, nil when conversion is not requested or not needed.
+// Wallet currency never changes, so it's checked without lock.
+func (w *WalletService) convert(ctx context.Context, wallet domain.Wallet, amount domain.Amount, currency domain.Currency, request *domain.Conversion) (*domain.Conversion, error) {
+	if request == nil {
+		return nil, nil
+	}
+	if wallet.Currency == currency {
+		return nil, nil
+	}
+	if w.fx == nil {
+		return nil, fmt.Errorf("%w: currency conversion is not configured", domain.ErrRateNotAvailable)
+	}
+
+	conversion, err := w.fx.Convert(ctx, amount, currency, wallet.Currency, request.QuoteID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &conversion, nil
+}
+
+func (w *WalletService) findTransactions(ctx context.Context, id uuid.UUID) ([]domain.Transaction, error) {
+	stored, err := w.repo.FindTransactions(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("can't get transaction: %w", err)
+	}
+
+	return stored, nil
+}
+
+// walletAfter returns wallet with balance recorded right after stored transaction was applied.
+// Holds active at that time are not recorded, so whole balance is reported as available.
+func (w *WalletService) walletAfter(ctx context.Context, t domain.Transaction) (domain.Wallet, error) {
+	wallet, err := w.repo.Get(ctx, t.WalletID)
+	if err != nil {
+		return domain.Wallet{}, fmt.Errorf("can't get wallet %d: %w", t.WalletID, err)
+	}
+
+	wallet.Amount = t.Balance
+	wallet.Held = 0
+	return wallet, nil
+}
+
+// WatchWallet sends current state of wallet and then state after every change until context is done.
+// Client that reconnects passes the last version it has seen, state is sent only when it's newer.
+// Changes that happen while state is read are coalesced, so some intermediate versions may be skipped.
+func (w *WalletService) WatchWallet(ctx context.Context, walletID int, fromVersion int64, send func(domain.Wallet) error) error {
+	if w.feed == nil {
+		return ErrWatchNotAvailable
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// subscribe before the first read, so changes made right after it are not missed
+	changes := w.feed.Subscribe(ctx, walletID)
+	last := fromVersion
+	if fromVersion == 0 {
+		// new watch starts with current state even when wallet was never changed
+		last = -1
+	}
+
+	for {
+		wallet, err := w.repo.Get(ctx, walletID)
+		if err != nil {
+			return fmt.Errorf("can't get wallet %d: %w", walletID, err)
+		}
+		if wallet.Version > last {
+			if err := send(wallet); err != nil {
+				return err
+			}
+			last = wallet.Version
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case _, ok := <-changes:
+			if !ok {
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+func (w *WalletService) ListTransactions(ctx context.Context, filter domain.TransactionFilter) (domain.TransactionPage, error) {
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return domain.TransactionPage{}, ErrInvalidTimeRange
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+	limit = min(limit, MaxPageSize)
+
+	// fetch one extra row to find out if there is a next page
+	filter.Limit = limit + 1
+	transactions, err := w.repo.ListTransactions(ctx, filter)
+	if err != nil {
+		return domain.TransactionPage{}, fmt.Errorf("can't list transactions of wallet %d: %w", filter.WalletID, err)
+	}
+
+	page := domain.TransactionPage{Transactions: transactions}
+	if len(transactions) > limit {
+		page.Transactions = transactions[:limit]
+		last := page.Transactions[limit-1]
+		page.Next = &domain.TransactionCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	return page, nil
+}
+
+func (w *WalletService) Hold(ctx context.Context, hold domain.Hold) (domain.Hold, error) {
+	currency, err := w.supportedCurrency(hold.Currency)
+	if err != nil {
+		return domain.Hold{}, err
+	}
+	hold.Currency = currency
+
+	if hold.Amount <= 0 {
+		return domain.Hold{}, ErrInvalitTransactionAmount
+	}
+
+	target, err := w.targetWallet(ctx, hold.WalletID, hold.Currency)
+	if err != nil {
+		return domain.Hold{}, err
+	}
+	hold.WalletID = target.ID
+
+	hold.ExpiresAt = time.Now().Add(w.holdTTL)
+	created, err := w.repo.CreateHold(ctx, hold)
+	if errors.Is(err, ErrDuplicateTransaction) {
+		w.metrics.DuplicateKey()
+		return w.replayHold(ctx, hold)
+	}
+
+	return created, err
+}
+
+// replayHold returns hold created with the same idempotency key
+func (w *WalletService) replayHold(ctx context.Context, hold domain.Hold) (domain.Hold, error) {
+	stored, err := w.repo.GetHold(ctx, hold.ID)
+	if errors.Is(err, domain.ErrHoldNotFound) {
+		// key was used by transaction
+		return domain.Hold{}, ErrIdempotencyKeyReused
+	}
+	if err != nil {
+		return domain.Hold{}, fmt.Errorf("can't get hold %s: %w", hold.ID, err)
+	}
+	if !hold.Matches(stored) {
+		return domain.Hold{}, ErrIdempotencyKeyReused
+	}
+
+	return stored, nil
+}
+
+func (w *WalletService) CaptureHold(ctx context.Context, id uuid.UUID, amount domain.Amount) (domain.Hold, domain.Wallet, error) {
+	hold, wallet, err := w.captureHold(ctx, id, amount)
+	w.recordRejected(err)
+	return hold, wallet, err
+}
+
+func (w *WalletService) captureHold(ctx context.Context, id uuid.UUID, amount domain.Amount) (domain.Hold, domain.Wallet, error) {
+	hold, err := w.repo.GetHold(ctx, id)
+	if err != nil {
+		return domain.Hold{}, domain.Wallet{}, fmt.Errorf("can't get hold %s: %w", id, err)
+	}
+
+	if amount == 0 {
+		amount = hold.Amount
+	}
+	if amount < 0 || amount > hold.Amount {
+		return domain.Hold{}, domain.Wallet{}, ErrInvalitTransactionAmount
+	}
+
+	if !hold.IsActive(time.Now()) {
+		return w.replayCapture(ctx, hold, amount)
+	}
+
+	captured, wallet, err := w.repo.CaptureHold(ctx, hold, amount)
+	if errors.Is(err, domain.ErrHoldNotActive) {
+		// hold could be captured by request with the same key processed concurrently
+		hold, err := w.repo.GetHold(ctx, id)
+		if err != nil {
+			return domain.Hold{}, domain.Wallet{}, fmt.Errorf("can't get hold %s: %w", id, err)
+		}
+		return w.replayCapture(ctx, hold, amount)
+	}
+	if err != nil {
+		return domain.Hold{}, domain.Wallet{}, err
+	}
+
+	w.metrics.TransactionProcessed(captured.Capture(amount))
+	return captured, wallet, nil
+}
+
+// replayCapture returns captured hold and wallet balance recorded right after the capture,
+// hold that wasn't captured or was captured with different amount can't be captured again
+func (w *WalletService) replayCapture(ctx context.Context, hold domain.Hold, amount domain.Amount) (domain.Hold, domain.Wallet, error) {
+	if hold.Status != domain.HoldCaptured {
+		return domain.Hold{}, domain.Wallet{}, domain.ErrHoldNotActive
+	}
+
+	stored, err := w.findTransactions(ctx, hold.ID)
+	if err != nil {
+		return domain.Hold{}, domain.Wallet{}, err
+	}
+	if amount != hold.CapturedAmount || len(stored) != 1 || !hold.Capture(amount).Matches(stored[0]) {
+		return domain.Hold{}, domain.Wallet{}, ErrIdempotencyKeyReused
+	}
+
+	wallet, err := w.walletAfter(ctx, stored[0])
+	if err != nil {
+		return domain.Hold{}, domain.Wallet{}, err
+	}
+
+	w.metrics.DuplicateKey()
+	return hold, wallet, nil
+}
+
+func (w *WalletService) ReleaseHold(ctx context.Context, id uuid.UUID) (domain.Hold, error) {
+	return w.repo.ReleaseHold(ctx, id)
+}
+
+func (w *WalletService) FreezeWallet(ctx context.Context, change domain.StatusChange) (domain.Wallet, error) {
+	change.Status = domain.WalletFrozen
+	return w.changeStatus(ctx, change)
+}
+
+func (w *WalletService) UnfreezeWallet(ctx context.Context, change domain.StatusChange) (domain.Wallet, error) {
+	change.Status, change.BlockCredits = domain.WalletActive, false
+	return w.changeStatus(ctx, change)
+}
+
+func (w *WalletService) CloseWallet(ctx context.Context, change domain.StatusChange) (domain.Wallet, error) {
+	change.Status, change.BlockCredits = domain.WalletClosed, false
+	return w.changeStatus(ctx, change)
+}
+
+// changeStatus checks that change is attributed and explained,
+// status transition and balance are checked by repository under lock
+func (w *WalletService) changeStatus(ctx context.Context, change domain.StatusChange) (domain.Wallet, error) {
+	change.Actor, change.Reason = strings.TrimSpace(change.Actor), strings.TrimSpace(change.Reason)
+	if change.Actor == "" || change.Reason == "" {
+		return domain.Wallet{}, domain.ErrStatusChangeIncomplete
+	}
+
+	return w.repo.ChangeStatus(ctx, change)
+}
+
+// ExpireHolds closes holds that are past expiration time.
+// Expired holds stop reserving funds even before they are closed.
+func (w *WalletService) ExpireHolds(ctx context.Context) (int, error) {
+	return w.repo.ExpireHolds(ctx)
+}
+
+// RunHoldExpiry closes expired holds every interval until context is canceled
+func (w *WalletService) RunHoldExpiry(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			count, err := w.ExpireHolds(ctx)
+			if err != nil {
+				log.Println(fmt.Errorf("failed to expire holds %w", err))
+				continue
+			}
+			if count > 0 {
+				log.Printf("expired %d holds\n", count)
+			}
+		}
+	}
+}
+
+// supportedCurrency returns canonical form of currency enabled in the catalog
+func (w *WalletService) supportedCurrency(currency domain.Currency) (domain.Currency, error) {
+	canonical, err := domain.ParseCurrency(string(currency))
+	if err != nil {
+		return "", err
+	}
+	if !w.currencies.Supported(canonical) {
+		return "", ErrUnsuportedCurrency
+	}
+
+	return canonical, nil
+}
+
+// recordRejected records reason of domain error, other errors are failures of the service
+func (w *WalletService) recordRejected(err error) {
+	var domainErr *domain.Error
+	if errors.As(err, &domainErr) {
+		w.metrics.TransactionRejected(domainErr.Reason)
+	}
+}
+
+// noMetrics is used when metrics are not configured
+type noMetrics struct{}
+
+func (noMetrics) TransactionProcessed(domain.Transaction) {}
+func (noMetrics) TransactionRejected(string)              {}
+func (noMetrics) DuplicateKey()                           {}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
@@ -46,18 +47,25 @@ func TestCreate(t *testing.T) {
 			},
 		},
 		"error": {
-			currency: "test",
+			currency: "gbp",
 			err:      service.ErrUnsuportedCurrency,
 			mocks: func(c domain.Currency, m *mocks.MockWalletRepository) {
 			},
 		},
+		"invalid": {
+			currency: "test",
+			err:      domain.ErrInvalidCurrency,
+			mocks: func(c domain.Currency, m *mocks.MockWalletRepository) {
+			},
+		},
 	}
 	for name, tt := range tests {
 		tt := tt
 		t.Run(name, func(t *testing.T) {
 			repository := mocks.NewMockWalletRepository(ctrl)
 			wallet := service.NewWalletService(repository)
-			tt.mocks(tt.currency, repository)
+			canonical, _ := domain.ParseCurrency(string(tt.currency))
+			tt.mocks(canonical, repository)
 			_, err := wallet.Create(ctx, account, tt.currency)
 			if tt.err == nil {
 				assert.NilError(t, err)
@@ -68,6 +76,24 @@ func TestCreate(t *testing.T) {
 	}
 }
 
+func TestCreateMultiCurrency(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	repository := mocks.NewMockWalletRepository(ctrl)
+	wallet := service.NewWalletService(repository)
+	account := uuid.New()
+
+	repository.EXPECT().CreateMultiCurrency(ctx, account, domain.Currency("eur")).
+		Return(domain.Wallet{ID: 1, Account: account, Currency: "eur", MultiCurrency: true}, nil)
+
+	result, err := wallet.CreateMultiCurrency(ctx, account, "EUR")
+	assert.NilError(t, err)
+	assert.Assert(t, result.MultiCurrency)
+
+	_, err = wallet.CreateMultiCurrency(ctx, account, "gbp")
+	assert.ErrorIs(t, err, service.ErrUnsuportedCurrency)
+}
+
 func TestProcessTransaction(t *testing.T) {
 	ctx := context.Background()
 	ctrl := gomock.NewController(t)
@@ -77,6 +103,7 @@ func TestProcessTransaction(t *testing.T) {
 		WalletID: 1,
 		Amount:   100,
 	}
+	target := domain.Wallet{ID: transaction.WalletID, Currency: "usd"}
 
 	tests := map[string]struct {
 		currency domain.Currency
@@ -87,60 +114,72 @@ func TestProcessTransaction(t *testing.T) {
 			currency: "usd",
 			err:      fmt.Errorf("can't get transaction: %w", tErr),
 			mocks: func(m *mocks.MockWalletRepository) {
-				m.EXPECT().HasTransaction(ctx, transaction).Return(true, tErr)
+				m.EXPECT().Get(ctx, transaction.WalletID).Return(target, nil)
+				m.EXPECT().FindTransactions(ctx, transaction.ID).Return(nil, tErr)
 			},
 		},
 		"ErrUnsuportedCurrency": {
-			currency: "test",
+			currency: "gbp",
 			err:      service.ErrUnsuportedCurrency,
 			mocks: func(m *mocks.MockWalletRepository) {
 			},
 		},
-		"ErrDuplicateTransaction": {
+		"ErrIdempotencyKeyReused": {
 			currency: "usd",
-			err:      service.ErrDuplicateTransaction,
+			err:      service.ErrIdempotencyKeyReused,
 			mocks: func(m *mocks.MockWalletRepository) {
-				m.EXPECT().HasTransaction(ctx, transaction).Return(true, nil)
+				stored := transaction
+				stored.Currency = "usd"
+				stored.Amount = transaction.Amount + 1
+				m.EXPECT().Get(ctx, transaction.WalletID).Return(target, nil)
+				m.EXPECT().FindTransactions(ctx, transaction.ID).Return([]domain.Transaction{stored}, nil)
 			},
 		},
-		"can't get wallet for": {
+		"replay": {
 			currency: "usd",
-			err:      fmt.Errorf("can't get wallet %d: %w", transaction.WalletID, tErr),
 			mocks: func(m *mocks.MockWalletRepository) {
-				m.EXPECT().HasTransaction(ctx, transaction).Return(false, nil)
-				m.EXPECT().Get(ctx, transaction.WalletID).Return(domain.Wallet{}, tErr)
+				stored := transaction
+				stored.Currency = "usd"
+				stored.Balance = 150
+				m.EXPECT().Get(ctx, transaction.WalletID).Return(target, nil)
+				m.EXPECT().FindTransactions(ctx, transaction.ID).Return([]domain.Transaction{stored}, nil)
+				m.EXPECT().Get(ctx, transaction.WalletID).Return(domain.Wallet{Currency: "usd", Amount: 300}, nil)
 			},
 		},
-		"wallet currency different from transaction": {
+		"replay concurrent": {
 			currency: "usd",
-			err:      fmt.Errorf("wallet currency different from transaction, %s != %s", "eur", transaction.Currency),
 			mocks: func(m *mocks.MockWalletRepository) {
-				m.EXPECT().HasTransaction(ctx, transaction).Return(false, nil)
-				m.EXPECT().Get(ctx, transaction.WalletID).Return(domain.Wallet{
-					Currency: "eur",
-				}, nil)
+				stored := transaction
+				stored.Currency = "usd"
+				stored.Balance = 150
+				gomock.InOrder(
+					m.EXPECT().Get(ctx, transaction.WalletID).Return(target, nil),
+					m.EXPECT().FindTransactions(ctx, transaction.ID).Return(nil, nil),
+					m.EXPECT().ProcessTransaction(ctx, gomock.Any()).Return(domain.Wallet{}, service.ErrDuplicateTransaction),
+					m.EXPECT().FindTransactions(ctx, transaction.ID).Return([]domain.Transaction{stored}, nil),
+					m.EXPECT().Get(ctx, transaction.WalletID).Return(domain.Wallet{Currency: "usd", Amount: 300}, nil),
+				)
 			},
 		},
-		"ErrInvalitTransactionAmount": {
+		"ErrIdempotencyKeyReused key of hold": {
 			currency: "usd",
-			err:      service.ErrInvalitTransactionAmount,
+			err:      service.ErrIdempotencyKeyReused,
 			mocks: func(m *mocks.MockWalletRepository) {
-				m.EXPECT().HasTransaction(ctx, transaction).Return(false, nil)
-				m.EXPECT().Get(ctx, transaction.WalletID).Return(domain.Wallet{
-					Currency: "usd",
-					Amount:   -1 * (transaction.Amount + 10),
-				}, nil)
+				// hold claims its key without recording transaction until it's captured
+				gomock.InOrder(
+					m.EXPECT().Get(ctx, transaction.WalletID).Return(target, nil),
+					m.EXPECT().FindTransactions(ctx, transaction.ID).Return(nil, nil),
+					m.EXPECT().ProcessTransaction(ctx, gomock.Any()).Return(domain.Wallet{}, service.ErrDuplicateTransaction),
+					m.EXPECT().FindTransactions(ctx, transaction.ID).Return(nil, nil),
+				)
 			},
 		},
 		"Transaction error": {
 			currency: "usd",
 			err:      tErr,
 			mocks: func(m *mocks.MockWalletRepository) {
-				m.EXPECT().HasTransaction(ctx, transaction).Return(false, nil)
-				m.EXPECT().Get(ctx, transaction.WalletID).Return(domain.Wallet{
-					Currency: "usd",
-					Amount:   transaction.Amount + 10,
-				}, nil)
+				m.EXPECT().Get(ctx, transaction.WalletID).Return(target, nil)
+				m.EXPECT().FindTransactions(ctx, transaction.ID).Return(nil, nil)
 				m.EXPECT().ProcessTransaction(ctx, transaction).Return(domain.Wallet{}, tErr)
 			},
 		},
@@ -148,11 +187,8 @@ func TestProcessTransaction(t *testing.T) {
 			currency: "usd",
 			err:      nil,
 			mocks: func(m *mocks.MockWalletRepository) {
-				m.EXPECT().HasTransaction(ctx, transaction).Return(false, nil)
-				m.EXPECT().Get(ctx, transaction.WalletID).Return(domain.Wallet{
-					Currency: "usd",
-					Amount:   transaction.Amount + 10,
-				}, nil)
+				m.EXPECT().Get(ctx, transaction.WalletID).Return(target, nil)
+				m.EXPECT().FindTransactions(ctx, transaction.ID).Return(nil, nil)
 				m.EXPECT().ProcessTransaction(ctx, transaction).Return(domain.Wallet{}, nil)
 			},
 		},
@@ -175,3 +211,932 @@ func TestProcessTransaction(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessTransactionReplay(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	repository := mocks.NewMockWalletRepository(ctrl)
+	wallet := service.NewWalletService(repository)
+	transaction := domain.Transaction{
+		ID:       uuid.New(),
+		WalletID: 1,
+		Amount:   100,
+		Currency: "usd",
+	}
+	stored := transaction
+	stored.Balance = 150
+
+	repository.EXPECT().Get(ctx, transaction.WalletID).Return(domain.Wallet{ID: 1, Currency: "usd", Amount: 300, Held: 20}, nil).Times(2)
+	repository.EXPECT().FindTransactions(ctx, transaction.ID).Return([]domain.Transaction{stored}, nil)
+
+	result, err := wallet.ProcessTransaction(ctx, transaction)
+	assert.NilError(t, err)
+	assert.Equal(t, result.Amount, stored.Balance)
+	assert.Equal(t, result.Available(), stored.Balance)
+}
+
+func TestProcessPocketTransaction(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	parent := domain.Wallet{ID: 1, Currency: "eur", MultiCurrency: true, Pockets: []domain.Pocket{{WalletID: 5, Currency: "jpy", Amount: 1000}}}
+
+	tests := map[string]struct {
+		wallet   domain.Wallet
+		amount   domain.Amount
+		currency domain.Currency
+		mocks    func(m *mocks.MockWalletRepository)
+		walletID int
+	}{
+		"base currency": {
+			wallet:   parent,
+			amount:   100,
+			currency: "eur",
+			walletID: 1,
+			mocks:    func(m *mocks.MockWalletRepository) {},
+		},
+		"existing pocket": {
+			wallet:   parent,
+			amount:   -100,
+			currency: "jpy",
+			walletID: 5,
+			mocks:    func(m *mocks.MockWalletRepository) {},
+		},
+		"credit without pocket": {
+			wallet:   parent,
+			amount:   100,
+			currency: "usd",
+			walletID: 1,
+			mocks:    func(m *mocks.MockWalletRepository) {},
+		},
+		"debit without pocket": {
+			wallet:   parent,
+			amount:   -100,
+			currency: "usd",
+			walletID: 1,
+			mocks:    func(m *mocks.MockWalletRepository) {},
+		},
+		"single currency wallet": {
+			wallet:   domain.Wallet{ID: 1, Currency: "eur"},
+			amount:   100,
+			currency: "usd",
+			walletID: 1,
+			mocks:    func(m *mocks.MockWalletRepository) {},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			repository := mocks.NewMockWalletRepository(ctrl)
+			wallet := service.NewWalletService(repository)
+			request := domain.Transaction{ID: uuid.New(), WalletID: 1, Amount: tt.amount, Currency: tt.currency}
+			expected := request
+			expected.WalletID = tt.walletID
+
+			repository.EXPECT().Get(ctx, 1).Return(tt.wallet, nil)
+			tt.mocks(repository)
+			repository.EXPECT().FindTransactions(ctx, request.ID).Return(nil, nil)
+			repository.EXPECT().ProcessTransaction(ctx, expected).Return(domain.Wallet{ID: tt.walletID}, nil)
+
+			result, err := wallet.ProcessTransaction(ctx, request)
+			assert.NilError(t, err)
+			assert.Equal(t, result.ID, tt.walletID)
+		})
+	}
+}
+
+func TestProcessPocketTransactionConcurrently(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	repository := mocks.NewMockWalletRepository(ctrl)
+	wallet := service.NewWalletService(repository)
+	parent := domain.Wallet{ID: 1, Currency: "eur", MultiCurrency: true}
+	request := domain.Transaction{ID: uuid.New(), WalletID: 1, Amount: 100, Currency: "usd", Conversion: &domain.Conversion{}}
+	stored := domain.Transaction{ID: request.ID, WalletID: 6, Amount: 100, Currency: "usd", Balance: 100}
+
+	// credit isn't converted, concurrent request with the same key created pocket and applied the credit to it
+	repository.EXPECT().Get(ctx, 1).Return(parent, nil)
+	repository.EXPECT().FindTransactions(ctx, request.ID).Return(nil, nil)
+	repository.EXPECT().ProcessTransaction(ctx, request).Return(domain.Wallet{}, service.ErrDuplicateTransaction)
+	repository.EXPECT().FindTransactions(ctx, request.ID).Return([]domain.Transaction{stored}, nil)
+	parent.Pockets = []domain.Pocket{{WalletID: 6, Currency: "usd", Amount: 100}}
+	repository.EXPECT().Get(ctx, 1).Return(parent, nil)
+	repository.EXPECT().Get(ctx, 6).Return(domain.Wallet{ID: 6, Currency: "usd", ParentID: 1, Amount: 100}, nil)
+
+	result, err := wallet.ProcessTransaction(ctx, request)
+	assert.NilError(t, err)
+	assert.Equal(t, result.ID, 6)
+	assert.Equal(t, result.Amount, domain.Amount(100))
+}
+
+func TestTransfer(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	tErr := errors.New("test error")
+	transfer := domain.Transfer{
+		ID:           uuid.New(),
+		FromWalletID: 1,
+		ToWalletID:   2,
+		Amount:       100,
+		Currency:     "usd",
+	}
+	debit, credit := transfer.Transactions()
+	expectTargets := func(m *mocks.MockWalletRepository) {
+		m.EXPECT().Get(ctx, transfer.FromWalletID).Return(domain.Wallet{ID: transfer.FromWalletID, Currency: "usd"}, nil)
+		m.EXPECT().Get(ctx, transfer.ToWalletID).Return(domain.Wallet{ID: transfer.ToWalletID, Currency: "usd"}, nil)
+	}
+
+	tests := map[string]struct {
+		transfer func(t domain.Transfer) domain.Transfer
+		err      error
+		mocks    func(m *mocks.MockWalletRepository)
+	}{
+		"ErrUnsuportedCurrency": {
+			transfer: func(t domain.Transfer) domain.Transfer {
+				t.Currency = "gbp"
+				return t
+			},
+			err:   service.ErrUnsuportedCurrency,
+			mocks: func(m *mocks.MockWalletRepository) {},
+		},
+		"ErrInvalitTransactionAmount negative": {
+			transfer: func(t domain.Transfer) domain.Transfer {
+				t.Amount = -1
+				return t
+			},
+			err:   service.ErrInvalitTransactionAmount,
+			mocks: func(m *mocks.MockWalletRepository) {},
+		},
+		"ErrSameWallet": {
+			transfer: func(t domain.Transfer) domain.Transfer {
+				t.ToWalletID = t.FromWalletID
+				return t
+			},
+			err:   service.ErrSameWallet,
+			mocks: func(m *mocks.MockWalletRepository) {},
+		},
+		"can't get transaction": {
+			err: fmt.Errorf("can't get transaction: %w", tErr),
+			mocks: func(m *mocks.MockWalletRepository) {
+				expectTargets(m)
+				m.EXPECT().FindTransactions(ctx, transfer.ID).Return(nil, tErr)
+			},
+		},
+		"ErrIdempotencyKeyReused": {
+			err: service.ErrIdempotencyKeyReused,
+			mocks: func(m *mocks.MockWalletRepository) {
+				expectTargets(m)
+				m.EXPECT().FindTransactions(ctx, transfer.ID).Return([]domain.Transaction{debit}, nil)
+			},
+		},
+		"replay": {
+			mocks: func(m *mocks.MockWalletRepository) {
+				expectTargets(m)
+				m.EXPECT().FindTransactions(ctx, transfer.ID).Return([]domain.Transaction{credit, debit}, nil)
+				m.EXPECT().Get(ctx, transfer.FromWalletID).Return(domain.Wallet{Currency: "usd"}, nil)
+				m.EXPECT().Get(ctx, transfer.ToWalletID).Return(domain.Wallet{Currency: "usd"}, nil)
+			},
+		},
+		"destination without pocket": {
+			transfer: func(t domain.Transfer) domain.Transfer {
+				t.Conversion = &domain.Conversion{}
+				return t
+			},
+			mocks: func(m *mocks.MockWalletRepository) {
+				// credit isn't converted, repository creates pocket of destination in transaction of transfer
+				m.EXPECT().Get(ctx, transfer.FromWalletID).Return(domain.Wallet{ID: transfer.FromWalletID, Currency: "usd"}, nil)
+				m.EXPECT().Get(ctx, transfer.ToWalletID).Return(domain.Wallet{ID: transfer.ToWalletID, Currency: "eur", MultiCurrency: true}, nil)
+				m.EXPECT().FindTransactions(ctx, transfer.ID).Return(nil, nil)
+				request := transfer
+				request.Conversion = &domain.Conversion{}
+				m.EXPECT().Transfer(ctx, request).Return(domain.Wallet{}, domain.Wallet{}, nil)
+			},
+		},
+		"Ok": {
+			mocks: func(m *mocks.MockWalletRepository) {
+				expectTargets(m)
+				m.EXPECT().FindTransactions(ctx, transfer.ID).Return(nil, nil)
+				m.EXPECT().Transfer(ctx, transfer).Return(domain.Wallet{}, domain.Wallet{}, nil)
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			repository := mocks.NewMockWalletRepository(ctrl)
+			tt.mocks(repository)
+			wallet := service.NewWalletService(repository)
+
+			request := transfer
+			if tt.transfer != nil {
+				request = tt.transfer(transfer)
+			}
+			_, _, err := wallet.Transfer(ctx, request)
+			if tt.err == nil {
+				assert.NilError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err.Error())
+			}
+		})
+	}
+}
+
+func TestRefund(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	tErr := errors.New("test error")
+	refund := domain.Refund{
+		ID:            uuid.New(),
+		WalletID:      1,
+		TransactionID: uuid.New(),
+		Amount:        40,
+	}
+	refundTransaction := domain.Transaction{ID: refund.ID, WalletID: refund.WalletID, Currency: "usd", ReversalOf: refund.TransactionID}
+
+	tests := map[string]struct {
+		amount domain.Amount
+		err    error
+		mocks  func(m *mocks.MockWalletRepository)
+	}{
+		"ErrInvalitTransactionAmount negative": {
+			amount: -1,
+			err:    service.ErrInvalitTransactionAmount,
+			mocks:  func(m *mocks.MockWalletRepository) {},
+		},
+		"ErrIdempotencyKeyReused": {
+			err: service.ErrIdempotencyKeyReused,
+			mocks: func(m *mocks.MockWalletRepository) {
+				stored := refundTransaction
+				stored.Amount = -refund.Amount - 1
+				m.EXPECT().FindTransactions(ctx, refund.ID).Return([]domain.Transaction{stored}, nil)
+			},
+		},
+		"replay": {
+			mocks: func(m *mocks.MockWalletRepository) {
+				stored := refundTransaction
+				stored.Amount = -refund.Amount
+				m.EXPECT().FindTransactions(ctx, refund.ID).Return([]domain.Transaction{stored}, nil)
+				m.EXPECT().Get(ctx, refund.WalletID).Return(domain.Wallet{Currency: "usd"}, nil)
+			},
+		},
+		"can't get transaction": {
+			err: fmt.Errorf("can't get transaction: %w", tErr),
+			mocks: func(m *mocks.MockWalletRepository) {
+				m.EXPECT().FindTransactions(ctx, refund.ID).Return(nil, tErr)
+			},
+		},
+		"refund error": {
+			err: domain.ErrRefundExceedsOriginal,
+			mocks: func(m *mocks.MockWalletRepository) {
+				m.EXPECT().FindTransactions(ctx, refund.ID).Return(nil, nil)
+				m.EXPECT().Refund(ctx, refund).Return(domain.Transaction{}, domain.Wallet{}, domain.ErrRefundExceedsOriginal)
+			},
+		},
+		"Ok": {
+			mocks: func(m *mocks.MockWalletRepository) {
+				m.EXPECT().FindTransactions(ctx, refund.ID).Return(nil, nil)
+				m.EXPECT().Refund(ctx, refund).Return(domain.Transaction{}, domain.Wallet{}, nil)
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			repository := mocks.NewMockWalletRepository(ctrl)
+			tt.mocks(repository)
+			wallet := service.NewWalletService(repository)
+
+			request := refund
+			if tt.amount != 0 {
+				request.Amount = tt.amount
+			}
+			_, _, err := wallet.Refund(ctx, request)
+			if tt.err == nil {
+				assert.NilError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err.Error())
+			}
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	tErr := errors.New("test error")
+	account := uuid.New()
+	wallets := []domain.Wallet{
+		{ID: 1, Account: account, Currency: "eur"},
+		{ID: 2, Account: account, Currency: "usd"},
+		{ID: 3, Account: account, Currency: "usd"},
+	}
+
+	tests := map[string]struct {
+		filter domain.WalletFilter
+		err    error
+		count  int
+		next   *domain.WalletCursor
+		mocks  func(m *mocks.MockWalletRepository)
+	}{
+		"default page size": {
+			filter: domain.WalletFilter{Account: account},
+			count:  3,
+			mocks: func(m *mocks.MockWalletRepository) {
+				m.EXPECT().List(ctx, domain.WalletFilter{Account: account, Limit: service.DefaultPageSize + 1}).Return(wallets, nil)
+			},
+		},
+		"next page": {
+			filter: domain.WalletFilter{Account: account, Currency: "USD", Order: domain.OrderCurrency, Limit: 2},
+			count:  2,
+			next:   &domain.WalletCursor{Order: domain.OrderCurrency, Currency: "usd", ID: 2},
+			mocks: func(m *mocks.MockWalletRepository) {
+				m.EXPECT().List(ctx, domain.WalletFilter{Account: account, Currency: "usd", Order: domain.OrderCurrency, Limit: 3}).Return(wallets, nil)
+			},
+		},
+		"ErrInvalidCurrency": {
+			filter: domain.WalletFilter{Account: account, Currency: "dollar"},
+			err:    domain.ErrInvalidCurrency,
+			mocks:  func(m *mocks.MockWalletRepository) {},
+		},
+		"repository error": {
+			filter: domain.WalletFilter{Account: account},
+			err:    fmt.Errorf("can't list wallets of account %s: %w", account, tErr),
+			mocks: func(m *mocks.MockWalletRepository) {
+				m.EXPECT().List(ctx, gomock.Any()).Return(nil, tErr)
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			repository := mocks.NewMockWalletRepository(ctrl)
+			tt.mocks(repository)
+			wallet := service.NewWalletService(repository)
+
+			page, err := wallet.List(ctx, tt.filter)
+			if tt.err != nil {
+				assert.ErrorContains(t, err, tt.err.Error())
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.Equal(t, len(page.Wallets), tt.count)
+			assert.DeepEqual(t, page.Next, tt.next)
+		})
+	}
+}
+
+func TestListTransactions(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	tErr := errors.New("test error")
+	now := time.Now()
+	transactions := make([]domain.Transaction, 3)
+	for i := range transactions {
+		transactions[i] = domain.Transaction{
+			ID:        uuid.New(),
+			WalletID:  1,
+			Amount:    10,
+			CreatedAt: now.Add(-time.Duration(i) * time.Minute),
+		}
+	}
+
+	tests := map[string]struct {
+		filter domain.TransactionFilter
+		err    error
+		count  int
+		next   bool
+		mocks  func(m *mocks.MockWalletRepository)
+	}{
+		"default page size": {
+			filter: domain.TransactionFilter{WalletID: 1},
+			count:  3,
+			mocks: func(m *mocks.MockWalletRepository) {
+				m.EXPECT().ListTransactions(ctx, domain.TransactionFilter{WalletID: 1, Limit: service.DefaultPageSize + 1}).
+					Return(transactions, nil)
+			},
+		},
+		"max page size": {
+			filter: domain.TransactionFilter{WalletID: 1, Limit: service.MaxPageSize * 2},
+			count:  3,
+			mocks: func(m *mocks.MockWalletRepository) {
+				m.EXPECT().ListTransactions(ctx, domain.TransactionFilter{WalletID: 1, Limit: service.MaxPageSize + 1}).
+					Return(transactions, nil)
+			},
+		},
+		"next page": {
+			filter: domain.TransactionFilter{WalletID: 1, Limit: 2},
+			count:  2,
+			next:   true,
+			mocks: func(m *mocks.MockWalletRepository) {
+				m.EXPECT().ListTransactions(ctx, domain.TransactionFilter{WalletID: 1, Limit: 3}).
+					Return(transactions, nil)
+			},
+		},
+		"ErrInvalidTimeRange": {
+			filter: domain.TransactionFilter{WalletID: 1, From: now, To: now.Add(-time.Hour)},
+			err:    service.ErrInvalidTimeRange,
+			mocks:  func(m *mocks.MockWalletRepository) {},
+		},
+		"repository error": {
+			filter: domain.TransactionFilter{WalletID: 1},
+			err:    fmt.Errorf("can't list transactions of wallet %d: %w", 1, tErr),
+			mocks: func(m *mocks.MockWalletRepository) {
+				m.EXPECT().ListTransactions(ctx, gomock.Any()).Return(nil, tErr)
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			repository := mocks.NewMockWalletRepository(ctrl)
+			tt.mocks(repository)
+			wallet := service.NewWalletService(repository)
+
+			page, err := wallet.ListTransactions(ctx, tt.filter)
+			if tt.err != nil {
+				assert.ErrorContains(t, err, tt.err.Error())
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.Equal(t, len(page.Transactions), tt.count)
+			if tt.next {
+				last := transactions[tt.count-1]
+				assert.DeepEqual(t, page.Next, &domain.TransactionCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+			} else {
+				assert.Assert(t, page.Next == nil)
+			}
+		})
+	}
+}
+
+func TestHold(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	hold := domain.Hold{
+		ID:       uuid.New(),
+		WalletID: 1,
+		Amount:   100,
+		Currency: "usd",
+	}
+
+	tests := map[string]struct {
+		hold  func(h domain.Hold) domain.Hold
+		err   error
+		mocks func(m *mocks.MockWalletRepository)
+	}{
+		"ErrUnsuportedCurrency": {
+			hold: func(h domain.Hold) domain.Hold {
+				h.Currency = "gbp"
+				return h
+			},
+			err:   service.ErrUnsuportedCurrency,
+			mocks: func(m *mocks.MockWalletRepository) {},
+		},
+		"ErrInvalitTransactionAmount negative": {
+			hold: func(h domain.Hold) domain.Hold {
+				h.Amount = -1
+				return h
+			},
+			err:   service.ErrInvalitTransactionAmount,
+			mocks: func(m *mocks.MockWalletRepository) {},
+		},
+		"ErrInvalitTransactionAmount funds already held": {
+			err: service.ErrInvalitTransactionAmount,
+			mocks: func(m *mocks.MockWalletRepository) {
+				m.EXPECT().Get(ctx, hold.WalletID).Return(domain.Wallet{ID: hold.WalletID, Currency: "usd"}, nil)
+				m.EXPECT().CreateHold(ctx, gomock.Any()).Return(domain.Hold{}, domain.ErrInvalidAmount)
+			},
+		},
+		"retry replays stored hold": {
+			mocks: func(m *mocks.MockWalletRepository) {
+				m.EXPECT().Get(ctx, hold.WalletID).Return(domain.Wallet{ID: hold.WalletID, Currency: "usd"}, nil)
+				m.EXPECT().CreateHold(ctx, gomock.Any()).Return(domain.Hold{}, domain.ErrDuplicateTransaction)
+				m.EXPECT().GetHold(ctx, hold.ID).Return(hold, nil)
+			},
+		},
+		"ErrIdempotencyKeyReused hold with different amount": {
+			hold: func(h domain.Hold) domain.Hold {
+				h.Amount = 50
+				return h
+			},
+			err: service.ErrIdempotencyKeyReused,
+			mocks: func(m *mocks.MockWalletRepository) {
+				m.EXPECT().Get(ctx, hold.WalletID).Return(domain.Wallet{ID: hold.WalletID, Currency: "usd"}, nil)
+				m.EXPECT().CreateHold(ctx, gomock.Any()).Return(domain.Hold{}, domain.ErrDuplicateTransaction)
+				m.EXPECT().GetHold(ctx, hold.ID).Return(hold, nil)
+			},
+		},
+		"ErrIdempotencyKeyReused key of transaction": {
+			err: service.ErrIdempotencyKeyReused,
+			mocks: func(m *mocks.MockWalletRepository) {
+				m.EXPECT().Get(ctx, hold.WalletID).Return(domain.Wallet{ID: hold.WalletID, Currency: "usd"}, nil)
+				m.EXPECT().CreateHold(ctx, gomock.Any()).Return(domain.Hold{}, domain.ErrDuplicateTransaction)
+				m.EXPECT().GetHold(ctx, hold.ID).Return(domain.Hold{}, domain.ErrHoldNotFound)
+			},
+		},
+		"Ok": {
+			mocks: func(m *mocks.MockWalletRepository) {
+				m.EXPECT().Get(ctx, hold.WalletID).Return(domain.Wallet{ID: hold.WalletID, Currency: "usd"}, nil)
+				m.EXPECT().CreateHold(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, h domain.Hold) (domain.Hold, error) {
+					if time.Until(h.ExpiresAt) > time.Hour || time.Until(h.ExpiresAt) < time.Hour-time.Minute {
+						return domain.Hold{}, fmt.Errorf("unexpected expiration time %s", h.ExpiresAt)
+					}
+					return h, nil
+				})
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			repository := mocks.NewMockWalletRepository(ctrl)
+			tt.mocks(repository)
+			wallet := service.NewWalletService(repository, service.WithHoldTTL(time.Hour))
+
+			request := hold
+			if tt.hold != nil {
+				request = tt.hold(hold)
+			}
+			_, err := wallet.Hold(ctx, request)
+			if tt.err == nil {
+				assert.NilError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err.Error())
+			}
+		})
+	}
+}
+
+func TestCaptureHold(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	tErr := errors.New("test error")
+	hold := domain.Hold{
+		ID:        uuid.New(),
+		WalletID:  1,
+		Amount:    100,
+		Currency:  "usd",
+		Status:    domain.HoldActive,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	tests := map[string]struct {
+		amount  domain.Amount
+		err     error
+		mocks   func(m *mocks.MockWalletRepository)
+		balance domain.Amount
+	}{
+		"can't get hold": {
+			err: fmt.Errorf("can't get hold %s: %w", hold.ID, tErr),
+			mocks: func(m *mocks.MockWalletRepository) {
+				m.EXPECT().GetHold(ctx, hold.ID).Return(domain.Hold{}, tErr)
+			},
+		},
+		"ErrHoldNotActive released": {
+			err: domain.ErrHoldNotActive,
+			mocks: func(m *mocks.MockWalletRepository) {
+				h := hold
+				h.Status = domain.HoldReleased
+				m.EXPECT().GetHold(ctx, hold.ID).Return(h, nil)
+			},
+		},
+		"ErrHoldNotActive expired": {
+			err: domain.ErrHoldNotActive,
+			mocks: func(m *mocks.MockWalletRepository) {
+				h := hold
+				h.ExpiresAt = time.Now().Add(-time.Minute)
+				m.EXPECT().GetHold(ctx, hold.ID).Return(h, nil)
+			},
+		},
+		"retry replays capture": {
+			amount: 40,
+			mocks: func(m *mocks.MockWalletRepository) {
+				h := hold
+				h.Status, h.CapturedAmount = domain.HoldCaptured, 40
+				capture := h.Capture(40)
+				capture.Balance = 60
+				m.EXPECT().GetHold(ctx, hold.ID).Return(h, nil)
+				m.EXPECT().FindTransactions(ctx, hold.ID).Return([]domain.Transaction{capture}, nil)
+				m.EXPECT().Get(ctx, hold.WalletID).Return(domain.Wallet{ID: hold.WalletID, Amount: 10, Currency: "usd"}, nil)
+			},
+			balance: 60,
+		},
+		"retry replays capture that was processed concurrently": {
+			mocks: func(m *mocks.MockWalletRepository) {
+				h := hold
+				h.Status, h.CapturedAmount = domain.HoldCaptured, hold.Amount
+				capture := h.Capture(hold.Amount)
+				m.EXPECT().GetHold(ctx, hold.ID).Return(hold, nil)
+				m.EXPECT().CaptureHold(ctx, hold, hold.Amount).Return(domain.Hold{}, domain.Wallet{}, domain.ErrHoldNotActive)
+				m.EXPECT().GetHold(ctx, hold.ID).Return(h, nil)
+				m.EXPECT().FindTransactions(ctx, hold.ID).Return([]domain.Transaction{capture}, nil)
+				m.EXPECT().Get(ctx, hold.WalletID).Return(domain.Wallet{ID: hold.WalletID, Currency: "usd"}, nil)
+			},
+		},
+		"ErrIdempotencyKeyReused capture of different amount": {
+			amount: 50,
+			err:    service.ErrIdempotencyKeyReused,
+			mocks: func(m *mocks.MockWalletRepository) {
+				h := hold
+				h.Status, h.CapturedAmount = domain.HoldCaptured, 40
+				m.EXPECT().GetHold(ctx, hold.ID).Return(h, nil)
+				m.EXPECT().FindTransactions(ctx, hold.ID).Return([]domain.Transaction{h.Capture(40)}, nil)
+			},
+		},
+		"ErrInvalitTransactionAmount more than held": {
+			amount: hold.Amount + 1,
+			err:    service.ErrInvalitTransactionAmount,
+			mocks: func(m *mocks.MockWalletRepository) {
+				m.EXPECT().GetHold(ctx, hold.ID).Return(hold, nil)
+			},
+		},
+		"Ok partial": {
+			amount: 40,
+			mocks: func(m *mocks.MockWalletRepository) {
+				m.EXPECT().GetHold(ctx, hold.ID).Return(hold, nil)
+				m.EXPECT().CaptureHold(ctx, hold, domain.Amount(40)).Return(domain.Hold{}, domain.Wallet{}, nil)
+			},
+		},
+		"Ok full": {
+			mocks: func(m *mocks.MockWalletRepository) {
+				m.EXPECT().GetHold(ctx, hold.ID).Return(hold, nil)
+				m.EXPECT().CaptureHold(ctx, hold, hold.Amount).Return(domain.Hold{}, domain.Wallet{}, nil)
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			repository := mocks.NewMockWalletRepository(ctrl)
+			tt.mocks(repository)
+			wallet := service.NewWalletService(repository)
+
+			_, result, err := wallet.CaptureHold(ctx, hold.ID, tt.amount)
+			if tt.err == nil {
+				assert.NilError(t, err)
+				assert.Equal(t, result.Amount, tt.balance)
+			} else {
+				assert.ErrorContains(t, err, tt.err.Error())
+			}
+		})
+	}
+}
+
+func TestChangeStatus(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	repository := mocks.NewMockWalletRepository(ctrl)
+	wallet := service.NewWalletService(repository)
+
+	_, err := wallet.FreezeWallet(ctx, domain.StatusChange{WalletID: 1, Actor: "support", Reason: " "})
+	assert.ErrorIs(t, err, domain.ErrStatusChangeIncomplete)
+	_, err = wallet.CloseWallet(ctx, domain.StatusChange{WalletID: 1, Reason: "closed"})
+	assert.ErrorIs(t, err, domain.ErrStatusChangeIncomplete)
+
+	repository.EXPECT().ChangeStatus(ctx, domain.StatusChange{WalletID: 1, Status: domain.WalletFrozen, BlockCredits: true, Actor: "support", Reason: "compromised"}).
+		Return(domain.Wallet{ID: 1, Status: domain.WalletFrozen, BlockCredits: true}, nil)
+	_, err = wallet.FreezeWallet(ctx, domain.StatusChange{WalletID: 1, BlockCredits: true, Actor: "support", Reason: " compromised"})
+	assert.NilError(t, err)
+
+	repository.EXPECT().ChangeStatus(ctx, domain.StatusChange{WalletID: 1, Status: domain.WalletActive, Actor: "support", Reason: "resolved"}).
+		Return(domain.Wallet{ID: 1, Status: domain.WalletActive}, nil)
+	_, err = wallet.UnfreezeWallet(ctx, domain.StatusChange{WalletID: 1, BlockCredits: true, Actor: "support", Reason: "resolved"})
+	assert.NilError(t, err)
+
+	repository.EXPECT().ChangeStatus(ctx, domain.StatusChange{WalletID: 1, Status: domain.WalletClosed, Actor: "support", Reason: "retired"}).
+		Return(domain.Wallet{}, domain.ErrWalletNotEmpty)
+	_, err = wallet.CloseWallet(ctx, domain.StatusChange{WalletID: 1, Actor: "support", Reason: "retired"})
+	assert.ErrorIs(t, err, domain.ErrWalletNotEmpty)
+}
+
+func TestWatchWallet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repository := mocks.NewMockWalletRepository(ctrl)
+	feed := mocks.NewMockWalletFeed(ctrl)
+
+	unavailable := service.NewWalletService(repository)
+	err := unavailable.WatchWallet(context.Background(), 1, 0, func(domain.Wallet) error { return nil })
+	assert.ErrorIs(t, err, service.ErrWatchNotAvailable)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// change is already pending, so state is read again right after the first read
+	changes := make(chan struct{}, 1)
+	changes <- struct{}{}
+	feed.EXPECT().Subscribe(gomock.Any(), 1).Return(changes)
+	gomock.InOrder(
+		repository.EXPECT().Get(gomock.Any(), 1).Return(domain.Wallet{ID: 1, Amount: 100, Version: 2}, nil),
+		repository.EXPECT().Get(gomock.Any(), 1).Return(domain.Wallet{ID: 1, Amount: 150, Version: 3}, nil),
+	)
+
+	var sent []domain.Wallet
+	wallet := service.NewWalletService(repository, service.WithFeed(feed))
+	err = wallet.WatchWallet(ctx, 1, 2, func(w domain.Wallet) error {
+		sent = append(sent, w)
+		cancel()
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	// version 2 was already seen by client
+	assert.DeepEqual(t, sent, []domain.Wallet{{ID: 1, Amount: 150, Version: 3}})
+
+	sendErr := errors.New("stream closed")
+	feed.EXPECT().Subscribe(gomock.Any(), 1).Return(make(chan struct{}))
+	repository.EXPECT().Get(gomock.Any(), 1).Return(domain.Wallet{ID: 1}, nil)
+	err = wallet.WatchWallet(context.Background(), 1, 0, func(domain.Wallet) error { return sendErr })
+	assert.ErrorIs(t, err, sendErr)
+}
+
+func TestWalletServiceMetrics(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	wallet := domain.Wallet{ID: 1, Currency: "usd", Amount: 100}
+	other := domain.Wallet{ID: 2, Currency: "usd"}
+	credit := domain.Transaction{ID: uuid.New(), WalletID: 1, Amount: 10, Currency: "usd"}
+	transfer := domain.Transfer{ID: uuid.New(), FromWalletID: 1, ToWalletID: 2, Amount: 10, Currency: "usd"}
+	debit, transferCredit := transfer.Transactions()
+	refund := domain.Refund{ID: uuid.New(), WalletID: 1, TransactionID: credit.ID, Amount: 4}
+	reversal := domain.Transaction{ID: refund.ID, WalletID: 1, Amount: -4, Currency: "usd", ReversalOf: credit.ID}
+	hold := domain.Hold{ID: uuid.New(), WalletID: 1, Amount: 30, Currency: "usd", Status: domain.HoldActive, ExpiresAt: time.Now().Add(time.Hour)}
+	captured := hold
+	captured.Status, captured.CapturedAmount = domain.HoldCaptured, 20
+
+	tests := map[string]struct {
+		call    func(s *service.WalletService) error
+		mocks   func(m *mocks.MockWalletRepository)
+		metrics func(m *mocks.MockWalletMetrics)
+		err     error
+	}{
+		"processed": {
+			call: func(s *service.WalletService) error {
+				_, err := s.ProcessTransaction(ctx, credit)
+				return err
+			},
+			mocks: func(m *mocks.MockWalletRepository) {
+				m.EXPECT().Get(ctx, 1).Return(wallet, nil)
+				m.EXPECT().FindTransactions(ctx, credit.ID).Return(nil, nil)
+				m.EXPECT().ProcessTransaction(ctx, credit).Return(wallet, nil)
+			},
+			metrics: func(m *mocks.MockWalletMetrics) {
+				m.EXPECT().TransactionProcessed(credit)
+			},
+		},
+		"rejected": {
+			call: func(s *service.WalletService) error {
+				_, err := s.ProcessTransaction(ctx, domain.Transaction{ID: uuid.New(), WalletID: 1, Amount: 10, Currency: "xxx"})
+				return err
+			},
+			mocks: func(m *mocks.MockWalletRepository) {},
+			metrics: func(m *mocks.MockWalletMetrics) {
+				m.EXPECT().TransactionRejected("UNSUPPORTED_CURRENCY")
+			},
+			err: service.ErrUnsuportedCurrency,
+		},
+		"replayed": {
+			call: func(s *service.WalletService) error {
+				_, err := s.ProcessTransaction(ctx, credit)
+				return err
+			},
+			mocks: func(m *mocks.MockWalletRepository) {
+				m.EXPECT().Get(ctx, 1).Return(wallet, nil).Times(2)
+				m.EXPECT().FindTransactions(ctx, credit.ID).Return([]domain.Transaction{credit}, nil)
+			},
+			metrics: func(m *mocks.MockWalletMetrics) {
+				m.EXPECT().DuplicateKey()
+			},
+		},
+		"transfer": {
+			call: func(s *service.WalletService) error {
+				_, _, err := s.Transfer(ctx, transfer)
+				return err
+			},
+			mocks: func(m *mocks.MockWalletRepository) {
+				m.EXPECT().Get(ctx, 1).Return(wallet, nil)
+				m.EXPECT().Get(ctx, 2).Return(other, nil)
+				m.EXPECT().FindTransactions(ctx, transfer.ID).Return(nil, nil)
+				m.EXPECT().Transfer(ctx, transfer).Return(wallet, other, nil)
+			},
+			metrics: func(m *mocks.MockWalletMetrics) {
+				m.EXPECT().TransactionProcessed(debit)
+				m.EXPECT().TransactionProcessed(transferCredit)
+			},
+		},
+		"refund": {
+			call: func(s *service.WalletService) error {
+				_, _, err := s.Refund(ctx, refund)
+				return err
+			},
+			mocks: func(m *mocks.MockWalletRepository) {
+				m.EXPECT().FindTransactions(ctx, refund.ID).Return(nil, nil)
+				m.EXPECT().Refund(ctx, refund).Return(reversal, wallet, nil)
+			},
+			metrics: func(m *mocks.MockWalletMetrics) {
+				m.EXPECT().TransactionProcessed(reversal)
+			},
+		},
+		"refund rejected": {
+			call: func(s *service.WalletService) error {
+				_, _, err := s.Refund(ctx, refund)
+				return err
+			},
+			mocks: func(m *mocks.MockWalletRepository) {
+				m.EXPECT().FindTransactions(ctx, refund.ID).Return(nil, nil)
+				m.EXPECT().Refund(ctx, refund).Return(domain.Transaction{}, domain.Wallet{}, domain.ErrRefundExceedsOriginal)
+			},
+			metrics: func(m *mocks.MockWalletMetrics) {
+				m.EXPECT().TransactionRejected("REFUND_EXCEEDS_ORIGINAL")
+			},
+			err: domain.ErrRefundExceedsOriginal,
+		},
+		"refund with invalid amount": {
+			call: func(s *service.WalletService) error {
+				_, _, err := s.Refund(ctx, domain.Refund{ID: uuid.New(), WalletID: 1, TransactionID: credit.ID})
+				return err
+			},
+			mocks: func(m *mocks.MockWalletRepository) {},
+			metrics: func(m *mocks.MockWalletMetrics) {
+				m.EXPECT().TransactionRejected("INVALID_AMOUNT")
+			},
+			err: service.ErrInvalitTransactionAmount,
+		},
+		"capture": {
+			call: func(s *service.WalletService) error {
+				_, _, err := s.CaptureHold(ctx, hold.ID, 20)
+				return err
+			},
+			mocks: func(m *mocks.MockWalletRepository) {
+				m.EXPECT().GetHold(ctx, hold.ID).Return(hold, nil)
+				m.EXPECT().CaptureHold(ctx, hold, domain.Amount(20)).Return(captured, wallet, nil)
+			},
+			metrics: func(m *mocks.MockWalletMetrics) {
+				m.EXPECT().TransactionProcessed(domain.Transaction{ID: hold.ID, WalletID: 1, Amount: -20, Currency: "usd"})
+			},
+		},
+		"capture rejected": {
+			call: func(s *service.WalletService) error {
+				_, _, err := s.CaptureHold(ctx, hold.ID, 20)
+				return err
+			},
+			mocks: func(m *mocks.MockWalletRepository) {
+				m.EXPECT().GetHold(ctx, hold.ID).Return(hold, nil)
+				m.EXPECT().CaptureHold(ctx, hold, domain.Amount(20)).Return(domain.Hold{}, domain.Wallet{}, domain.ErrInsufficientFunds)
+			},
+			metrics: func(m *mocks.MockWalletMetrics) {
+				m.EXPECT().TransactionRejected("INSUFFICIENT_FUNDS")
+			},
+			err: domain.ErrInsufficientFunds,
+		},
+		"capture of inactive hold": {
+			call: func(s *service.WalletService) error {
+				_, _, err := s.CaptureHold(ctx, hold.ID, 20)
+				return err
+			},
+			mocks: func(m *mocks.MockWalletRepository) {
+				expired := hold
+				expired.ExpiresAt = time.Now().Add(-time.Minute)
+				m.EXPECT().GetHold(ctx, hold.ID).Return(expired, nil)
+			},
+			metrics: func(m *mocks.MockWalletMetrics) {
+				m.EXPECT().TransactionRejected("HOLD_NOT_ACTIVE")
+			},
+			err: domain.ErrHoldNotActive,
+		},
+		"batch": {
+			call: func(s *service.WalletService) error {
+				_, err := s.ProcessBatch(ctx, domain.Batch{Mode: domain.BatchBestEffort, Transactions: []domain.Transaction{credit, credit}})
+				return err
+			},
+			mocks: func(m *mocks.MockWalletRepository) {
+				m.EXPECT().Get(ctx, 1).Return(wallet, nil)
+				m.EXPECT().ProcessBatch(ctx, domain.Batch{Mode: domain.BatchBestEffort, Transactions: []domain.Transaction{credit}}).
+					Return([]domain.BatchItem{{Transaction: credit, Outcome: domain.BatchApplied}}, nil)
+			},
+			metrics: func(m *mocks.MockWalletMetrics) {
+				m.EXPECT().TransactionProcessed(credit)
+				m.EXPECT().TransactionRejected("DUPLICATE_BATCH_KEY")
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			repository := mocks.NewMockWalletRepository(ctrl)
+			tt.mocks(repository)
+			metrics := mocks.NewMockWalletMetrics(ctrl)
+			tt.metrics(metrics)
+
+			wallet := service.NewWalletService(repository, service.WithMetrics(metrics))
+			assert.ErrorIs(t, tt.call(&wallet), tt.err)
+		})
+	}
+}
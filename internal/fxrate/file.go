@@ -0,0 +1,119 @@
+package fxrate
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/ximura/gowallet/internal/core/domain"
+	"github.com/ximura/gowallet/internal/core/ports"
+	"gopkg.in/yaml.v3"
+)
+
+var _ ports.RateProvider = (*FileProvider)(nil)
+
+// FileProvider serves rates from YAML file that maps base currency to rates of quote currencies:
+//
+//	usd:
+//	  eur: 0.92
+//	  uah: 41.25
+//
+// Rate of the opposite direction is derived when it's not listed.
+type FileProvider struct {
+	path string
+
+	mu    sync.RWMutex
+	rates map[pair]domain.Rate
+}
+
+type pair struct {
+	from, to domain.Currency
+}
+
+// NewFileProvider returns provider with rates loaded from file
+func NewFileProvider(path string) (*FileProvider, error) {
+	p := &FileProvider{path: path}
+	if err := p.Load(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Load replaces rates with the current content of the file, rates are kept when file is invalid
+func (p *FileProvider) Load() error {
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		return fmt.Errorf("can't read rates file: %w", err)
+	}
+
+	rates, err := parse(data)
+	if err != nil {
+		return fmt.Errorf("can't parse rates file %s: %w", p.path, err)
+	}
+
+	p.mu.Lock()
+	p.rates = rates
+	p.mu.Unlock()
+	return nil
+}
+
+func (p *FileProvider) Rate(ctx context.Context, from, to domain.Currency) (domain.Rate, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	rate, ok := p.rates[pair{from, to}]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s/%s", domain.ErrRateNotAvailable, from, to)
+	}
+
+	return rate, nil
+}
+
+func parse(data []byte) (map[pair]domain.Rate, error) {
+	// rates are read as strings, so decimals are not rounded by float conversion
+	var file map[string]map[string]string
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	rates := make(map[pair]domain.Rate)
+	inverse := make(map[pair]domain.Rate)
+	for base, quotes := range file {
+		from, err := domain.ParseCurrency(base)
+		if err != nil {
+			return nil, err
+		}
+		for quote, value := range quotes {
+			to, err := domain.ParseCurrency(quote)
+			if err != nil {
+				return nil, err
+			}
+			if from == to {
+				return nil, fmt.Errorf("%s has rate to itself", from)
+			}
+
+			rate, err := domain.ParseRate(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s/%s: %w", from, to, err)
+			}
+			rates[pair{from, to}] = rate
+
+			inv, err := rate.Inverse()
+			if err != nil {
+				return nil, fmt.Errorf("%s/%s: %w", from, to, err)
+			}
+			inverse[pair{to, from}] = inv
+		}
+	}
+
+	// listed rates take precedence over derived ones
+	for p, rate := range inverse {
+		if _, ok := rates[p]; !ok {
+			rates[p] = rate
+		}
+	}
+
+	return rates, nil
+}
@@ -0,0 +1,97 @@
+package fxrate_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ximura/gowallet/internal/core/domain"
+	"github.com/ximura/gowallet/internal/fxrate"
+	"gotest.tools/v3/assert"
+)
+
+func writeRates(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "rates.yaml")
+	assert.NilError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestFileProvider(t *testing.T) {
+	ctx := context.Background()
+	path := writeRates(t, `
+usd:
+  eur: 0.92
+  uah: "41.25"
+eur:
+  usd: 1.085
+`)
+	provider, err := fxrate.NewFileProvider(path)
+	assert.NilError(t, err)
+
+	tests := map[string]struct {
+		from, to domain.Currency
+		rate     domain.Rate
+		err      error
+	}{
+		"listed":                {from: "usd", to: "eur", rate: 920_000_000},
+		"quoted string":         {from: "usd", to: "uah", rate: 41_250_000_000},
+		"listed over inverse":   {from: "eur", to: "usd", rate: 1_085_000_000},
+		"inverse":               {from: "uah", to: "usd", rate: 24_242_424},
+		"unknown pair":          {from: "eur", to: "uah", err: domain.ErrRateNotAvailable},
+		"same currency":         {from: "usd", to: "usd", err: domain.ErrRateNotAvailable},
+		"not canonical request": {from: "USD", to: "eur", err: domain.ErrRateNotAvailable},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			rate, err := provider.Rate(ctx, tt.from, tt.to)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, rate, tt.rate)
+		})
+	}
+}
+
+func TestFileProviderInvalid(t *testing.T) {
+	tests := map[string]string{
+		"not yaml":         "usd: [",
+		"invalid currency": "usd:\n  euro: 0.92\n",
+		"negative rate":    "usd:\n  eur: -0.92\n",
+		"too precise":      "usd:\n  eur: 0.9200000001\n",
+		"rate to itself":   "usd:\n  usd: 1\n",
+	}
+
+	for name, content := range tests {
+		content := content
+		t.Run(name, func(t *testing.T) {
+			_, err := fxrate.NewFileProvider(writeRates(t, content))
+			assert.ErrorContains(t, err, "can't parse rates file")
+		})
+	}
+}
+
+func TestFileProviderReload(t *testing.T) {
+	ctx := context.Background()
+	path := writeRates(t, "usd:\n  eur: 0.92\n")
+	provider, err := fxrate.NewFileProvider(path)
+	assert.NilError(t, err)
+
+	assert.NilError(t, os.WriteFile(path, []byte("usd:\n  eur: 0.93\n"), 0o600))
+	assert.NilError(t, provider.Load())
+	rate, err := provider.Rate(ctx, "usd", "eur")
+	assert.NilError(t, err)
+	assert.Equal(t, rate, domain.Rate(930_000_000))
+
+	// invalid file keeps previous rates
+	assert.NilError(t, os.WriteFile(path, []byte("usd: ["), 0o600))
+	assert.Assert(t, provider.Load() != nil)
+	rate, err = provider.Rate(ctx, "usd", "eur")
+	assert.NilError(t, err)
+	assert.Equal(t, rate, domain.Rate(930_000_000))
+}
@@ -0,0 +1,84 @@
+package metrics_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/ximura/gowallet/internal/core/domain"
+	"github.com/ximura/gowallet/internal/metrics"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/poll"
+)
+
+func TestWalletMetrics(t *testing.T) {
+	db, _, err := sqlmock.New()
+	assert.NilError(t, err)
+	defer db.Close()
+	registry := metrics.NewRegistry(db)
+	wallet := metrics.NewWalletMetrics(registry)
+
+	wallet.TransactionProcessed(domain.Transaction{Amount: 150, Currency: "usd"})
+	wallet.TransactionProcessed(domain.Transaction{Amount: -50, Currency: "usd"})
+	wallet.TransactionProcessed(domain.Transaction{Amount: 30, Currency: "usd"})
+	wallet.TransactionRejected("INSUFFICIENT_FUNDS")
+	wallet.DuplicateKey()
+
+	expected := `
+# HELP wallet_duplicate_keys_total Number of transaction requests with idempotency key that was already processed.
+# TYPE wallet_duplicate_keys_total counter
+wallet_duplicate_keys_total 1
+# HELP wallet_transactions_processed_total Number of transactions applied to wallets.
+# TYPE wallet_transactions_processed_total counter
+wallet_transactions_processed_total{currency="usd",direction="credit"} 2
+wallet_transactions_processed_total{currency="usd",direction="debit"} 1
+# HELP wallet_transactions_rejected_total Number of transactions rejected by domain error reason.
+# TYPE wallet_transactions_rejected_total counter
+wallet_transactions_rejected_total{reason="INSUFFICIENT_FUNDS"} 1
+# HELP wallet_transactions_volume_total Sum of absolute amounts of applied transactions in the smallest currency unit.
+# TYPE wallet_transactions_volume_total counter
+wallet_transactions_volume_total{currency="usd",direction="credit"} 180
+wallet_transactions_volume_total{currency="usd",direction="debit"} 50
+`
+	assert.NilError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"wallet_duplicate_keys_total", "wallet_transactions_processed_total", "wallet_transactions_rejected_total", "wallet_transactions_volume_total"))
+}
+
+func TestServer(t *testing.T) {
+	db, _, err := sqlmock.New()
+	assert.NilError(t, err)
+	defer db.Close()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	addr := lis.Addr().String()
+	lis.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan error)
+	server := metrics.NewServer(addr, metrics.NewRegistry(db))
+	go func() { stopped <- server.Run(ctx) }()
+
+	var resp *http.Response
+	poll.WaitOn(t, func(poll.LogT) poll.Result {
+		if resp, err = http.Get("http://" + addr + metrics.Path); err != nil {
+			return poll.Continue("scrape failed: %v", err)
+		}
+		return poll.Success()
+	}, poll.WithDelay(5*time.Millisecond))
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.NilError(t, err)
+	assert.Equal(t, resp.StatusCode, http.StatusOK)
+	// pool stats are labeled with name of the database
+	assert.Assert(t, strings.Contains(string(body), `go_sql_open_connections{db_name="wallet"}`), string(body))
+
+	cancel()
+	assert.NilError(t, <-stopped)
+}
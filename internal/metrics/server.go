@@ -0,0 +1,68 @@
+// Package metrics exposes prometheus metrics of the server over HTTP
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Path metrics are served on
+const Path = "/metrics"
+
+// NewRegistry returns registry with runtime metrics of the process and stats of database connection pool
+func NewRegistry(db *sql.DB) *prometheus.Registry {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		collectors.NewDBStatsCollector(db, "wallet"),
+	)
+	return reg
+}
+
+// Server serves metrics of gatherer to prometheus scrapes
+type Server struct {
+	server *http.Server
+}
+
+func NewServer(addr string, gatherer prometheus.Gatherer) *Server {
+	mux := http.NewServeMux()
+	mux.Handle(Path, promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{ErrorLog: log.Default()}))
+	return &Server{
+		server: &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second},
+	}
+}
+
+// Run serves metrics until context is canceled or server is closed
+func (s *Server) Run(ctx context.Context) error {
+	lc := &net.ListenConfig{}
+	lis, err := lc.Listen(ctx, "tcp", s.server.Addr)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		<-ctx.Done()
+		s.Close()
+	}()
+
+	log.Println("Serving metrics on " + s.server.Addr + Path)
+	if err := s.server.Serve(lis); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Close stops the server, scrapes don't need to finish
+func (s *Server) Close() error {
+	return s.server.Close()
+}
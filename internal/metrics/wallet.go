@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/ximura/gowallet/internal/core/domain"
+	"github.com/ximura/gowallet/internal/core/ports"
+)
+
+var _ ports.WalletMetrics = (*WalletMetrics)(nil)
+
+// WalletMetrics counts business events of wallet service
+type WalletMetrics struct {
+	processed  *prometheus.CounterVec
+	volume     *prometheus.CounterVec
+	rejected   *prometheus.CounterVec
+	duplicates prometheus.Counter
+}
+
+// NewWalletMetrics registers wallet metrics in reg
+func NewWalletMetrics(reg prometheus.Registerer) *WalletMetrics {
+	m := &WalletMetrics{
+		processed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "wallet_transactions_processed_total",
+			Help: "Number of transactions applied to wallets.",
+		}, []string{"currency", "direction"}),
+		volume: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "wallet_transactions_volume_total",
+			Help: "Sum of absolute amounts of applied transactions in the smallest currency unit.",
+		}, []string{"currency", "direction"}),
+		rejected: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "wallet_transactions_rejected_total",
+			Help: "Number of transactions rejected by domain error reason.",
+		}, []string{"reason"}),
+		duplicates: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "wallet_duplicate_keys_total",
+			Help: "Number of transaction requests with idempotency key that was already processed.",
+		}),
+	}
+	reg.MustRegister(m.processed, m.volume, m.rejected, m.duplicates)
+	return m
+}
+
+func (m *WalletMetrics) TransactionProcessed(t domain.Transaction) {
+	direction := "credit"
+	amount := float64(t.Amount)
+	if t.Amount < 0 {
+		// float64 has no overflow of MinAmount
+		direction, amount = "debit", -amount
+	}
+
+	m.processed.WithLabelValues(string(t.Currency), direction).Inc()
+	m.volume.WithLabelValues(string(t.Currency), direction).Add(amount)
+}
+
+func (m *WalletMetrics) TransactionRejected(reason string) {
+	m.rejected.WithLabelValues(reason).Inc()
+}
+
+func (m *WalletMetrics) DuplicateKey() {
+	m.duplicates.Inc()
+}
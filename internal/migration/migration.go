@@ -0,0 +1,197 @@
+// Package migration applies database schema migrations embedded into the binary
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sync"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/lib/pq"
+	"github.com/ximura/gowallet/resources"
+)
+
+var ErrSchemaBehind = errors.New("database schema is behind the binary")
+var ErrDirtySchema = errors.New("database schema is dirty, last migration failed")
+
+// undefinedTable is postgres error code of query to missing table, migrations table is created by the first run
+const undefinedTable = "42P01"
+
+// Status describes schema version of the database against migrations known to the binary
+type Status struct {
+	// version of the last applied migration, meaningless when Applied is false
+	Current uint
+	// false when no migration was applied yet
+	Applied bool
+	Dirty   bool
+	// version of the newest embedded migration
+	Latest uint
+}
+
+// Behind reports whether database misses migrations known to the binary
+func (s Status) Behind() bool {
+	return !s.Applied || s.Current < s.Latest
+}
+
+// Migrator runs embedded migrations on a single connection of the pool,
+// the pool itself is left open on Close.
+type Migrator struct {
+	m *migrate.Migrate
+}
+
+func New(ctx context.Context, db *sql.DB) (*Migrator, error) {
+	src, err := iofs.New(resources.Migrations, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("can't read migrations: %w", err)
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	driver, err := postgres.WithConnection(ctx, conn, &postgres.Config{})
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("can't prepare migrations table: %w", err)
+	}
+
+	m, err := migrate.NewWithInstance("iofs", src, "postgres", driver)
+	if err != nil {
+		driver.Close()
+		return nil, err
+	}
+
+	return &Migrator{m: m}, nil
+}
+
+func (m *Migrator) Close() error {
+	srcErr, dbErr := m.m.Close()
+	return errors.Join(srcErr, dbErr)
+}
+
+// Up applies all migrations that were not applied yet,
+// postgres advisory lock makes concurrent runs from several replicas wait for each other.
+func (m *Migrator) Up() error {
+	if err := m.m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	return nil
+}
+
+// Down rolls back given number of the last applied migrations
+func (m *Migrator) Down(steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("steps should be positive, got %d", steps)
+	}
+	if err := m.m.Steps(-steps); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	return nil
+}
+
+func (m *Migrator) Status() (Status, error) {
+	latest, err := LatestVersion()
+	if err != nil {
+		return Status{}, err
+	}
+
+	result := Status{Latest: latest}
+	version, dirty, err := m.m.Version()
+	switch {
+	case errors.Is(err, migrate.ErrNilVersion):
+		return result, nil
+	case err != nil:
+		return Status{}, err
+	}
+
+	result.Current = version
+	result.Applied = true
+	result.Dirty = dirty
+	return result, nil
+}
+
+// CheckCurrent returns error when server can't work with the database schema
+func (m *Migrator) CheckCurrent() error {
+	s, err := m.Status()
+	if err != nil {
+		return fmt.Errorf("can't get schema version: %w", err)
+	}
+	return s.Check()
+}
+
+// Check returns error when server can't work with the database schema. Unlike Migrator it reads
+// version with a single query of the pool, so it's cheap enough for periodic health checks.
+func Check(ctx context.Context, db *sql.DB) error {
+	latest, err := latestVersion()
+	if err != nil {
+		return err
+	}
+
+	s := Status{Latest: latest}
+	var version int64
+	query := `SELECT version, dirty FROM ` + postgres.DefaultMigrationsTable + ` LIMIT 1`
+	err = db.QueryRowContext(ctx, query).Scan(&version, &s.Dirty)
+	var pqErr *pq.Error
+	switch {
+	case errors.Is(err, sql.ErrNoRows), errors.As(err, &pqErr) && pqErr.Code == undefinedTable:
+		// no migration was applied yet
+	case err != nil:
+		return fmt.Errorf("can't get schema version: %w", err)
+	default:
+		s.Current = uint(version)
+		s.Applied = true
+	}
+
+	return s.Check()
+}
+
+// Check returns error when server can't work with the database schema of status
+func (s Status) Check() error {
+	if s.Dirty {
+		return fmt.Errorf("%w: version %d", ErrDirtySchema, s.Current)
+	}
+	if !s.Applied {
+		return fmt.Errorf("%w: no migrations applied, latest is %d", ErrSchemaBehind, s.Latest)
+	}
+	if s.Behind() {
+		return fmt.Errorf("%w: version %d, latest is %d", ErrSchemaBehind, s.Current, s.Latest)
+	}
+	return nil
+}
+
+// latestVersion is LatestVersion read once, embedded migrations don't change
+var latestVersion = sync.OnceValues(LatestVersion)
+
+// LatestVersion returns version of the newest embedded migration
+func LatestVersion() (uint, error) {
+	src, err := iofs.New(resources.Migrations, "migrations")
+	if err != nil {
+		return 0, fmt.Errorf("can't read migrations: %w", err)
+	}
+	defer src.Close()
+
+	return latest(src)
+}
+
+func latest(src source.Driver) (uint, error) {
+	version, err := src.First()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		next, err := src.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		version = next
+	}
+}
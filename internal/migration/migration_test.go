@@ -0,0 +1,111 @@
+package migration_test
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/ximura/gowallet/internal/migration"
+	"github.com/ximura/gowallet/resources"
+	"gotest.tools/v3/assert"
+)
+
+func TestLatestVersion(t *testing.T) {
+	up, err := fs.Glob(resources.Migrations, "migrations/*.up.sql")
+	assert.NilError(t, err)
+	assert.Assert(t, len(up) > 0)
+
+	latest, err := migration.LatestVersion()
+	assert.NilError(t, err)
+	// versions start from zero and have no gaps
+	assert.Equal(t, latest, uint(len(up)-1))
+}
+
+func TestMigrationsReversible(t *testing.T) {
+	up, err := fs.Glob(resources.Migrations, "migrations/*.up.sql")
+	assert.NilError(t, err)
+
+	for _, name := range up {
+		down := strings.TrimSuffix(name, ".up.sql") + ".down.sql"
+		_, err := fs.Stat(resources.Migrations, down)
+		assert.NilError(t, err, "%s has no down migration", name)
+	}
+}
+
+func TestStatusBehind(t *testing.T) {
+	tests := map[string]struct {
+		status migration.Status
+		behind bool
+	}{
+		"empty database": {
+			status: migration.Status{Latest: 6},
+			behind: true,
+		},
+		"behind": {
+			status: migration.Status{Current: 5, Applied: true, Latest: 6},
+			behind: true,
+		},
+		"current": {
+			status: migration.Status{Current: 6, Applied: true, Latest: 6},
+		},
+		"ahead": {
+			status: migration.Status{Current: 7, Applied: true, Latest: 6},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.status.Behind(), tt.behind)
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	latest, err := migration.LatestVersion()
+	assert.NilError(t, err)
+	columns := []string{"version", "dirty"}
+	refused := errors.New("connection refused")
+
+	tests := map[string]struct {
+		mocks func(m *sqlmock.ExpectedQuery)
+		err   error
+	}{
+		"current": {
+			mocks: func(m *sqlmock.ExpectedQuery) { m.WillReturnRows(sqlmock.NewRows(columns).AddRow(latest, false)) },
+		},
+		"behind": {
+			mocks: func(m *sqlmock.ExpectedQuery) { m.WillReturnRows(sqlmock.NewRows(columns).AddRow(latest-1, false)) },
+			err:   migration.ErrSchemaBehind,
+		},
+		"dirty": {
+			mocks: func(m *sqlmock.ExpectedQuery) { m.WillReturnRows(sqlmock.NewRows(columns).AddRow(latest, true)) },
+			err:   migration.ErrDirtySchema,
+		},
+		"no migrations table": {
+			mocks: func(m *sqlmock.ExpectedQuery) { m.WillReturnError(&pq.Error{Code: "42P01"}) },
+			err:   migration.ErrSchemaBehind,
+		},
+		"database error": {
+			mocks: func(m *sqlmock.ExpectedQuery) { m.WillReturnError(refused) },
+			err:   refused,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NilError(t, err)
+			defer db.Close()
+			tt.mocks(mock.ExpectQuery(`SELECT version, dirty FROM schema_migrations`))
+
+			assert.ErrorIs(t, migration.Check(context.Background(), db), tt.err)
+			assert.NilError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
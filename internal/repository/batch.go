@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	pg "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/google/uuid"
+	"github.com/ximura/gowallet/internal/core/domain"
+	"github.com/ximura/gowallet/internal/repository/jet/model"
+)
+
+// errBatchFailed rolls back atomic batch with failed transactions, results of the batch are still returned
+var errBatchFailed = errors.New("batch has failed transactions")
+
+// ProcessBatch applies transactions of batch in a single database transaction. Wallets of the batch are locked
+// and used idempotency keys are looked up once for the whole batch. Failed write of best effort batch
+// is rolled back to savepoint, so it doesn't affect other transactions of the batch.
+// Credit of multi-currency wallet in currency it has no pocket for creates the pocket.
+func (r *WalletRepo) ProcessBatch(ctx context.Context, batch domain.Batch) ([]domain.BatchItem, error) {
+	var items []domain.BatchItem
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		items, err = r.processBatch(ctx, tx, batch)
+		return err
+	})
+	if err != nil && !errors.Is(err, errBatchFailed) {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (r *WalletRepo) processBatch(ctx context.Context, tx *sql.Tx, batch domain.Batch) ([]domain.BatchItem, error) {
+	wallets, err := r.lockWallets(ctx, tx, batch.WalletIDs())
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := r.transactionsByKey(ctx, tx, batch.Keys())
+	if err != nil {
+		return nil, err
+	}
+
+	atomic := batch.Mode == domain.BatchAtomic
+	items := make([]domain.BatchItem, len(batch.Transactions))
+	failed := false
+	for i, t := range batch.Transactions {
+		if failed && atomic {
+			items[i] = domain.BatchItem{Transaction: t, Outcome: domain.BatchAborted}
+			continue
+		}
+
+		item, err := r.applyBatchTransaction(ctx, tx, !atomic, wallets, stored, t)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = item
+		failed = failed || item.Outcome == domain.BatchFailed
+	}
+
+	if failed && atomic {
+		// transactions applied before the failed one are rolled back with it
+		for i := range items {
+			if items[i].Outcome == domain.BatchApplied {
+				items[i] = domain.BatchItem{Transaction: batch.Transactions[i], Outcome: domain.BatchAborted}
+			}
+		}
+		return items, errBatchFailed
+	}
+
+	return items, nil
+}
+
+// applyBatchTransaction applies transaction to wallet locked by the batch and updates the locked state.
+// Failure of the transaction is reported in its item, returned error fails the whole batch.
+func (r *WalletRepo) applyBatchTransaction(ctx context.Context, tx *sql.Tx, savepoint bool, wallets map[int]domain.Wallet,
+	stored map[uuid.UUID][]domain.Transaction, t domain.Transaction) (domain.BatchItem, error) {
+	wallet, ok := wallets[t.WalletID]
+	if !ok {
+		return domain.FailedItem(t, fmt.Errorf("can't get wallet %d: %w", t.WalletID, domain.ErrWalletNotFound)), nil
+	}
+
+	if s, ok := stored[t.ID]; ok {
+		if len(s) != 1 || !t.Matches(s[0]) {
+			return domain.FailedItem(t, domain.ErrIdempotencyKeyReused), nil
+		}
+		// holds active when transaction was applied are not recorded
+		wallet.Amount, wallet.Held = s[0].Balance, 0
+		return domain.BatchItem{Transaction: s[0], Wallet: wallet, Outcome: domain.BatchReplayed}, nil
+	}
+
+	if wallet.MultiCurrency && wallet.Currency != t.Currency && t.Amount > 0 {
+		// pocket is created in batch transaction, so it's rolled back with atomic batch that failed
+		pocket, err := r.createPocket(ctx, tx, wallet, t.Currency)
+		if err != nil {
+			var domainErr *domain.Error
+			if !errors.As(err, &domainErr) {
+				return domain.BatchItem{}, fmt.Errorf("can't create %s pocket of wallet %d: %w", t.Currency, wallet.ID, err)
+			}
+			return domain.FailedItem(t, err), nil
+		}
+		wallet, t.WalletID = pocket, pocket.ID
+	}
+
+	if err := wallet.CanApply(t); err != nil {
+		return domain.FailedItem(t, err), nil
+	}
+
+	if savepoint {
+		if _, err := tx.ExecContext(ctx, "SAVEPOINT batch_item"); err != nil {
+			return domain.BatchItem{}, err
+		}
+	}
+
+	// key is free when batch started, but it could be claimed by concurrent transaction of other wallet
+	err := r.claimKey(ctx, tx, t.ID)
+	var applied domain.Transaction
+	var w domain.Wallet
+	if err == nil {
+		applied, w, err = r.recordTransaction(ctx, tx, t)
+	}
+	if err != nil {
+		var domainErr *domain.Error
+		if !errors.As(err, &domainErr) {
+			return domain.BatchItem{}, err
+		}
+		if savepoint {
+			if _, err := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT batch_item"); err != nil {
+				return domain.BatchItem{}, err
+			}
+		}
+		return domain.FailedItem(t, err), nil
+	}
+
+	if savepoint {
+		if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT batch_item"); err != nil {
+			return domain.BatchItem{}, err
+		}
+	}
+
+	wallets[w.ID] = w
+	return domain.BatchItem{Transaction: applied, Wallet: w, Outcome: domain.BatchApplied}, nil
+}
+
+// lockWallets reads wallets and locks them until the end of transaction, wallets are locked in id order
+func (r *WalletRepo) lockWallets(ctx context.Context, db qrm.Queryable, ids []int) (map[int]domain.Wallet, error) {
+	values := make([]pg.Expression, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, pg.Int(int64(id)))
+	}
+
+	query := r.wallet.SELECT(r.walletProjection()).
+		WHERE(r.wallet.ID.IN(values...)).
+		ORDER_BY(r.wallet.ID.ASC()).
+		FOR(pg.UPDATE().OF(r.wallet))
+
+	var rows []domain.Wallet
+	if err := query.QueryContext(ctx, db, &rows); err != nil {
+		return nil, fmt.Errorf("can't get wallets: %w", err)
+	}
+
+	result := make(map[int]domain.Wallet, len(rows))
+	for _, w := range rows {
+		result[w.ID] = w
+	}
+
+	return result, nil
+}
+
+// transactionsByKey returns transactions recorded with idempotency keys
+func (r *WalletRepo) transactionsByKey(ctx context.Context, db qrm.Queryable, keys []uuid.UUID) (map[uuid.UUID][]domain.Transaction, error) {
+	values := make([]pg.Expression, 0, len(keys))
+	for _, key := range keys {
+		values = append(values, pg.UUID(key))
+	}
+
+	query := r.transaction.SELECT(r.transactionProjection()).
+		WHERE(r.transaction.TransactionID.IN(values...))
+
+	var rows []model.Transaction
+	if err := query.QueryContext(ctx, db, &rows); err != nil {
+		return nil, err
+	}
+
+	result := make(map[uuid.UUID][]domain.Transaction)
+	for _, row := range rows {
+		t := convertTransaction(row)
+		result[t.ID] = append(result[t.ID], t)
+	}
+
+	return result, nil
+}
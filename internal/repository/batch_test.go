@@ -0,0 +1,217 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/ximura/gowallet/internal/core/domain"
+	"github.com/ximura/gowallet/internal/repository"
+	"gotest.tools/v3/assert"
+)
+
+var lockWalletsQuery = `SELECT ` + walletColumns(1) + ` FROM public.wallet WHERE wallet.id IN \(\$4, \$5\) ORDER BY wallet.id ASC FOR UPDATE OF wallet;`
+
+var transactionsByKeyQuery = selectTransactionQuery + `WHERE transaction.transaction_id IN \(\$1, \$2, \$3\);`
+
+// expectRecordCredit expects key of credit to be claimed, credit to be applied to locked wallet and recorded
+// with its journal entry, id of system account is looked up by the first journal entry, repository caches it for later ones
+func expectRecordCredit(mock sqlmock.Sqlmock, t domain.Transaction, balance domain.Amount, entryID int64) {
+	expectClaimKey(mock, t.ID, false)
+	mock.ExpectQuery(updateWalletQuery).WithArgs(t.Amount, t.WalletID, t.Currency, 0, 0, domain.HoldActive).
+		WillReturnRows(sqlmock.NewRows([]string{"wallet.id", "wallet.amount", "wallet.currency"}).AddRow(t.WalletID, balance, t.Currency))
+	mock.ExpectExec(insertTransactionQuery).
+		WithArgs(t.WalletID, t.ID, t.Amount, t.Currency, balance, nil, nil, nil, nil, nil, nil, nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectJournalEntry(mock, t.ID, domain.EntryTransaction, entryID)
+	expectWalletPosting(mock, t.WalletID, t.Amount, 1, int64(balance))
+	if entryID == 1 {
+		expectSystemAccount(mock, domain.ExternalFunding, t.Currency, 2)
+	}
+	mock.ExpectExec(postingQuery).WithArgs(entryID, 1, t.Amount, entryID, 2, -t.Amount).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+}
+
+func TestProcessBatch(t *testing.T) {
+	ctx := context.Background()
+	first := domain.Transaction{ID: uuid.New(), WalletID: 1, Amount: 10, Currency: "usd"}
+	second := domain.Transaction{ID: uuid.New(), WalletID: 2, Amount: -500, Currency: "usd"}
+	third := domain.Transaction{ID: uuid.New(), WalletID: 1, Amount: 20, Currency: "usd"}
+	transactions := []domain.Transaction{first, second, third}
+
+	// wallets of the batch are locked by one query, 1 has 100 and 2 has 50
+	expectLockWallets := func(mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(lockWalletsQuery).WithArgs(0, 0, domain.HoldActive, 1, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"wallet.id", "wallet.amount", "wallet.currency"}).
+				AddRow(1, 100, "usd").
+				AddRow(2, 50, "usd"))
+	}
+	expectKeys := func(mock sqlmock.Sqlmock, rows *sqlmock.Rows) {
+		mock.ExpectQuery(transactionsByKeyQuery).WithArgs(first.ID, second.ID, third.ID).WillReturnRows(rows)
+	}
+
+	tests := map[string]struct {
+		mode     domain.BatchMode
+		outcomes []domain.BatchOutcome
+		balances []domain.Amount
+		errs     []error
+		mocks    func(mock sqlmock.Sqlmock)
+	}{
+		"atomic": {
+			mode:     domain.BatchAtomic,
+			outcomes: []domain.BatchOutcome{domain.BatchAborted, domain.BatchFailed, domain.BatchAborted},
+			balances: []domain.Amount{0, 0, 0},
+			errs:     []error{nil, domain.ErrInsufficientFunds, nil},
+			mocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectLockWallets(mock)
+				expectKeys(mock, sqlmock.NewRows(transactionColumns))
+				expectRecordCredit(mock, first, 110, 1)
+				mock.ExpectRollback()
+			},
+		},
+		"best effort": {
+			mode:     domain.BatchBestEffort,
+			outcomes: []domain.BatchOutcome{domain.BatchApplied, domain.BatchFailed, domain.BatchApplied},
+			balances: []domain.Amount{110, 0, 130},
+			errs:     []error{nil, domain.ErrInsufficientFunds, nil},
+			mocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectLockWallets(mock)
+				expectKeys(mock, sqlmock.NewRows(transactionColumns))
+				mock.ExpectExec("SAVEPOINT batch_item").WillReturnResult(sqlmock.NewResult(0, 0))
+				expectRecordCredit(mock, first, 110, 1)
+				mock.ExpectExec("RELEASE SAVEPOINT batch_item").WillReturnResult(sqlmock.NewResult(0, 0))
+				// third transaction sees balance updated by the first one
+				mock.ExpectExec("SAVEPOINT batch_item").WillReturnResult(sqlmock.NewResult(0, 0))
+				expectRecordCredit(mock, third, 130, 2)
+				mock.ExpectExec("RELEASE SAVEPOINT batch_item").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+		},
+		"concurrent duplicate": {
+			mode:     domain.BatchBestEffort,
+			outcomes: []domain.BatchOutcome{domain.BatchFailed, domain.BatchFailed, domain.BatchApplied},
+			balances: []domain.Amount{0, 0, 120},
+			errs:     []error{domain.ErrDuplicateTransaction, domain.ErrInsufficientFunds, nil},
+			mocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectLockWallets(mock)
+				expectKeys(mock, sqlmock.NewRows(transactionColumns))
+				mock.ExpectExec("SAVEPOINT batch_item").WillReturnResult(sqlmock.NewResult(0, 0))
+				// key was claimed by transaction of other wallet after the batch looked it up
+				expectClaimKey(mock, first.ID, true)
+				mock.ExpectExec("ROLLBACK TO SAVEPOINT batch_item").WillReturnResult(sqlmock.NewResult(0, 0))
+				// balance of rolled back transaction is not seen by the next one
+				mock.ExpectExec("SAVEPOINT batch_item").WillReturnResult(sqlmock.NewResult(0, 0))
+				expectRecordCredit(mock, third, 120, 1)
+				mock.ExpectExec("RELEASE SAVEPOINT batch_item").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+		},
+		"replay": {
+			mode:     domain.BatchAtomic,
+			outcomes: []domain.BatchOutcome{domain.BatchReplayed, domain.BatchFailed, domain.BatchAborted},
+			balances: []domain.Amount{90, 0, 0},
+			errs:     []error{nil, domain.ErrIdempotencyKeyReused, nil},
+			mocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectLockWallets(mock)
+				expectKeys(mock, sqlmock.NewRows(transactionColumns).
+					AddRow(first.WalletID, first.ID, first.Amount, first.Currency, 90, nil, nil, nil).
+					AddRow(second.WalletID, second.ID, -50, second.Currency, 0, nil, nil, nil))
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			db, mock := newMock()
+			repo := repository.NewWalletRepo(db)
+			defer func() {
+				repo.Close()
+			}()
+			mock.MatchExpectationsInOrder(true)
+			tt.mocks(mock)
+
+			items, err := repo.ProcessBatch(ctx, domain.Batch{Mode: tt.mode, Transactions: transactions})
+			assert.NilError(t, err)
+			assert.Equal(t, len(items), len(transactions))
+			for i, item := range items {
+				assert.Equal(t, item.Outcome, tt.outcomes[i], "transaction %d", i)
+				assert.Equal(t, item.Wallet.Amount, tt.balances[i], "transaction %d", i)
+				if tt.errs[i] != nil {
+					assert.ErrorIs(t, item.Err, tt.errs[i])
+				}
+			}
+			assert.NilError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestProcessBatchWalletNotFound(t *testing.T) {
+	db, mock := newMock()
+	repo := repository.NewWalletRepo(db)
+	defer func() {
+		repo.Close()
+	}()
+	transaction := domain.Transaction{ID: uuid.New(), WalletID: 3, Amount: 10, Currency: "usd"}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT `+walletColumns(1)+` FROM public.wallet WHERE wallet.id IN \(\$4\) ORDER BY wallet.id ASC FOR UPDATE OF wallet;`).
+		WithArgs(0, 0, domain.HoldActive, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"wallet.id", "wallet.amount", "wallet.currency"}))
+	mock.ExpectQuery(selectTransactionQuery + `WHERE transaction.transaction_id IN \(\$1\);`).WithArgs(transaction.ID).
+		WillReturnRows(sqlmock.NewRows(transactionColumns))
+	mock.ExpectCommit()
+
+	items, err := repo.ProcessBatch(context.Background(), domain.Batch{Mode: domain.BatchBestEffort, Transactions: []domain.Transaction{transaction}})
+	assert.NilError(t, err)
+	assert.Equal(t, items[0].Outcome, domain.BatchFailed)
+	assert.ErrorIs(t, items[0].Err, domain.ErrWalletNotFound)
+	assert.NilError(t, mock.ExpectationsWereMet())
+}
+
+func TestProcessBatchPocket(t *testing.T) {
+	db, mock := newMock()
+	repo := repository.NewWalletRepo(db)
+	defer func() {
+		repo.Close()
+	}()
+	account := uuid.New()
+	credit := domain.Transaction{ID: uuid.New(), WalletID: 1, Amount: 10, Currency: "eur"}
+	debit := domain.Transaction{ID: uuid.New(), WalletID: 1, Amount: -500, Currency: "usd"}
+	columns := []string{"wallet.id", "wallet.account", "wallet.amount", "wallet.currency", "wallet.parent_id", "wallet.multi_currency",
+		"wallet.status", "wallet.block_credits", "wallet.held"}
+
+	// pocket for credit is created in batch transaction and rolled back with the failed batch
+	mock.MatchExpectationsInOrder(true)
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT `+walletColumns(1)+` FROM public.wallet WHERE wallet.id IN \(\$4\) ORDER BY wallet.id ASC FOR UPDATE OF wallet;`).
+		WithArgs(0, 0, domain.HoldActive, 1).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(1, account, 100, "usd", nil, true, domain.WalletActive, false, 0))
+	mock.ExpectQuery(selectTransactionQuery+`WHERE transaction.transaction_id IN \(\$1, \$2\);`).WithArgs(credit.ID, debit.ID).
+		WillReturnRows(sqlmock.NewRows(transactionColumns))
+	mock.ExpectQuery(`SELECT `+walletColumns(1)+` FROM public.wallet WHERE \(wallet.parent_id = \$4\) AND \(wallet.currency = \$5::text\);`).
+		WithArgs(0, 0, domain.HoldActive, 1, "eur").
+		WillReturnRows(sqlmock.NewRows(columns))
+	mock.ExpectQuery(`INSERT INTO public.wallet \(account, currency, multi_currency, parent_id, status, block_credits\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6\) RETURNING `).
+		WithArgs(account, "eur", false, 1, domain.WalletActive, false).
+		WillReturnRows(sqlmock.NewRows(columns[:8]).AddRow(3, account, 0, "eur", 1, false, domain.WalletActive, false))
+	mock.ExpectExec(`INSERT INTO public.ledger_account \(wallet_id, currency\) VALUES \(\$1, \$2\);`).WithArgs(3, "eur").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	pocketCredit := credit
+	pocketCredit.WalletID = 3
+	expectRecordCredit(mock, pocketCredit, 10, 1)
+	mock.ExpectRollback()
+
+	items, err := repo.ProcessBatch(context.Background(), domain.Batch{Mode: domain.BatchAtomic, Transactions: []domain.Transaction{credit, debit}})
+	assert.NilError(t, err)
+	assert.Equal(t, items[0].Outcome, domain.BatchAborted)
+	assert.Equal(t, items[1].Outcome, domain.BatchFailed)
+	assert.ErrorIs(t, items[1].Err, domain.ErrInsufficientFunds)
+	assert.NilError(t, mock.ExpectationsWereMet())
+}
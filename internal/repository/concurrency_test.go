@@ -0,0 +1,196 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ximura/gowallet/internal/core/domain"
+	"github.com/ximura/gowallet/internal/repository"
+	"gotest.tools/v3/assert"
+)
+
+// openTestDB opens database with applied migrations, tests that need it are skipped unless it's set, e.g.
+// WALLET_TEST_DSN="host=localhost port=5432 user=postgres password=postgres dbname=wallet sslmode=disable"
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	dsn := os.Getenv("WALLET_TEST_DSN")
+	if dsn == "" {
+		t.Skip("WALLET_TEST_DSN is not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	assert.NilError(t, err)
+	return db
+}
+
+// TestConcurrentTransactions hammers a single wallet with concurrent credits and debits
+// and checks that every applied transaction is reflected in the balance, history and ledger.
+func TestConcurrentTransactions(t *testing.T) {
+	repo := repository.NewWalletRepo(openTestDB(t))
+	defer func() {
+		repo.Close()
+	}()
+
+	ctx := context.Background()
+	account := uuid.New()
+	const opening = 1000
+	wallet, err := repo.Create(ctx, account, "usd")
+	assert.NilError(t, err)
+	_, err = repo.ProcessTransaction(ctx, domain.Transaction{ID: uuid.New(), WalletID: wallet.ID, Amount: opening, Currency: "usd"})
+	assert.NilError(t, err)
+
+	const workers = 16
+	const perWorker = 50
+	var applied, rejected, aborted atomic.Int64
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*perWorker)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < perWorker; j++ {
+				// debits outweigh credits, so the wallet runs out of funds during the test
+				amount := domain.Amount(7)
+				if (i+j)%2 == 0 {
+					amount = -13
+				}
+
+				_, err := repo.ProcessTransaction(ctx, domain.Transaction{ID: uuid.New(), WalletID: wallet.ID, Amount: amount, Currency: "usd"})
+				switch {
+				case err == nil:
+					applied.Add(int64(amount))
+				case errors.Is(err, domain.ErrInsufficientFunds):
+					rejected.Add(1)
+				case errors.Is(err, domain.ErrConcurrentUpdate):
+					// retries were exhausted, transaction was not applied
+					aborted.Add(1)
+				default:
+					errs <- err
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+	t.Logf("applied %d, rejected %d, aborted %d", applied.Load(), rejected.Load(), aborted.Load())
+
+	result, err := repo.Get(ctx, wallet.ID)
+	assert.NilError(t, err)
+	assert.Equal(t, result.Amount, opening+domain.Amount(applied.Load()))
+	assert.Assert(t, result.Amount >= 0)
+	assert.Assert(t, rejected.Load() > 0)
+
+	reports, err := repo.RecomputeBalances(ctx, domain.ReconcileFilter{Account: account, Limit: 10})
+	assert.NilError(t, err)
+	assert.Equal(t, len(reports), 1)
+	assert.Assert(t, !reports[0].HasDrift(), "%+v", reports[0])
+}
+
+// TestIdempotencyKeyAcrossWallets uses the same key for transactions of different wallets concurrently,
+// only one of them is applied.
+func TestIdempotencyKeyAcrossWallets(t *testing.T) {
+	repo := repository.NewWalletRepo(openTestDB(t))
+	defer func() {
+		repo.Close()
+	}()
+
+	ctx := context.Background()
+	const wallets = 8
+	key := uuid.New()
+	var applied, duplicates atomic.Int64
+	var wg sync.WaitGroup
+	errs := make(chan error, wallets)
+	for i := 0; i < wallets; i++ {
+		wallet, err := repo.Create(ctx, uuid.New(), "usd")
+		assert.NilError(t, err)
+
+		wg.Add(1)
+		go func(walletID int) {
+			defer wg.Done()
+			_, err := repo.ProcessTransaction(ctx, domain.Transaction{ID: key, WalletID: walletID, Amount: 10, Currency: "usd"})
+			switch {
+			case err == nil:
+				applied.Add(1)
+			case errors.Is(err, domain.ErrDuplicateTransaction):
+				duplicates.Add(1)
+			default:
+				errs <- err
+			}
+		}(wallet.ID)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+	assert.Equal(t, applied.Load(), int64(1))
+	assert.Equal(t, duplicates.Load(), int64(wallets-1))
+}
+
+// TestHoldIdempotencyKey checks that transaction can't take key of hold, so the hold can still be captured
+func TestHoldIdempotencyKey(t *testing.T) {
+	repo := repository.NewWalletRepo(openTestDB(t))
+	defer func() {
+		repo.Close()
+	}()
+
+	ctx := context.Background()
+	wallet, err := repo.Create(ctx, uuid.New(), "usd")
+	assert.NilError(t, err)
+	_, err = repo.ProcessTransaction(ctx, domain.Transaction{ID: uuid.New(), WalletID: wallet.ID, Amount: 100, Currency: "usd"})
+	assert.NilError(t, err)
+
+	hold := domain.Hold{ID: uuid.New(), WalletID: wallet.ID, Amount: 30, Currency: "usd", ExpiresAt: time.Now().Add(time.Hour)}
+	created, err := repo.CreateHold(ctx, hold)
+	assert.NilError(t, err)
+
+	_, err = repo.CreateHold(ctx, hold)
+	assert.ErrorIs(t, err, domain.ErrDuplicateTransaction)
+	_, err = repo.ProcessTransaction(ctx, domain.Transaction{ID: hold.ID, WalletID: wallet.ID, Amount: 10, Currency: "usd"})
+	assert.ErrorIs(t, err, domain.ErrDuplicateTransaction)
+
+	captured, result, err := repo.CaptureHold(ctx, created, 30)
+	assert.NilError(t, err)
+	assert.Equal(t, captured.Status, domain.HoldCaptured)
+	assert.Equal(t, result.Amount, domain.Amount(70))
+}
+
+// TestHoldWalletVersion checks that hold changes held funds of wallet with a new version, so watchers see them
+func TestHoldWalletVersion(t *testing.T) {
+	repo := repository.NewWalletRepo(openTestDB(t))
+	defer func() {
+		repo.Close()
+	}()
+
+	ctx := context.Background()
+	wallet, err := repo.Create(ctx, uuid.New(), "usd")
+	assert.NilError(t, err)
+	funded, err := repo.ProcessTransaction(ctx, domain.Transaction{ID: uuid.New(), WalletID: wallet.ID, Amount: 100, Currency: "usd"})
+	assert.NilError(t, err)
+
+	hold, err := repo.CreateHold(ctx, domain.Hold{ID: uuid.New(), WalletID: wallet.ID, Amount: 30, Currency: "usd", ExpiresAt: time.Now().Add(time.Hour)})
+	assert.NilError(t, err)
+	held, err := repo.Get(ctx, wallet.ID)
+	assert.NilError(t, err)
+	assert.Equal(t, held.Held, domain.Amount(30))
+	assert.Assert(t, held.Version > funded.Version)
+
+	_, err = repo.ReleaseHold(ctx, hold.ID)
+	assert.NilError(t, err)
+	released, err := repo.Get(ctx, wallet.ID)
+	assert.NilError(t, err)
+	assert.Equal(t, released.Held, domain.Amount(0))
+	assert.Assert(t, released.Version > held.Version)
+}
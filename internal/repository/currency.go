@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+
+	pg "github.com/go-jet/jet/v2/postgres"
+	"github.com/ximura/gowallet/internal/core/domain"
+	"github.com/ximura/gowallet/internal/core/ports"
+	"github.com/ximura/gowallet/internal/repository/jet/model"
+)
+
+var _ ports.CurrencyRepository = (*WalletRepo)(nil)
+
+func (r *WalletRepo) ListCurrencies(ctx context.Context) ([]domain.CurrencyInfo, error) {
+	query := r.currency.SELECT(r.currencyProjection()).
+		ORDER_BY(r.currency.Code.ASC())
+
+	var rows []model.Currency
+	if err := query.QueryContext(ctx, r.db, &rows); err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.CurrencyInfo, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, convertCurrency(row))
+	}
+
+	return result, nil
+}
+
+func (r *WalletRepo) SetCurrencyEnabled(ctx context.Context, code domain.Currency, enabled bool) (domain.CurrencyInfo, error) {
+	query := r.currency.UPDATE(r.currency.Enabled).
+		SET(pg.Bool(enabled)).
+		WHERE(r.currency.Code.EQ(pg.String(string(code)))).
+		RETURNING(r.currencyProjection())
+
+	var result model.Currency
+	if err := query.QueryContext(ctx, r.db, &result); err != nil {
+		return domain.CurrencyInfo{}, notFound(err, domain.ErrCurrencyNotFound)
+	}
+
+	return convertCurrency(result), nil
+}
+
+func (r *WalletRepo) currencyProjection() pg.ProjectionList {
+	return pg.ProjectionList{
+		r.currency.Code,
+		r.currency.NumericCode,
+		r.currency.Exponent,
+		r.currency.Enabled,
+	}
+}
+
+func convertCurrency(c model.Currency) domain.CurrencyInfo {
+	return domain.CurrencyInfo{
+		Code:        domain.Currency(c.Code),
+		NumericCode: int(c.NumericCode),
+		Exponent:    int(c.Exponent),
+		Enabled:     c.Enabled,
+	}
+}
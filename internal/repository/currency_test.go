@@ -0,0 +1,81 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ximura/gowallet/internal/core/domain"
+	"github.com/ximura/gowallet/internal/repository"
+	"gotest.tools/v3/assert"
+)
+
+var currencyColumns = []string{"currency.code", "currency.numeric_code", "currency.exponent", "currency.enabled"}
+
+func TestListCurrencies(t *testing.T) {
+	ctx := context.Background()
+	db, mock := newMock()
+	repo := repository.NewWalletRepo(db)
+	defer func() {
+		repo.Close()
+	}()
+
+	query := `SELECT currency.code AS "currency.code", currency.numeric_code AS "currency.numeric_code", currency.exponent AS "currency.exponent", currency.enabled AS "currency.enabled"
+		FROM public.currency ORDER BY currency.code ASC;`
+	mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows(currencyColumns).
+		AddRow("jpy", 392, 0, true).
+		AddRow("usd", 840, 2, false))
+
+	result, err := repo.ListCurrencies(ctx)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, result, []domain.CurrencyInfo{
+		{Code: "jpy", NumericCode: 392, Exponent: 0, Enabled: true},
+		{Code: "usd", NumericCode: 840, Exponent: 2, Enabled: false},
+	})
+	assert.NilError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetCurrencyEnabled(t *testing.T) {
+	ctx := context.Background()
+	query := `UPDATE public.currency SET enabled = \$1::boolean WHERE currency.code = \$2::text RETURNING currency.code AS "currency.code"`
+
+	tests := map[string]struct {
+		err   error
+		mocks func(m sqlmock.Sqlmock)
+	}{
+		"Ok": {
+			mocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs(true, "gbp").
+					WillReturnRows(sqlmock.NewRows(currencyColumns).AddRow("gbp", 826, 2, true))
+			},
+		},
+		"not found": {
+			err: domain.ErrCurrencyNotFound,
+			mocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs(true, "gbp").
+					WillReturnRows(sqlmock.NewRows(currencyColumns))
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			db, mock := newMock()
+			repo := repository.NewWalletRepo(db)
+			defer func() {
+				repo.Close()
+			}()
+			tt.mocks(mock)
+
+			c, err := repo.SetCurrencyEnabled(ctx, "gbp", true)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NilError(t, err)
+				assert.DeepEqual(t, c, domain.CurrencyInfo{Code: "gbp", NumericCode: 826, Exponent: 2, Enabled: true})
+			}
+			assert.NilError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	pg "github.com/go-jet/jet/v2/postgres"
+	"github.com/google/uuid"
+	"github.com/ximura/gowallet/internal/core/domain"
+	"github.com/ximura/gowallet/internal/core/ports"
+	"github.com/ximura/gowallet/internal/repository/jet/model"
+)
+
+var _ ports.QuoteRepository = (*WalletRepo)(nil)
+
+func (r *WalletRepo) CreateQuote(ctx context.Context, quote domain.Quote) (domain.Quote, error) {
+	query := r.fxQuote.INSERT(
+		r.fxQuote.ID,
+		r.fxQuote.FromCurrency,
+		r.fxQuote.ToCurrency,
+		r.fxQuote.Rate,
+		r.fxQuote.SpreadBps,
+		r.fxQuote.ExpiresAt,
+	).VALUES(quote.ID, quote.From, quote.To, int64(quote.Rate), quote.SpreadBps, quote.ExpiresAt).
+		RETURNING(r.fxQuote.AllColumns.Except(r.fxQuote.CreatedAt))
+
+	var result model.FxQuote
+	if err := query.QueryContext(ctx, r.db, &result); err != nil {
+		return domain.Quote{}, err
+	}
+
+	return convertQuote(result), nil
+}
+
+func (r *WalletRepo) GetQuote(ctx context.Context, id uuid.UUID) (domain.Quote, error) {
+	query := r.fxQuote.SELECT(r.fxQuote.AllColumns.Except(r.fxQuote.CreatedAt)).
+		WHERE(r.fxQuote.ID.EQ(pg.UUID(id)))
+
+	var result model.FxQuote
+	if err := query.QueryContext(ctx, r.db, &result); err != nil {
+		return domain.Quote{}, notFound(err, domain.ErrQuoteNotFound)
+	}
+
+	return convertQuote(result), nil
+}
+
+func (r *WalletRepo) DeleteExpiredQuotes(ctx context.Context, before time.Time) (int, error) {
+	// quote of converted transaction is kept as its record
+	used := pg.SELECT(pg.Int(1)).
+		FROM(r.transaction).
+		WHERE(r.transaction.FxQuoteID.EQ(r.fxQuote.ID))
+	query := r.fxQuote.DELETE().
+		WHERE(r.fxQuote.ExpiresAt.LT(pg.TimestampzT(before)).
+			AND(pg.NOT(pg.EXISTS(used))))
+
+	result, err := query.ExecContext(ctx, r.db)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+func convertQuote(q model.FxQuote) domain.Quote {
+	return domain.Quote{
+		ID:        q.ID,
+		From:      domain.Currency(q.FromCurrency),
+		To:        domain.Currency(q.ToCurrency),
+		Rate:      domain.Rate(q.Rate),
+		SpreadBps: int(q.SpreadBps),
+		ExpiresAt: q.ExpiresAt,
+	}
+}
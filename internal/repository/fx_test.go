@@ -0,0 +1,152 @@
+package repository_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/ximura/gowallet/internal/core/domain"
+	"github.com/ximura/gowallet/internal/repository"
+	"gotest.tools/v3/assert"
+)
+
+var quoteColumns = []string{"fx_quote.id", "fx_quote.from_currency", "fx_quote.to_currency", "fx_quote.rate",
+	"fx_quote.spread_bps", "fx_quote.expires_at"}
+
+func TestCreateQuote(t *testing.T) {
+	ctx := context.Background()
+	db, mock := newMock()
+	repo := repository.NewWalletRepo(db)
+	defer func() {
+		repo.Close()
+	}()
+
+	quote := domain.Quote{
+		ID:        uuid.New(),
+		From:      "eur",
+		To:        "usd",
+		Rate:      1_085_000_000,
+		SpreadBps: 50,
+		ExpiresAt: time.Date(2024, 8, 1, 10, 0, 30, 0, time.UTC),
+	}
+	query := `INSERT INTO public.fx_quote \(id, from_currency, to_currency, rate, spread_bps, expires_at\)
+		VALUES \(\$1, \$2, \$3, \$4, \$5, \$6\)
+		RETURNING fx_quote.id AS "fx_quote.id"`
+	mock.ExpectQuery(query).
+		WithArgs(quote.ID, quote.From, quote.To, int64(quote.Rate), quote.SpreadBps, quote.ExpiresAt).
+		WillReturnRows(sqlmock.NewRows(quoteColumns).
+			AddRow(quote.ID, quote.From, quote.To, quote.Rate, quote.SpreadBps, quote.ExpiresAt))
+
+	result, err := repo.CreateQuote(ctx, quote)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, result, quote)
+	assert.NilError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteExpiredQuotes(t *testing.T) {
+	db, mock := newMock()
+	repo := repository.NewWalletRepo(db)
+	defer func() {
+		repo.Close()
+	}()
+	before := time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC)
+
+	// quotes of converted transactions are kept
+	mock.ExpectExec(`DELETE FROM public.fx_quote
+		WHERE \(fx_quote.expires_at < \$1::timestamp with time zone\) AND \(NOT \(EXISTS \(
+			SELECT \$2
+			FROM public.transaction
+			WHERE transaction.fx_quote_id = fx_quote.id
+		\)\)\);`).
+		WithArgs(before, 1).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	count, err := repo.DeleteExpiredQuotes(context.Background(), before)
+	assert.NilError(t, err)
+	assert.Equal(t, count, 3)
+	assert.NilError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetQuote(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	expiresAt := time.Date(2024, 8, 1, 10, 0, 30, 0, time.UTC)
+	query := `SELECT fx_quote.id AS "fx_quote.id", .* FROM public.fx_quote WHERE fx_quote.id = \$1;`
+
+	tests := map[string]struct {
+		err   error
+		mocks func(m sqlmock.Sqlmock)
+	}{
+		"Ok": {
+			mocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs(id).
+					WillReturnRows(sqlmock.NewRows(quoteColumns).AddRow(id, "eur", "usd", 1_085_000_000, 50, expiresAt))
+			},
+		},
+		"not found": {
+			err: domain.ErrQuoteNotFound,
+			mocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs(id).WillReturnRows(sqlmock.NewRows(quoteColumns))
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			db, mock := newMock()
+			repo := repository.NewWalletRepo(db)
+			defer func() {
+				repo.Close()
+			}()
+			tt.mocks(mock)
+
+			q, err := repo.GetQuote(ctx, id)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NilError(t, err)
+				assert.DeepEqual(t, q, domain.Quote{ID: id, From: "eur", To: "usd", Rate: 1_085_000_000, SpreadBps: 50, ExpiresAt: expiresAt})
+			}
+			assert.NilError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFindConvertedTransaction(t *testing.T) {
+	ctx := context.Background()
+	db, mock := newMock()
+	repo := repository.NewWalletRepo(db)
+	defer func() {
+		repo.Close()
+	}()
+
+	id := uuid.New()
+	quoteID := uuid.New()
+	createdAt := time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC)
+	columns := slices.Concat(transactionColumns, []string{"transaction.fx_amount", "transaction.fx_currency", "transaction.fx_rate",
+		"transaction.fx_spread_bps", "transaction.fx_quote_id"})
+	query := selectTransactionQuery + `WHERE transaction.transaction_id = \$1 ORDER BY transaction.wallet_id ASC;`
+	mock.ExpectQuery(query).WithArgs(id).WillReturnRows(sqlmock.NewRows(columns).
+		AddRow(1, id, 1079, "usd", 1079, nil, nil, createdAt, 1000, "eur", 1_085_000_000, 50, quoteID))
+
+	result, err := repo.FindTransactions(ctx, id)
+	assert.NilError(t, err)
+	assert.Equal(t, len(result), 1)
+	assert.DeepEqual(t, result[0].Conversion, &domain.Conversion{
+		FromAmount:   1000,
+		FromCurrency: "eur",
+		ToAmount:     1079,
+		ToCurrency:   "usd",
+		Rate:         1_085_000_000,
+		SpreadBps:    50,
+		QuoteID:      quoteID,
+	})
+	amount, currency := result[0].Requested()
+	assert.Equal(t, amount, domain.Amount(1000))
+	assert.Equal(t, currency, domain.Currency("eur"))
+	assert.NilError(t, mock.ExpectationsWereMet())
+}
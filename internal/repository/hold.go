@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	pg "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/google/uuid"
+	"github.com/ximura/gowallet/internal/core/domain"
+	"github.com/ximura/gowallet/internal/repository/jet/model"
+)
+
+func (r *WalletRepo) CreateHold(ctx context.Context, hold domain.Hold) (domain.Hold, error) {
+	var result domain.Hold
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		wallet, err := r.lockWallet(ctx, tx, hold.WalletID)
+		if err != nil {
+			return err
+		}
+		// hold id is idempotency key of its capture, so transactions can't take it
+		if err := r.claimKey(ctx, tx, hold.ID); err != nil {
+			return err
+		}
+		// hold reserves the same funds its capture debits
+		if err := wallet.CanApply(hold.Capture(hold.Amount)); err != nil {
+			return err
+		}
+
+		result, err = r.createHold(ctx, tx, hold)
+		return err
+	})
+	if err != nil {
+		return domain.Hold{}, err
+	}
+
+	return result, nil
+}
+
+func (r *WalletRepo) createHold(ctx context.Context, db qrm.Queryable, hold domain.Hold) (domain.Hold, error) {
+	query := r.hold.INSERT(
+		r.hold.ID,
+		r.hold.WalletID,
+		r.hold.Amount,
+		r.hold.Currency,
+		r.hold.Status,
+		r.hold.ExpiresAt,
+	).VALUES(hold.ID, hold.WalletID, hold.Amount, hold.Currency, domain.HoldActive, hold.ExpiresAt).
+		RETURNING(r.hold.AllColumns.Except(r.hold.UpdatedAt))
+
+	var result model.Hold
+	if err := query.QueryContext(ctx, db, &result); err != nil {
+		if isUniqueViolation(err, holdPrimaryKey) {
+			return domain.Hold{}, domain.ErrDuplicateTransaction
+		}
+		return domain.Hold{}, err
+	}
+
+	return convertHold(result), nil
+}
+
+func (r *WalletRepo) GetHold(ctx context.Context, id uuid.UUID) (domain.Hold, error) {
+	query := r.hold.SELECT(r.hold.AllColumns.Except(r.hold.UpdatedAt)).
+		WHERE(r.hold.ID.EQ(pg.UUID(id)))
+
+	var result model.Hold
+	if err := query.QueryContext(ctx, r.db, &result); err != nil {
+		return domain.Hold{}, notFound(err, domain.ErrHoldNotFound)
+	}
+
+	return convertHold(result), nil
+}
+
+func (r *WalletRepo) CaptureHold(ctx context.Context, hold domain.Hold, amount domain.Amount) (domain.Hold, domain.Wallet, error) {
+	var captured domain.Hold
+	var w domain.Wallet
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		captured, err = r.closeHold(ctx, tx, hold.ID, domain.HoldCaptured, amount)
+		if err != nil {
+			return err
+		}
+
+		// key of capture was claimed by the hold
+		capture := captured.Capture(amount)
+		wallet, err := r.lockWallet(ctx, tx, capture.WalletID)
+		if err != nil {
+			return err
+		}
+		// closed hold no longer reserves funds, so capture is checked against them
+		if err := wallet.CanApply(capture); err != nil {
+			return err
+		}
+
+		_, w, err = r.recordTransaction(ctx, tx, capture)
+		return err
+	})
+	if err != nil {
+		return domain.Hold{}, domain.Wallet{}, err
+	}
+
+	return captured, w, nil
+}
+
+func (r *WalletRepo) ReleaseHold(ctx context.Context, id uuid.UUID) (domain.Hold, error) {
+	return r.closeHold(ctx, r.db, id, domain.HoldReleased, 0)
+}
+
+func (r *WalletRepo) ExpireHolds(ctx context.Context) (int, error) {
+	query := r.hold.UPDATE(r.hold.Status).
+		SET(pg.String(string(domain.HoldExpired))).
+		WHERE(r.hold.Status.EQ(pg.String(string(domain.HoldActive))).
+			AND(r.hold.ExpiresAt.LT_EQ(pg.NOW())))
+
+	result, err := query.ExecContext(ctx, r.db)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+// closeHold moves active and not expired hold to final status
+func (r *WalletRepo) closeHold(ctx context.Context, db qrm.Queryable, id uuid.UUID, status domain.HoldStatus, captured domain.Amount) (domain.Hold, error) {
+	query := r.hold.UPDATE(r.hold.Status, r.hold.CapturedAmount).
+		SET(pg.String(string(status)), pg.Int(int64(captured))).
+		WHERE(r.hold.ID.EQ(pg.UUID(id)).
+			AND(r.hold.Status.EQ(pg.String(string(domain.HoldActive)))).
+			AND(r.hold.ExpiresAt.GT(pg.NOW()))).
+		RETURNING(r.hold.AllColumns.Except(r.hold.UpdatedAt))
+
+	var result model.Hold
+	if err := query.QueryContext(ctx, db, &result); err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return domain.Hold{}, domain.ErrHoldNotActive
+		}
+		return domain.Hold{}, err
+	}
+
+	return convertHold(result), nil
+}
+
+func convertHold(h model.Hold) domain.Hold {
+	return domain.Hold{
+		ID:             h.ID,
+		WalletID:       int(h.WalletID),
+		Amount:         domain.Amount(h.Amount),
+		Currency:       domain.Currency(h.Currency),
+		Status:         domain.HoldStatus(h.Status),
+		CapturedAmount: domain.Amount(h.CapturedAmount),
+		ExpiresAt:      h.ExpiresAt,
+		CreatedAt:      h.CreatedAt,
+	}
+}
@@ -0,0 +1,232 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/ximura/gowallet/internal/core/domain"
+	"github.com/ximura/gowallet/internal/repository"
+	"gotest.tools/v3/assert"
+)
+
+func TestCaptureHold(t *testing.T) {
+	ctx := context.Background()
+	hold := domain.Hold{
+		ID:        uuid.New(),
+		WalletID:  1,
+		Amount:    100,
+		Currency:  "usd",
+		Status:    domain.HoldActive,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	account := uuid.New()
+	holdColumns := []string{"hold.id", "hold.wallet_id", "hold.amount", "hold.currency", "hold.status", "hold.captured_amount", "hold.expires_at", "hold.created_at"}
+	closeQuery := `UPDATE public.hold
+		SET \(status, captured_amount\) = \(\$1::text, \$2\)
+		WHERE \(\(hold.id = \$3\) AND \(hold.status = \$4::text\)\) AND \(hold.expires_at > NOW\(\)\)
+		RETURNING hold.id AS "hold.id", hold.wallet_id AS "hold.wallet_id", hold.amount AS "hold.amount", hold.currency AS "hold.currency", hold.status AS "hold.status", hold.captured_amount AS "hold.captured_amount", hold.expires_at AS "hold.expires_at", hold.created_at AS "hold.created_at";`
+
+	tests := map[string]struct {
+		amount domain.Amount
+		err    error
+		mocks  func(m sqlmock.Sqlmock)
+	}{
+		"ErrHoldNotActive": {
+			amount: 40,
+			err:    domain.ErrHoldNotActive,
+			mocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(closeQuery).
+					WithArgs(domain.HoldCaptured, 40, hold.ID, domain.HoldActive).
+					WillReturnRows(sqlmock.NewRows(holdColumns))
+				mock.ExpectRollback()
+			},
+		},
+		"Ok": {
+			amount: 40,
+			mocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(closeQuery).
+					WithArgs(domain.HoldCaptured, 40, hold.ID, domain.HoldActive).
+					WillReturnRows(sqlmock.NewRows(holdColumns).
+						AddRow(hold.ID, hold.WalletID, hold.Amount, hold.Currency, domain.HoldCaptured, 40, hold.ExpiresAt, time.Now()))
+				// key of capture was claimed by the hold
+				expectLockWallet(mock, hold.WalletID, hold.Currency, 100, 0)
+
+				rows := sqlmock.NewRows([]string{"wallet.id", "wallet.account", "wallet.amount", "wallet.currency", "wallet.held"}).
+					AddRow(hold.WalletID, account, 60, hold.Currency, 0)
+				mock.ExpectQuery(updateWalletQuery).WithArgs(-40, hold.WalletID, hold.Currency, 0, 0, domain.HoldActive).WillReturnRows(rows)
+				mock.ExpectExec(insertTransactionQuery).
+					WithArgs(hold.WalletID, hold.ID, -40, hold.Currency, 60, nil, nil, nil, nil, nil, nil, nil).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				expectJournalEntry(mock, hold.ID, domain.EntryTransaction, 1)
+				expectWalletPosting(mock, hold.WalletID, -40, 1, 60)
+				expectSystemAccount(mock, domain.ExternalWithdrawal, hold.Currency, 2)
+				mock.ExpectExec(postingQuery).WithArgs(1, 1, -40, 1, 2, 40).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			db, mock := newMock()
+			repo := repository.NewWalletRepo(db)
+			defer func() {
+				repo.Close()
+			}()
+
+			mock.MatchExpectationsInOrder(true)
+			tt.mocks(mock)
+
+			captured, w, err := repo.CaptureHold(ctx, hold, tt.amount)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, captured.Status, domain.HoldCaptured)
+			assert.Equal(t, captured.CapturedAmount, tt.amount)
+			assert.Equal(t, w.Amount, domain.Amount(60))
+			assert.NilError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestExpireHolds(t *testing.T) {
+	ctx := context.Background()
+	query := `UPDATE public.hold SET status = \$1::text WHERE \(hold.status = \$2::text\) AND \(hold.expires_at <= NOW\(\)\);`
+
+	tests := map[string]struct {
+		count int
+		err   error
+		mocks func(m sqlmock.Sqlmock, err error)
+	}{
+		"error": {
+			err: errors.New("test error"),
+			mocks: func(mock sqlmock.Sqlmock, err error) {
+				mock.ExpectExec(query).WithArgs(domain.HoldExpired, domain.HoldActive).WillReturnError(err)
+			},
+		},
+		"Ok": {
+			count: 3,
+			mocks: func(mock sqlmock.Sqlmock, err error) {
+				mock.ExpectExec(query).WithArgs(domain.HoldExpired, domain.HoldActive).WillReturnResult(sqlmock.NewResult(0, 3))
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			db, mock := newMock()
+			repo := repository.NewWalletRepo(db)
+			defer func() {
+				repo.Close()
+			}()
+
+			tt.mocks(mock, tt.err)
+
+			count, err := repo.ExpireHolds(ctx)
+			if tt.err != nil {
+				assert.ErrorContains(t, err, tt.err.Error())
+			} else {
+				assert.NilError(t, err)
+				assert.Equal(t, count, tt.count)
+			}
+		})
+	}
+}
+
+func TestCreateHold(t *testing.T) {
+	ctx := context.Background()
+	hold := domain.Hold{
+		ID:        uuid.New(),
+		WalletID:  1,
+		Amount:    100,
+		Currency:  "usd",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	insertQuery := `INSERT INTO public.hold \(id, wallet_id, amount, currency, status, expires_at\)
+		VALUES \(\$1, \$2, \$3, \$4, \$5, \$6\)
+		RETURNING hold.id AS "hold.id"`
+
+	tests := map[string]struct {
+		err   error
+		mocks func(m sqlmock.Sqlmock)
+	}{
+		"funds already held": {
+			err: domain.ErrInsufficientFunds,
+			mocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectLockWallet(mock, hold.WalletID, hold.Currency, 150, 60)
+				expectClaimKey(mock, hold.ID, false)
+				mock.ExpectRollback()
+			},
+		},
+		"key already used": {
+			err: domain.ErrDuplicateTransaction,
+			mocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectLockWallet(mock, hold.WalletID, hold.Currency, 150, 50)
+				expectClaimKey(mock, hold.ID, true)
+				mock.ExpectRollback()
+			},
+		},
+		"hold created before keys were claimed": {
+			err: domain.ErrDuplicateTransaction,
+			mocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectLockWallet(mock, hold.WalletID, hold.Currency, 150, 50)
+				expectClaimKey(mock, hold.ID, false)
+				mock.ExpectQuery(insertQuery).
+					WithArgs(hold.ID, hold.WalletID, hold.Amount, hold.Currency, domain.HoldActive, hold.ExpiresAt).
+					WillReturnError(&pq.Error{Code: "23505", Constraint: "hold_pkey", Message: "duplicate key value violates unique constraint"})
+				mock.ExpectRollback()
+			},
+		},
+		"Ok": {
+			mocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectLockWallet(mock, hold.WalletID, hold.Currency, 150, 50)
+				expectClaimKey(mock, hold.ID, false)
+				mock.ExpectQuery(insertQuery).
+					WithArgs(hold.ID, hold.WalletID, hold.Amount, hold.Currency, domain.HoldActive, hold.ExpiresAt).
+					WillReturnRows(sqlmock.NewRows([]string{"hold.id", "hold.wallet_id", "hold.amount", "hold.currency", "hold.status"}).
+						AddRow(hold.ID, hold.WalletID, hold.Amount, hold.Currency, domain.HoldActive))
+				mock.ExpectCommit()
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			db, mock := newMock()
+			repo := repository.NewWalletRepo(db)
+			defer func() {
+				repo.Close()
+			}()
+
+			mock.MatchExpectationsInOrder(true)
+			tt.mocks(mock)
+
+			result, err := repo.CreateHold(ctx, hold)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NilError(t, err)
+				assert.Equal(t, result.Status, domain.HoldActive)
+			}
+			assert.NilError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -13,8 +13,18 @@ import (
 )
 
 type Transaction struct {
-	WalletID      int32     `sql:"primary_key"`
-	TransactionID uuid.UUID `sql:"primary_key"`
-	UpdatedAt     time.Time
-	CreatedAt     time.Time
+	WalletID             int32     `sql:"primary_key"`
+	TransactionID        uuid.UUID `sql:"primary_key"`
+	UpdatedAt            time.Time
+	CreatedAt            time.Time
+	Amount               int64
+	Currency             string
+	Balance              int64
+	CounterpartyWalletID *int32
+	ReversalOf           *uuid.UUID
+	FxAmount             *int64
+	FxCurrency           *string
+	FxRate               *int64
+	FxSpreadBps          *int32
+	FxQuoteID            *uuid.UUID
 }
@@ -13,10 +13,15 @@ import (
 )
 
 type Wallet struct {
-	ID        int32 `sql:"primary_key"`
-	Account   uuid.UUID
-	Amount    int32
-	Currency  string
-	UpdatedAt time.Time
-	CreatedAt time.Time
+	ID            int32 `sql:"primary_key"`
+	Account       uuid.UUID
+	Amount        int64
+	Currency      string
+	UpdatedAt     time.Time
+	CreatedAt     time.Time
+	ParentID      *int32
+	MultiCurrency bool
+	Status        string
+	BlockCredits  bool
+	Version       int64
 }
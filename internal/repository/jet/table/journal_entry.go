@@ -0,0 +1,84 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/postgres"
+)
+
+var JournalEntry = newJournalEntryTable("public", "journal_entry", "")
+
+type journalEntryTable struct {
+	postgres.Table
+
+	// Columns
+	ID            postgres.ColumnInteger
+	TransactionID postgres.ColumnString
+	Kind          postgres.ColumnString
+	CreatedAt     postgres.ColumnTimestampz
+
+	AllColumns     postgres.ColumnList
+	MutableColumns postgres.ColumnList
+}
+
+type JournalEntryTable struct {
+	journalEntryTable
+
+	EXCLUDED journalEntryTable
+}
+
+// AS creates new JournalEntryTable with assigned alias
+func (a JournalEntryTable) AS(alias string) *JournalEntryTable {
+	return newJournalEntryTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new JournalEntryTable with assigned schema name
+func (a JournalEntryTable) FromSchema(schemaName string) *JournalEntryTable {
+	return newJournalEntryTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new JournalEntryTable with assigned table prefix
+func (a JournalEntryTable) WithPrefix(prefix string) *JournalEntryTable {
+	return newJournalEntryTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new JournalEntryTable with assigned table suffix
+func (a JournalEntryTable) WithSuffix(suffix string) *JournalEntryTable {
+	return newJournalEntryTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newJournalEntryTable(schemaName, tableName, alias string) *JournalEntryTable {
+	return &JournalEntryTable{
+		journalEntryTable: newJournalEntryTableImpl(schemaName, tableName, alias),
+		EXCLUDED:          newJournalEntryTableImpl("", "excluded", ""),
+	}
+}
+
+func newJournalEntryTableImpl(schemaName, tableName, alias string) journalEntryTable {
+	var (
+		IDColumn            = postgres.IntegerColumn("id")
+		TransactionIDColumn = postgres.StringColumn("transaction_id")
+		KindColumn          = postgres.StringColumn("kind")
+		CreatedAtColumn     = postgres.TimestampzColumn("created_at")
+		allColumns          = postgres.ColumnList{IDColumn, TransactionIDColumn, KindColumn, CreatedAtColumn}
+		mutableColumns      = postgres.ColumnList{TransactionIDColumn, KindColumn, CreatedAtColumn}
+	)
+
+	return journalEntryTable{
+		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		ID:            IDColumn,
+		TransactionID: TransactionIDColumn,
+		Kind:          KindColumn,
+		CreatedAt:     CreatedAtColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+	}
+}
@@ -0,0 +1,90 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/postgres"
+)
+
+var LedgerAccount = newLedgerAccountTable("public", "ledger_account", "")
+
+type ledgerAccountTable struct {
+	postgres.Table
+
+	// Columns
+	ID        postgres.ColumnInteger
+	WalletID  postgres.ColumnInteger
+	Code      postgres.ColumnString
+	Currency  postgres.ColumnString
+	Balance   postgres.ColumnInteger
+	CreatedAt postgres.ColumnTimestampz
+
+	AllColumns     postgres.ColumnList
+	MutableColumns postgres.ColumnList
+}
+
+type LedgerAccountTable struct {
+	ledgerAccountTable
+
+	EXCLUDED ledgerAccountTable
+}
+
+// AS creates new LedgerAccountTable with assigned alias
+func (a LedgerAccountTable) AS(alias string) *LedgerAccountTable {
+	return newLedgerAccountTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new LedgerAccountTable with assigned schema name
+func (a LedgerAccountTable) FromSchema(schemaName string) *LedgerAccountTable {
+	return newLedgerAccountTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new LedgerAccountTable with assigned table prefix
+func (a LedgerAccountTable) WithPrefix(prefix string) *LedgerAccountTable {
+	return newLedgerAccountTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new LedgerAccountTable with assigned table suffix
+func (a LedgerAccountTable) WithSuffix(suffix string) *LedgerAccountTable {
+	return newLedgerAccountTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newLedgerAccountTable(schemaName, tableName, alias string) *LedgerAccountTable {
+	return &LedgerAccountTable{
+		ledgerAccountTable: newLedgerAccountTableImpl(schemaName, tableName, alias),
+		EXCLUDED:           newLedgerAccountTableImpl("", "excluded", ""),
+	}
+}
+
+func newLedgerAccountTableImpl(schemaName, tableName, alias string) ledgerAccountTable {
+	var (
+		IDColumn        = postgres.IntegerColumn("id")
+		WalletIDColumn  = postgres.IntegerColumn("wallet_id")
+		CodeColumn      = postgres.StringColumn("code")
+		CurrencyColumn  = postgres.StringColumn("currency")
+		BalanceColumn   = postgres.IntegerColumn("balance")
+		CreatedAtColumn = postgres.TimestampzColumn("created_at")
+		allColumns      = postgres.ColumnList{IDColumn, WalletIDColumn, CodeColumn, CurrencyColumn, BalanceColumn, CreatedAtColumn}
+		mutableColumns  = postgres.ColumnList{WalletIDColumn, CodeColumn, CurrencyColumn, BalanceColumn, CreatedAtColumn}
+	)
+
+	return ledgerAccountTable{
+		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		ID:        IDColumn,
+		WalletID:  WalletIDColumn,
+		Code:      CodeColumn,
+		Currency:  CurrencyColumn,
+		Balance:   BalanceColumn,
+		CreatedAt: CreatedAtColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+	}
+}
@@ -0,0 +1,84 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/postgres"
+)
+
+var Posting = newPostingTable("public", "posting", "")
+
+type postingTable struct {
+	postgres.Table
+
+	// Columns
+	ID              postgres.ColumnInteger
+	JournalEntryID  postgres.ColumnInteger
+	LedgerAccountID postgres.ColumnInteger
+	Amount          postgres.ColumnInteger
+
+	AllColumns     postgres.ColumnList
+	MutableColumns postgres.ColumnList
+}
+
+type PostingTable struct {
+	postingTable
+
+	EXCLUDED postingTable
+}
+
+// AS creates new PostingTable with assigned alias
+func (a PostingTable) AS(alias string) *PostingTable {
+	return newPostingTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new PostingTable with assigned schema name
+func (a PostingTable) FromSchema(schemaName string) *PostingTable {
+	return newPostingTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new PostingTable with assigned table prefix
+func (a PostingTable) WithPrefix(prefix string) *PostingTable {
+	return newPostingTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new PostingTable with assigned table suffix
+func (a PostingTable) WithSuffix(suffix string) *PostingTable {
+	return newPostingTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newPostingTable(schemaName, tableName, alias string) *PostingTable {
+	return &PostingTable{
+		postingTable: newPostingTableImpl(schemaName, tableName, alias),
+		EXCLUDED:     newPostingTableImpl("", "excluded", ""),
+	}
+}
+
+func newPostingTableImpl(schemaName, tableName, alias string) postingTable {
+	var (
+		IDColumn              = postgres.IntegerColumn("id")
+		JournalEntryIDColumn  = postgres.IntegerColumn("journal_entry_id")
+		LedgerAccountIDColumn = postgres.IntegerColumn("ledger_account_id")
+		AmountColumn          = postgres.IntegerColumn("amount")
+		allColumns            = postgres.ColumnList{IDColumn, JournalEntryIDColumn, LedgerAccountIDColumn, AmountColumn}
+		mutableColumns        = postgres.ColumnList{JournalEntryIDColumn, LedgerAccountIDColumn, AmountColumn}
+	)
+
+	return postingTable{
+		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		ID:              IDColumn,
+		JournalEntryID:  JournalEntryIDColumn,
+		LedgerAccountID: LedgerAccountIDColumn,
+		Amount:          AmountColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+	}
+}
@@ -10,6 +10,14 @@ package table
 // UseSchema sets a new schema name for all generated table SQL builder types. It is recommended to invoke
 // this method only once at the beginning of the program.
 func UseSchema(schema string) {
+	Currency = Currency.FromSchema(schema)
+	FxQuote = FxQuote.FromSchema(schema)
+	Hold = Hold.FromSchema(schema)
+	JournalEntry = JournalEntry.FromSchema(schema)
+	LedgerAccount = LedgerAccount.FromSchema(schema)
+	Posting = Posting.FromSchema(schema)
 	Transaction = Transaction.FromSchema(schema)
 	Wallet = Wallet.FromSchema(schema)
+	WalletStatusChange = WalletStatusChange.FromSchema(schema)
+	IdempotencyKey = IdempotencyKey.FromSchema(schema)
 }
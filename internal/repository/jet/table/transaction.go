@@ -17,10 +17,20 @@ type transactionTable struct {
 	postgres.Table
 
 	// Columns
-	WalletID      postgres.ColumnInteger
-	TransactionID postgres.ColumnString
-	UpdatedAt     postgres.ColumnTimestampz
-	CreatedAt     postgres.ColumnTimestampz
+	WalletID             postgres.ColumnInteger
+	TransactionID        postgres.ColumnString
+	UpdatedAt            postgres.ColumnTimestampz
+	CreatedAt            postgres.ColumnTimestampz
+	Amount               postgres.ColumnInteger
+	Currency             postgres.ColumnString
+	Balance              postgres.ColumnInteger
+	CounterpartyWalletID postgres.ColumnInteger
+	ReversalOf           postgres.ColumnString
+	FxAmount             postgres.ColumnInteger
+	FxCurrency           postgres.ColumnString
+	FxRate               postgres.ColumnInteger
+	FxSpreadBps          postgres.ColumnInteger
+	FxQuoteID            postgres.ColumnString
 
 	AllColumns     postgres.ColumnList
 	MutableColumns postgres.ColumnList
@@ -61,22 +71,42 @@ func newTransactionTable(schemaName, tableName, alias string) *TransactionTable
 
 func newTransactionTableImpl(schemaName, tableName, alias string) transactionTable {
 	var (
-		WalletIDColumn      = postgres.IntegerColumn("wallet_id")
-		TransactionIDColumn = postgres.StringColumn("transaction_id")
-		UpdatedAtColumn     = postgres.TimestampzColumn("updated_at")
-		CreatedAtColumn     = postgres.TimestampzColumn("created_at")
-		allColumns          = postgres.ColumnList{WalletIDColumn, TransactionIDColumn, UpdatedAtColumn, CreatedAtColumn}
-		mutableColumns      = postgres.ColumnList{UpdatedAtColumn, CreatedAtColumn}
+		WalletIDColumn             = postgres.IntegerColumn("wallet_id")
+		TransactionIDColumn        = postgres.StringColumn("transaction_id")
+		UpdatedAtColumn            = postgres.TimestampzColumn("updated_at")
+		CreatedAtColumn            = postgres.TimestampzColumn("created_at")
+		AmountColumn               = postgres.IntegerColumn("amount")
+		CurrencyColumn             = postgres.StringColumn("currency")
+		BalanceColumn              = postgres.IntegerColumn("balance")
+		CounterpartyWalletIDColumn = postgres.IntegerColumn("counterparty_wallet_id")
+		ReversalOfColumn           = postgres.StringColumn("reversal_of")
+		FxAmountColumn             = postgres.IntegerColumn("fx_amount")
+		FxCurrencyColumn           = postgres.StringColumn("fx_currency")
+		FxRateColumn               = postgres.IntegerColumn("fx_rate")
+		FxSpreadBpsColumn          = postgres.IntegerColumn("fx_spread_bps")
+		FxQuoteIDColumn            = postgres.StringColumn("fx_quote_id")
+		allColumns                 = postgres.ColumnList{WalletIDColumn, TransactionIDColumn, UpdatedAtColumn, CreatedAtColumn, AmountColumn, CurrencyColumn, BalanceColumn, CounterpartyWalletIDColumn, ReversalOfColumn, FxAmountColumn, FxCurrencyColumn, FxRateColumn, FxSpreadBpsColumn, FxQuoteIDColumn}
+		mutableColumns             = postgres.ColumnList{UpdatedAtColumn, CreatedAtColumn, AmountColumn, CurrencyColumn, BalanceColumn, CounterpartyWalletIDColumn, ReversalOfColumn, FxAmountColumn, FxCurrencyColumn, FxRateColumn, FxSpreadBpsColumn, FxQuoteIDColumn}
 	)
 
 	return transactionTable{
 		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
 
 		//Columns
-		WalletID:      WalletIDColumn,
-		TransactionID: TransactionIDColumn,
-		UpdatedAt:     UpdatedAtColumn,
-		CreatedAt:     CreatedAtColumn,
+		WalletID:             WalletIDColumn,
+		TransactionID:        TransactionIDColumn,
+		UpdatedAt:            UpdatedAtColumn,
+		CreatedAt:            CreatedAtColumn,
+		Amount:               AmountColumn,
+		Currency:             CurrencyColumn,
+		Balance:              BalanceColumn,
+		CounterpartyWalletID: CounterpartyWalletIDColumn,
+		ReversalOf:           ReversalOfColumn,
+		FxAmount:             FxAmountColumn,
+		FxCurrency:           FxCurrencyColumn,
+		FxRate:               FxRateColumn,
+		FxSpreadBps:          FxSpreadBpsColumn,
+		FxQuoteID:            FxQuoteIDColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
@@ -17,12 +17,17 @@ type walletTable struct {
 	postgres.Table
 
 	// Columns
-	ID        postgres.ColumnInteger
-	Account   postgres.ColumnString
-	Amount    postgres.ColumnInteger
-	Currency  postgres.ColumnString
-	UpdatedAt postgres.ColumnTimestampz
-	CreatedAt postgres.ColumnTimestampz
+	ID            postgres.ColumnInteger
+	Account       postgres.ColumnString
+	Amount        postgres.ColumnInteger
+	Currency      postgres.ColumnString
+	UpdatedAt     postgres.ColumnTimestampz
+	CreatedAt     postgres.ColumnTimestampz
+	ParentID      postgres.ColumnInteger
+	MultiCurrency postgres.ColumnBool
+	Status        postgres.ColumnString
+	BlockCredits  postgres.ColumnBool
+	Version       postgres.ColumnInteger
 
 	AllColumns     postgres.ColumnList
 	MutableColumns postgres.ColumnList
@@ -63,26 +68,36 @@ func newWalletTable(schemaName, tableName, alias string) *WalletTable {
 
 func newWalletTableImpl(schemaName, tableName, alias string) walletTable {
 	var (
-		IDColumn        = postgres.IntegerColumn("id")
-		AccountColumn   = postgres.StringColumn("account")
-		AmountColumn    = postgres.IntegerColumn("amount")
-		CurrencyColumn  = postgres.StringColumn("currency")
-		UpdatedAtColumn = postgres.TimestampzColumn("updated_at")
-		CreatedAtColumn = postgres.TimestampzColumn("created_at")
-		allColumns      = postgres.ColumnList{IDColumn, AccountColumn, AmountColumn, CurrencyColumn, UpdatedAtColumn, CreatedAtColumn}
-		mutableColumns  = postgres.ColumnList{AccountColumn, AmountColumn, CurrencyColumn, UpdatedAtColumn, CreatedAtColumn}
+		IDColumn            = postgres.IntegerColumn("id")
+		AccountColumn       = postgres.StringColumn("account")
+		AmountColumn        = postgres.IntegerColumn("amount")
+		CurrencyColumn      = postgres.StringColumn("currency")
+		UpdatedAtColumn     = postgres.TimestampzColumn("updated_at")
+		CreatedAtColumn     = postgres.TimestampzColumn("created_at")
+		ParentIDColumn      = postgres.IntegerColumn("parent_id")
+		MultiCurrencyColumn = postgres.BoolColumn("multi_currency")
+		StatusColumn        = postgres.StringColumn("status")
+		BlockCreditsColumn  = postgres.BoolColumn("block_credits")
+		VersionColumn       = postgres.IntegerColumn("version")
+		allColumns          = postgres.ColumnList{IDColumn, AccountColumn, AmountColumn, CurrencyColumn, UpdatedAtColumn, CreatedAtColumn, ParentIDColumn, MultiCurrencyColumn, StatusColumn, BlockCreditsColumn, VersionColumn}
+		mutableColumns      = postgres.ColumnList{AccountColumn, AmountColumn, CurrencyColumn, UpdatedAtColumn, CreatedAtColumn, ParentIDColumn, MultiCurrencyColumn, StatusColumn, BlockCreditsColumn, VersionColumn}
 	)
 
 	return walletTable{
 		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
 
 		//Columns
-		ID:        IDColumn,
-		Account:   AccountColumn,
-		Amount:    AmountColumn,
-		Currency:  CurrencyColumn,
-		UpdatedAt: UpdatedAtColumn,
-		CreatedAt: CreatedAtColumn,
+		ID:            IDColumn,
+		Account:       AccountColumn,
+		Amount:        AmountColumn,
+		Currency:      CurrencyColumn,
+		UpdatedAt:     UpdatedAtColumn,
+		CreatedAt:     CreatedAtColumn,
+		ParentID:      ParentIDColumn,
+		MultiCurrency: MultiCurrencyColumn,
+		Status:        StatusColumn,
+		BlockCredits:  BlockCreditsColumn,
+		Version:       VersionColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	pg "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/ximura/gowallet/internal/core/domain"
+	"github.com/ximura/gowallet/internal/repository/jet/model"
+)
+
+// postJournalEntry stores balanced journal entry and moves balances of wallet accounts,
+// every wallet account touched by entry is checked against provided wallet state.
+func (r *WalletRepo) postJournalEntry(ctx context.Context, db qrm.DB, entry domain.JournalEntry, wallets ...domain.Wallet) error {
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+
+	var journalEntry model.JournalEntry
+	query := r.journalEntry.INSERT(r.journalEntry.TransactionID, r.journalEntry.Kind).
+		VALUES(entry.TransactionID, entry.Kind).
+		RETURNING(r.journalEntry.ID)
+	if err := query.QueryContext(ctx, db, &journalEntry); err != nil {
+		return err
+	}
+
+	insert := r.posting.INSERT(r.posting.JournalEntryID, r.posting.LedgerAccountID, r.posting.Amount)
+	for _, posting := range entry.Postings {
+		accountID, err := r.ledgerAccountID(ctx, db, posting, wallets)
+		if err != nil {
+			return err
+		}
+		insert = insert.VALUES(journalEntry.ID, accountID, posting.Amount)
+	}
+
+	if _, err := insert.ExecContext(ctx, db); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *WalletRepo) ledgerAccountID(ctx context.Context, db qrm.DB, posting domain.Posting, wallets []domain.Wallet) (int32, error) {
+	if !posting.Account.IsWallet() {
+		return r.systemAccountID(ctx, db, posting.Account)
+	}
+
+	query := r.ledgerAccount.UPDATE(r.ledgerAccount.Balance).
+		SET(r.ledgerAccount.Balance.ADD(pg.Int(int64(posting.Amount)))).
+		WHERE(r.ledgerAccount.WalletID.EQ(pg.Int(int64(posting.Account.WalletID)))).
+		RETURNING(r.ledgerAccount.ID, r.ledgerAccount.Balance)
+
+	var account model.LedgerAccount
+	if err := query.QueryContext(ctx, db, &account); err != nil {
+		return 0, fmt.Errorf("can't post to wallet %d account: %w", posting.Account.WalletID, err)
+	}
+
+	for _, w := range wallets {
+		if w.ID == posting.Account.WalletID && int64(w.Amount) != account.Balance {
+			return 0, fmt.Errorf("%w: wallet %d has %d, ledger has %d", domain.ErrLedgerMismatch, w.ID, w.Amount, account.Balance)
+		}
+	}
+
+	return account.ID, nil
+}
+
+// systemAccountID returns id of system account, account is created on first use.
+// Balance of system accounts is not maintained to avoid contention on a single row.
+func (r *WalletRepo) systemAccountID(ctx context.Context, db qrm.DB, account domain.LedgerAccount) (int32, error) {
+	key := string(account.System) + "/" + string(account.Currency)
+	if id, ok := r.systemAccounts.Load(key); ok {
+		return id.(int32), nil
+	}
+
+	condition := r.ledgerAccount.Code.EQ(pg.String(string(account.System))).
+		AND(r.ledgerAccount.Currency.EQ(pg.String(string(account.Currency))))
+	query := r.ledgerAccount.SELECT(r.ledgerAccount.ID).WHERE(condition)
+
+	var result model.LedgerAccount
+	err := query.QueryContext(ctx, db, &result)
+	if err == nil {
+		// only accounts that existed before current transaction are cached,
+		// created one would be gone if transaction is rolled back
+		r.systemAccounts.Store(key, result.ID)
+		return result.ID, nil
+	}
+	if !errors.Is(err, qrm.ErrNoRows) {
+		return 0, err
+	}
+
+	insert := r.ledgerAccount.INSERT(r.ledgerAccount.Code, r.ledgerAccount.Currency).
+		VALUES(account.System, account.Currency).
+		ON_CONFLICT(r.ledgerAccount.Code, r.ledgerAccount.Currency).DO_NOTHING()
+	if _, err := insert.ExecContext(ctx, db); err != nil {
+		return 0, err
+	}
+
+	if err := query.QueryContext(ctx, db, &result); err != nil {
+		return 0, fmt.Errorf("can't create %s account: %w", key, err)
+	}
+
+	return result.ID, nil
+}
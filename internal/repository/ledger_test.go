@@ -0,0 +1,129 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/google/uuid"
+	"github.com/ximura/gowallet/internal/core/domain"
+	"github.com/ximura/gowallet/internal/repository"
+	"gotest.tools/v3/assert"
+)
+
+const (
+	journalEntryQuery  = `INSERT INTO public.journal_entry \(transaction_id, kind\) VALUES \(\$1, \$2\) RETURNING journal_entry.id AS "journal_entry.id";`
+	walletPostingQuery = `UPDATE public.ledger_account SET balance = \(ledger_account.balance \+ \$1\) WHERE ledger_account.wallet_id = \$2
+		RETURNING ledger_account.id AS "ledger_account.id", ledger_account.balance AS "ledger_account.balance";`
+	systemAccountQuery = `SELECT ledger_account.id AS "ledger_account.id" FROM public.ledger_account
+		WHERE \(ledger_account.code = \$1::text\) AND \(ledger_account.currency = \$2::text\);`
+	createSystemAccountQuery = `INSERT INTO public.ledger_account \(code, currency\) VALUES \(\$1, \$2\) ON CONFLICT \(code, currency\) DO NOTHING;`
+	postingQuery             = `INSERT INTO public.posting \(journal_entry_id, ledger_account_id, amount\) VALUES \(\$1, \$2, \$3\), \(\$4, \$5, \$6\);`
+)
+
+func expectJournalEntry(mock sqlmock.Sqlmock, transactionID uuid.UUID, kind domain.EntryKind, id int64) {
+	mock.ExpectQuery(journalEntryQuery).WithArgs(transactionID, kind).
+		WillReturnRows(sqlmock.NewRows([]string{"journal_entry.id"}).AddRow(id))
+}
+
+func expectWalletPosting(mock sqlmock.Sqlmock, walletID int, amount domain.Amount, accountID int32, balance int64) {
+	mock.ExpectQuery(walletPostingQuery).WithArgs(amount, walletID).
+		WillReturnRows(sqlmock.NewRows([]string{"ledger_account.id", "ledger_account.balance"}).AddRow(accountID, balance))
+}
+
+func expectSystemAccount(mock sqlmock.Sqlmock, account domain.SystemAccount, currency domain.Currency, id int32) {
+	mock.ExpectQuery(systemAccountQuery).WithArgs(account, currency).
+		WillReturnRows(sqlmock.NewRows([]string{"ledger_account.id"}).AddRow(id))
+}
+
+func TestPostJournalEntry(t *testing.T) {
+	ctx := context.Background()
+	transaction := domain.Transaction{
+		ID:       uuid.New(),
+		WalletID: 1,
+		Amount:   10,
+		Currency: "usd",
+	}
+	updateQuery := `UPDATE public.wallet SET amount = \(wallet.amount \+ \$1\)`
+	insertQuery := `INSERT INTO public.transaction`
+	walletRows := func(amount int) *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"wallet.id", "wallet.account", "wallet.amount", "wallet.currency"}).
+			AddRow(transaction.WalletID, uuid.New(), amount, transaction.Currency)
+	}
+
+	tests := map[string]struct {
+		err   error
+		mocks func(m sqlmock.Sqlmock)
+	}{
+		"ErrLedgerMismatch": {
+			err: domain.ErrLedgerMismatch,
+			mocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectLockWallet(mock, transaction.WalletID, transaction.Currency, 100, 0)
+				expectClaimKey(mock, transaction.ID, false)
+				mock.ExpectQuery(updateQuery).WillReturnRows(walletRows(110))
+				mock.ExpectExec(insertQuery).WillReturnResult(sqlmock.NewResult(0, 1))
+				expectJournalEntry(mock, transaction.ID, domain.EntryTransaction, 1)
+				expectWalletPosting(mock, transaction.WalletID, transaction.Amount, 1, 10)
+				mock.ExpectRollback()
+			},
+		},
+		"create system account": {
+			mocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectLockWallet(mock, transaction.WalletID, transaction.Currency, 100, 0)
+				expectClaimKey(mock, transaction.ID, false)
+				mock.ExpectQuery(updateQuery).WillReturnRows(walletRows(110))
+				mock.ExpectExec(insertQuery).WillReturnResult(sqlmock.NewResult(0, 1))
+				expectJournalEntry(mock, transaction.ID, domain.EntryTransaction, 1)
+				expectWalletPosting(mock, transaction.WalletID, transaction.Amount, 1, 110)
+				mock.ExpectQuery(systemAccountQuery).WithArgs(domain.ExternalFunding, transaction.Currency).
+					WillReturnError(qrm.ErrNoRows)
+				mock.ExpectExec(createSystemAccountQuery).WithArgs(domain.ExternalFunding, transaction.Currency).
+					WillReturnResult(sqlmock.NewResult(2, 1))
+				expectSystemAccount(mock, domain.ExternalFunding, transaction.Currency, 2)
+				mock.ExpectExec(postingQuery).WithArgs(1, 1, transaction.Amount, 1, 2, -transaction.Amount).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+		},
+		"posting error": {
+			err: errors.New("pq: journal entry 1 is not balanced"),
+			mocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectLockWallet(mock, transaction.WalletID, transaction.Currency, 100, 0)
+				expectClaimKey(mock, transaction.ID, false)
+				mock.ExpectQuery(updateQuery).WillReturnRows(walletRows(110))
+				mock.ExpectExec(insertQuery).WillReturnResult(sqlmock.NewResult(0, 1))
+				expectJournalEntry(mock, transaction.ID, domain.EntryTransaction, 1)
+				expectWalletPosting(mock, transaction.WalletID, transaction.Amount, 1, 110)
+				expectSystemAccount(mock, domain.ExternalFunding, transaction.Currency, 2)
+				mock.ExpectExec(postingQuery).WillReturnError(errors.New("pq: journal entry 1 is not balanced"))
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			db, mock := newMock()
+			repo := repository.NewWalletRepo(db)
+			defer func() {
+				repo.Close()
+			}()
+			mock.MatchExpectationsInOrder(true)
+			tt.mocks(mock)
+
+			_, err := repo.ProcessTransaction(ctx, transaction)
+			if tt.err != nil {
+				assert.ErrorContains(t, err, tt.err.Error())
+			} else {
+				assert.NilError(t, err)
+			}
+			assert.NilError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
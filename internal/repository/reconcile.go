@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+
+	pg "github.com/go-jet/jet/v2/postgres"
+	"github.com/google/uuid"
+	"github.com/ximura/gowallet/internal/core/domain"
+	"github.com/ximura/gowallet/internal/core/ports"
+	"github.com/ximura/gowallet/internal/repository/jet/model"
+)
+
+var _ ports.ReconciliationRepository = (*WalletRepo)(nil)
+
+func (r *WalletRepo) RecomputeBalances(ctx context.Context, filter domain.ReconcileFilter) ([]domain.BalanceReport, error) {
+	// wallets funded before the ledger have opening balance entry instead of history with amounts,
+	// it replaces transactions recorded before it
+	walletEntries := r.posting.
+		INNER_JOIN(r.ledgerAccount, r.ledgerAccount.ID.EQ(r.posting.LedgerAccountID)).
+		INNER_JOIN(r.journalEntry, r.journalEntry.ID.EQ(r.posting.JournalEntryID))
+	isOpening := r.ledgerAccount.WalletID.EQ(r.wallet.ID).
+		AND(r.journalEntry.Kind.EQ(pg.String(string(domain.EntryOpeningBalance))))
+	openingBalance := pg.SELECT(pg.COALESCE(pg.SUM(r.posting.Amount), pg.Int(0))).
+		FROM(walletEntries).
+		WHERE(isOpening)
+	afterOpening := pg.NOT(pg.EXISTS(pg.SELECT(pg.Int(1)).
+		FROM(walletEntries).
+		WHERE(isOpening.AND(r.journalEntry.CreatedAt.GT(r.transaction.CreatedAt)))))
+	historyBalance := pg.SELECT(pg.IntExp(pg.COALESCE(pg.SUM(r.transaction.Amount), pg.Int(0))).ADD(pg.IntExp(openingBalance))).
+		FROM(r.transaction).
+		WHERE(r.transaction.WalletID.EQ(r.wallet.ID).AND(afterOpening))
+
+	ledgerBalance := pg.SELECT(pg.COALESCE(pg.SUM(r.posting.Amount), pg.Int(0))).
+		FROM(r.posting.INNER_JOIN(r.ledgerAccount, r.ledgerAccount.ID.EQ(r.posting.LedgerAccountID))).
+		WHERE(r.ledgerAccount.WalletID.EQ(r.wallet.ID))
+
+	condition := r.wallet.ID.GT(pg.Int(int64(filter.AfterID)))
+	if filter.Account != uuid.Nil {
+		condition = condition.AND(r.wallet.Account.EQ(pg.UUID(filter.Account)))
+	}
+
+	query := r.wallet.SELECT(
+		r.wallet.ID,
+		r.wallet.Account,
+		r.wallet.Amount,
+		r.wallet.Currency,
+		pg.IntExp(historyBalance).AS("balance.history"),
+		pg.IntExp(ledgerBalance).AS("balance.ledger")).
+		WHERE(condition).
+		ORDER_BY(r.wallet.ID.ASC()).
+		LIMIT(int64(filter.Limit))
+
+	var rows []struct {
+		model.Wallet
+
+		HistoryBalance int64 `alias:"balance.history"`
+		LedgerBalance  int64 `alias:"balance.ledger"`
+	}
+	if err := query.QueryContext(ctx, r.db, &rows); err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.BalanceReport, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, domain.BalanceReport{
+			WalletID:       int(row.ID),
+			Account:        row.Account,
+			Currency:       domain.Currency(row.Currency),
+			Balance:        domain.Amount(row.Amount),
+			HistoryBalance: domain.Amount(row.HistoryBalance),
+			LedgerBalance:  domain.Amount(row.LedgerBalance),
+		})
+	}
+
+	return result, nil
+}
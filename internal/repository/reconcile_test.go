@@ -0,0 +1,144 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/ximura/gowallet/internal/core/domain"
+	"github.com/ximura/gowallet/internal/repository"
+	"gotest.tools/v3/assert"
+)
+
+func TestRecomputeBalances(t *testing.T) {
+	ctx := context.Background()
+	account := uuid.New()
+	columns := []string{"wallet.id", "wallet.account", "wallet.amount", "wallet.currency", "balance.history", "balance.ledger"}
+	query := `SELECT wallet.id AS "wallet.id", wallet.account AS "wallet.account", wallet.amount AS "wallet.amount", wallet.currency AS "wallet.currency",
+		\(
+			SELECT COALESCE\(SUM\(transaction.amount\), \$1\) \+ \(
+				SELECT COALESCE\(SUM\(posting.amount\), \$2\)
+				FROM public.posting
+				INNER JOIN public.ledger_account ON \(ledger_account.id = posting.ledger_account_id\)
+				INNER JOIN public.journal_entry ON \(journal_entry.id = posting.journal_entry_id\)
+				WHERE \(ledger_account.wallet_id = wallet.id\) AND \(journal_entry.kind = \$3::text\)
+			\)
+			FROM public.transaction
+			WHERE \(transaction.wallet_id = wallet.id\) AND \(NOT \(EXISTS \(
+				SELECT \$4
+				FROM public.posting
+				INNER JOIN public.ledger_account ON \(ledger_account.id = posting.ledger_account_id\)
+				INNER JOIN public.journal_entry ON \(journal_entry.id = posting.journal_entry_id\)
+				WHERE \(\(ledger_account.wallet_id = wallet.id\) AND \(journal_entry.kind = \$5::text\)\) AND \(journal_entry.created_at > transaction.created_at\)
+			\)\)\)
+		\) AS "balance.history",
+		\(
+			SELECT COALESCE\(SUM\(posting.amount\), \$6\)
+			FROM public.posting
+			INNER JOIN public.ledger_account ON \(ledger_account.id = posting.ledger_account_id\)
+			WHERE ledger_account.wallet_id = wallet.id
+		\) AS "balance.ledger"
+		FROM public.wallet `
+
+	tests := map[string]struct {
+		filter domain.ReconcileFilter
+		err    error
+		mocks  func(m sqlmock.Sqlmock)
+		result []domain.BalanceReport
+	}{
+		"all wallets": {
+			filter: domain.ReconcileFilter{AfterID: 10, Limit: 2},
+			mocks: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns).
+					AddRow(11, account, 100, "usd", 100, 100).
+					AddRow(12, account, 50, "eur", 40, 50)
+				m.ExpectQuery(query+`WHERE wallet.id > \$7 ORDER BY wallet.id ASC LIMIT \$8;`).
+					WithArgs(0, 0, "opening_balance", 1, "opening_balance", 0, 10, 2).
+					WillReturnRows(rows)
+			},
+			result: []domain.BalanceReport{
+				{WalletID: 11, Account: account, Currency: "usd", Balance: 100, HistoryBalance: 100, LedgerBalance: 100},
+				{WalletID: 12, Account: account, Currency: "eur", Balance: 50, HistoryBalance: 40, LedgerBalance: 50},
+			},
+		},
+		"account": {
+			filter: domain.ReconcileFilter{Account: account, Limit: 2},
+			mocks: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(query+`WHERE \(wallet.id > \$7\) AND \(wallet.account = \$8\) ORDER BY wallet.id ASC LIMIT \$9;`).
+					WithArgs(0, 0, "opening_balance", 1, "opening_balance", 0, 0, account, 2).
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+			result: []domain.BalanceReport{},
+		},
+		"error": {
+			filter: domain.ReconcileFilter{Limit: 2},
+			err:    sql.ErrConnDone,
+			mocks: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(query).WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			db, mock := newMock()
+			repo := repository.NewWalletRepo(db)
+			defer func() {
+				repo.Close()
+			}()
+			tt.mocks(mock)
+
+			result, err := repo.RecomputeBalances(ctx, tt.filter)
+			if tt.err != nil {
+				assert.ErrorContains(t, err, tt.err.Error())
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.NilError(t, mock.ExpectationsWereMet())
+			assert.DeepEqual(t, result, tt.result)
+		})
+	}
+}
+
+// TestRecomputeBalancesLegacyWallet checks wallet funded before the ledger, its balance was moved
+// to opening balance entry and its older transactions have no recorded amounts
+func TestRecomputeBalancesLegacyWallet(t *testing.T) {
+	db := openTestDB(t)
+	repo := repository.NewWalletRepo(db)
+	defer func() {
+		repo.Close()
+	}()
+
+	ctx := context.Background()
+	account := uuid.New()
+	var walletID int
+	// state left by migrations of wallet funded with 500 before them
+	err := db.QueryRowContext(ctx, `INSERT INTO wallet (account, amount, currency) VALUES ($1, 500, 'usd') RETURNING id`, account).Scan(&walletID)
+	assert.NilError(t, err)
+	_, err = db.ExecContext(ctx, `INSERT INTO transaction (wallet_id, transaction_id, currency, created_at) VALUES ($1, $2, 'usd', NOW() - INTERVAL '1 hour')`, walletID, uuid.New())
+	assert.NilError(t, err)
+	_, err = db.ExecContext(ctx, `
+		WITH account AS (
+			INSERT INTO ledger_account (wallet_id, currency, balance) VALUES ($1, 'usd', 500) RETURNING id
+		), entry AS (
+			INSERT INTO journal_entry (transaction_id, kind) VALUES (gen_random_uuid(), 'opening_balance') RETURNING id
+		)
+		INSERT INTO posting (journal_entry_id, ledger_account_id, amount)
+		SELECT entry.id, account.id, 500 FROM entry, account
+		UNION ALL
+		SELECT entry.id, ledger_account.id, -500 FROM entry, ledger_account WHERE code = 'opening_balance' AND currency = 'usd'`, walletID)
+	assert.NilError(t, err)
+
+	_, err = repo.ProcessTransaction(ctx, domain.Transaction{ID: uuid.New(), WalletID: walletID, Amount: 100, Currency: "usd"})
+	assert.NilError(t, err)
+
+	reports, err := repo.RecomputeBalances(ctx, domain.ReconcileFilter{Account: account, Limit: 10})
+	assert.NilError(t, err)
+	assert.Equal(t, len(reports), 1)
+	assert.Assert(t, !reports[0].HasDrift(), "%+v", reports[0])
+	assert.Equal(t, reports[0].HistoryBalance, domain.Amount(600))
+}
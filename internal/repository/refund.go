@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	pg "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/ximura/gowallet/internal/core/domain"
+	"github.com/ximura/gowallet/internal/repository/jet/model"
+)
+
+func (r *WalletRepo) Refund(ctx context.Context, refund domain.Refund) (domain.Transaction, domain.Wallet, error) {
+	var reversal domain.Transaction
+	var w domain.Wallet
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		reversal, w, err = r.refund(ctx, tx, refund)
+		return err
+	})
+	if err != nil {
+		return domain.Transaction{}, domain.Wallet{}, err
+	}
+
+	return reversal, w, nil
+}
+
+func (r *WalletRepo) refund(ctx context.Context, tx *sql.Tx, refund domain.Refund) (domain.Transaction, domain.Wallet, error) {
+	// original row lock serializes concurrent refunds of the same transaction
+	query := r.transaction.SELECT(r.transactionProjection()).
+		WHERE(r.transaction.WalletID.EQ(pg.Int(int64(refund.WalletID))).
+			AND(r.transaction.TransactionID.EQ(pg.UUID(refund.TransactionID)))).
+		FOR(pg.UPDATE())
+
+	var original model.Transaction
+	if err := query.QueryContext(ctx, tx, &original); err != nil {
+		return domain.Transaction{}, domain.Wallet{}, fmt.Errorf("can't get transaction %s: %w", refund.TransactionID, notFound(err, domain.ErrTransactionNotFound))
+	}
+
+	refunded, err := r.refundedAmount(ctx, tx, refund)
+	if err != nil {
+		return domain.Transaction{}, domain.Wallet{}, err
+	}
+
+	reversal, err := refund.Reversal(convertTransaction(original), refunded)
+	if err != nil {
+		return domain.Transaction{}, domain.Wallet{}, err
+	}
+
+	w, err := r.applyTransaction(ctx, tx, reversal)
+	if err != nil {
+		return domain.Transaction{}, domain.Wallet{}, err
+	}
+	reversal.Balance = w.Amount
+
+	return reversal, w, nil
+}
+
+// refundedAmount returns amount already returned by refunds of the original transaction
+func (r *WalletRepo) refundedAmount(ctx context.Context, db qrm.Queryable, refund domain.Refund) (domain.Amount, error) {
+	query := r.transaction.SELECT(pg.COALESCE(pg.SUM(pg.ABSi(r.transaction.Amount)), pg.Int(0)).AS("refunded")).
+		WHERE(r.transaction.WalletID.EQ(pg.Int(int64(refund.WalletID))).
+			AND(r.transaction.ReversalOf.EQ(pg.UUID(refund.TransactionID))))
+
+	var result struct {
+		Refunded domain.Amount
+	}
+	if err := query.QueryContext(ctx, db, &result); err != nil {
+		return 0, err
+	}
+
+	return result.Refunded, nil
+}
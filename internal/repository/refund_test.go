@@ -0,0 +1,109 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/ximura/gowallet/internal/core/domain"
+	"github.com/ximura/gowallet/internal/repository"
+	"gotest.tools/v3/assert"
+)
+
+func TestRefund(t *testing.T) {
+	ctx := context.Background()
+	account := uuid.New()
+	refund := domain.Refund{
+		ID:            uuid.New(),
+		WalletID:      1,
+		TransactionID: uuid.New(),
+		Amount:        40,
+	}
+	lockQuery := selectTransactionQuery + `WHERE \(transaction.wallet_id = \$1\) AND \(transaction.transaction_id = \$2\) FOR UPDATE;`
+	refundedQuery := `SELECT COALESCE\(SUM\(ABS\(transaction.amount\)\), \$1\) AS "refunded"
+		FROM public.transaction
+		WHERE \(transaction.wallet_id = \$2\) AND \(transaction.reversal_of = \$3\);`
+	originalRows := func(amount int) *sqlmock.Rows {
+		return sqlmock.NewRows(transactionColumns).
+			AddRow(refund.WalletID, refund.TransactionID, amount, "usd", amount, nil, nil, time.Now())
+	}
+
+	tests := map[string]struct {
+		err   error
+		mocks func(m sqlmock.Sqlmock)
+	}{
+		"not found": {
+			err: domain.ErrTransactionNotFound,
+			mocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockQuery).WithArgs(refund.WalletID, refund.TransactionID).
+					WillReturnRows(sqlmock.NewRows(transactionColumns))
+				mock.ExpectRollback()
+			},
+		},
+		"ErrRefundExceedsOriginal": {
+			err: domain.ErrRefundExceedsOriginal,
+			mocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockQuery).WithArgs(refund.WalletID, refund.TransactionID).
+					WillReturnRows(originalRows(100))
+				mock.ExpectQuery(refundedQuery).WithArgs(0, refund.WalletID, refund.TransactionID).
+					WillReturnRows(sqlmock.NewRows([]string{"refunded"}).AddRow(70))
+				mock.ExpectRollback()
+			},
+		},
+		"Ok": {
+			mocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockQuery).WithArgs(refund.WalletID, refund.TransactionID).
+					WillReturnRows(originalRows(100))
+				mock.ExpectQuery(refundedQuery).WithArgs(0, refund.WalletID, refund.TransactionID).
+					WillReturnRows(sqlmock.NewRows([]string{"refunded"}).AddRow(60))
+				expectLockWallet(mock, refund.WalletID, "usd", 100, 0)
+				expectClaimKey(mock, refund.ID, false)
+
+				rows := sqlmock.NewRows([]string{"wallet.id", "wallet.account", "wallet.amount", "wallet.currency", "wallet.held"}).
+					AddRow(refund.WalletID, account, 60, "usd", 0)
+				mock.ExpectQuery(updateWalletQuery).WithArgs(-refund.Amount, refund.WalletID, "usd", 0, 0, domain.HoldActive).WillReturnRows(rows)
+				mock.ExpectExec(insertTransactionQuery).
+					WithArgs(refund.WalletID, refund.ID, -refund.Amount, "usd", 60, nil, refund.TransactionID, nil, nil, nil, nil, nil).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				expectJournalEntry(mock, refund.ID, domain.EntryReversal, 1)
+				expectWalletPosting(mock, refund.WalletID, -refund.Amount, 1, 60)
+				expectSystemAccount(mock, domain.ExternalFunding, "usd", 2)
+				mock.ExpectExec(postingQuery).WithArgs(1, 1, -refund.Amount, 1, 2, refund.Amount).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			db, mock := newMock()
+			repo := repository.NewWalletRepo(db)
+			defer func() {
+				repo.Close()
+			}()
+
+			mock.MatchExpectationsInOrder(true)
+			tt.mocks(mock)
+
+			reversal, w, err := repo.Refund(ctx, refund)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, reversal.ReversalOf, refund.TransactionID)
+			assert.Equal(t, reversal.Amount, -refund.Amount)
+			assert.Equal(t, reversal.Balance, domain.Amount(60))
+			assert.Equal(t, w.Amount, domain.Amount(60))
+			assert.NilError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	pg "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/ximura/gowallet/internal/core/domain"
+)
+
+// ChangeStatus moves wallet and its pockets to status of the change and records who changed it
+func (r *WalletRepo) ChangeStatus(ctx context.Context, change domain.StatusChange) (domain.Wallet, error) {
+	var result domain.Wallet
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		wallet, err := r.lockWalletWithPockets(ctx, tx, change.WalletID)
+		if err != nil {
+			return err
+		}
+		if wallet.IsPocket() {
+			return fmt.Errorf("%w: pocket %d shares status of wallet %d", domain.ErrInvalidStatusChange, wallet.ID, wallet.ParentID)
+		}
+		// balances are locked, so wallet can't get funds after it was checked to be empty
+		if err := wallet.CanChangeStatus(change); err != nil {
+			return err
+		}
+
+		id := pg.Int(int64(wallet.ID))
+		update := r.wallet.UPDATE(r.wallet.Status, r.wallet.BlockCredits).
+			SET(change.Status, change.BlockCredits).
+			WHERE(r.wallet.ID.EQ(id).OR(r.wallet.ParentID.EQ(id)))
+		if _, err := update.ExecContext(ctx, tx); err != nil {
+			return err
+		}
+
+		insert := r.statusChange.INSERT(
+			r.statusChange.WalletID,
+			r.statusChange.Status,
+			r.statusChange.BlockCredits,
+			r.statusChange.Actor,
+			r.statusChange.Reason,
+		).VALUES(wallet.ID, change.Status, change.BlockCredits, change.Actor, change.Reason)
+		if _, err := insert.ExecContext(ctx, tx); err != nil {
+			return err
+		}
+
+		// versions are incremented by trigger, so wallet is read again to return them
+		result, err = r.lockWalletWithPockets(ctx, tx, wallet.ID)
+		return err
+	})
+	if err != nil {
+		return domain.Wallet{}, err
+	}
+
+	return result, nil
+}
+
+// lockWalletWithPockets reads wallet with its pockets and locks them until the end of transaction
+func (r *WalletRepo) lockWalletWithPockets(ctx context.Context, db qrm.Queryable, id int) (domain.Wallet, error) {
+	query := r.wallet.SELECT(r.walletProjection()).
+		WHERE(r.wallet.ID.EQ(pg.Int(int64(id))).
+			OR(r.wallet.ParentID.EQ(pg.Int(int64(id))))).
+		ORDER_BY(r.wallet.ID).
+		FOR(pg.UPDATE().OF(r.wallet))
+
+	var rows []domain.Wallet
+	if err := query.QueryContext(ctx, db, &rows); err != nil {
+		return domain.Wallet{}, fmt.Errorf("can't get wallet %d: %w", id, err)
+	}
+
+	for _, w := range withPockets(rows) {
+		if w.ID == id {
+			return w, nil
+		}
+	}
+
+	return domain.Wallet{}, fmt.Errorf("can't get wallet %d: %w", id, domain.ErrWalletNotFound)
+}
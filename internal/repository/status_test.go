@@ -0,0 +1,123 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/ximura/gowallet/internal/core/domain"
+	"github.com/ximura/gowallet/internal/repository"
+	"gotest.tools/v3/assert"
+)
+
+func TestChangeStatus(t *testing.T) {
+	ctx := context.Background()
+	account := uuid.New()
+	columns := []string{"wallet.id", "wallet.account", "wallet.amount", "wallet.currency", "wallet.parent_id", "wallet.multi_currency",
+		"wallet.status", "wallet.block_credits", "wallet.held"}
+	// wallet is read again after the update with versions incremented by trigger
+	reloadColumns := append(columns, "wallet.version")
+	lockQuery := `SELECT ` + walletColumns(1) + ` FROM public.wallet WHERE \(wallet.id = \$4\) OR \(wallet.parent_id = \$5\) ORDER BY wallet.id FOR UPDATE OF wallet;`
+	updateQuery := `UPDATE public.wallet SET \(status, block_credits\) = \(\$1, \$2\) WHERE \(wallet.id = \$3\) OR \(wallet.parent_id = \$4\);`
+	auditQuery := `INSERT INTO public.wallet_status_change \(wallet_id, status, block_credits, actor, reason\) VALUES \(\$1, \$2, \$3, \$4, \$5\);`
+	freeze := domain.StatusChange{WalletID: 1, Status: domain.WalletFrozen, BlockCredits: true, Actor: "support", Reason: "compromised"}
+	closing := domain.StatusChange{WalletID: 1, Status: domain.WalletClosed, Actor: "support", Reason: "account closed"}
+
+	tests := map[string]struct {
+		change  domain.StatusChange
+		err     error
+		version int64
+		mocks   func(m sqlmock.Sqlmock)
+	}{
+		"freeze": {
+			change:  freeze,
+			version: 4,
+			mocks: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectQuery(lockQuery).WithArgs(0, 0, domain.HoldActive, 1, 1).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(1, account, 100, "usd", nil, false, domain.WalletActive, false, 0))
+				m.ExpectExec(updateQuery).WithArgs(domain.WalletFrozen, true, 1, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectExec(auditQuery).WithArgs(1, domain.WalletFrozen, true, "support", "compromised").WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectQuery(lockQuery).WithArgs(0, 0, domain.HoldActive, 1, 1).
+					WillReturnRows(sqlmock.NewRows(reloadColumns).AddRow(1, account, 100, "usd", nil, false, domain.WalletFrozen, true, 0, 4))
+				m.ExpectCommit()
+			},
+		},
+		"close": {
+			change:  closing,
+			version: 9,
+			mocks: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectQuery(lockQuery).WithArgs(0, 0, domain.HoldActive, 1, 1).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(1, account, 0, "usd", nil, true, domain.WalletFrozen, false, 0).
+						AddRow(2, account, 0, "eur", 1, false, domain.WalletFrozen, false, 0))
+				m.ExpectExec(updateQuery).WithArgs(domain.WalletClosed, false, 1, 1).WillReturnResult(sqlmock.NewResult(0, 2))
+				m.ExpectExec(auditQuery).WithArgs(1, domain.WalletClosed, false, "support", "account closed").WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectQuery(lockQuery).WithArgs(0, 0, domain.HoldActive, 1, 1).
+					WillReturnRows(sqlmock.NewRows(reloadColumns).
+						AddRow(1, account, 0, "usd", nil, true, domain.WalletClosed, false, 0, 9).
+						AddRow(2, account, 0, "eur", 1, false, domain.WalletClosed, false, 0, 3))
+				m.ExpectCommit()
+			},
+		},
+		"ErrWalletNotEmpty pocket": {
+			change: closing,
+			err:    domain.ErrWalletNotEmpty,
+			mocks: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectQuery(lockQuery).WithArgs(0, 0, domain.HoldActive, 1, 1).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(1, account, 0, "usd", nil, true, domain.WalletActive, false, 0).
+						AddRow(2, account, 0, "eur", 1, false, domain.WalletActive, false, 10))
+				m.ExpectRollback()
+			},
+		},
+		"ErrInvalidStatusChange pocket": {
+			change: freeze,
+			err:    domain.ErrInvalidStatusChange,
+			mocks: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectQuery(lockQuery).WithArgs(0, 0, domain.HoldActive, 1, 1).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(1, account, 0, "eur", 3, false, domain.WalletActive, false, 0))
+				m.ExpectRollback()
+			},
+		},
+		"ErrWalletNotFound": {
+			change: freeze,
+			err:    domain.ErrWalletNotFound,
+			mocks: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectQuery(lockQuery).WithArgs(0, 0, domain.HoldActive, 1, 1).WillReturnRows(sqlmock.NewRows(columns))
+				m.ExpectRollback()
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			db, mock := newMock()
+			repo := repository.NewWalletRepo(db)
+			defer func() {
+				repo.Close()
+			}()
+			tt.mocks(mock)
+
+			result, err := repo.ChangeStatus(ctx, tt.change)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				assert.NilError(t, mock.ExpectationsWereMet())
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.NilError(t, mock.ExpectationsWereMet())
+			assert.Equal(t, result.ID, tt.change.WalletID)
+			assert.Equal(t, result.Status, tt.change.Status)
+			assert.Equal(t, result.BlockCredits, tt.change.BlockCredits)
+			assert.Equal(t, result.Version, tt.version)
+		})
+	}
+}
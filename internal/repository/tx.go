@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"time"
+
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/lib/pq"
+	"github.com/ximura/gowallet/internal/core/domain"
+)
+
+// maxTxAttempts limits how many times transaction is run when it's aborted by concurrent update
+const maxTxAttempts = 10
+
+// txRetryDelay is base delay before transaction is run again, it grows with every attempt
+const txRetryDelay = 5 * time.Millisecond
+
+const (
+	serializationFailure = "40001"
+	deadlockDetected     = "40P01"
+	uniqueViolation      = "23505"
+)
+
+// inTx runs fn in repeatable read transaction and commits it,
+// transaction is run again from the start when it's aborted by concurrent update or deadlock.
+func (r *WalletRepo) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	var err error
+	for attempt := 1; attempt <= maxTxAttempts; attempt++ {
+		err = r.runTx(ctx, fn)
+		if !isRetryable(err) {
+			return err
+		}
+		if attempt == maxTxAttempts {
+			break
+		}
+
+		// jitter spreads retries of transactions that conflicted on the same rows
+		backoff := time.Duration(attempt) * txRetryDelay
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff/2 + rand.N(backoff/2)):
+		}
+	}
+
+	// cause is only logged, database error text isn't meant for clients
+	log.Println(fmt.Errorf("transaction aborted after %d attempts %w", maxTxAttempts, err))
+	return domain.ErrConcurrentUpdate
+}
+
+func (r *WalletRepo) runTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func isRetryable(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && (pqErr.Code == serializationFailure || pqErr.Code == deadlockDetected)
+}
+
+func isUniqueViolation(err error, constraint string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolation && pqErr.Constraint == constraint
+}
+
+// notFound replaces missing row error with domain error of the entity that was looked up
+func notFound(err error, domainErr error) error {
+	if errors.Is(err, qrm.ErrNoRows) {
+		return domainErr
+	}
+	return err
+}
@@ -3,6 +3,11 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
 
 	pg "github.com/go-jet/jet/v2/postgres"
 	"github.com/go-jet/jet/v2/qrm"
@@ -10,22 +15,49 @@ import (
 	_ "github.com/lib/pq"
 	"github.com/ximura/gowallet/internal/core/domain"
 	"github.com/ximura/gowallet/internal/core/ports"
+	"github.com/ximura/gowallet/internal/repository/jet/model"
 	"github.com/ximura/gowallet/internal/repository/jet/table"
 )
 
 var _ ports.WalletRepository = (*WalletRepo)(nil)
 
+const (
+	transactionPrimaryKey    = "transaction_pkey"
+	idempotencyKeyPrimaryKey = "idempotency_key_pkey"
+	holdPrimaryKey           = "hold_pkey"
+)
+
 type WalletRepo struct {
-	db          *sql.DB
-	wallet      table.WalletTable
-	transaction table.TransactionTable
+	db            *sql.DB
+	wallet        table.WalletTable
+	transaction   table.TransactionTable
+	ledgerAccount table.LedgerAccountTable
+	journalEntry  table.JournalEntryTable
+	posting       table.PostingTable
+	hold          table.HoldTable
+	currency      table.CurrencyTable
+	fxQuote       table.FxQuoteTable
+	statusChange  table.WalletStatusChangeTable
+	keys          table.IdempotencyKeyTable
+
+	// ids of system ledger accounts, keyed by code and currency
+	systemAccounts *sync.Map
 }
 
 func NewWalletRepo(db *sql.DB) WalletRepo {
 	return WalletRepo{
-		db:          db,
-		wallet:      *table.Wallet,
-		transaction: *table.Transaction,
+		db:             db,
+		wallet:         *table.Wallet,
+		transaction:    *table.Transaction,
+		ledgerAccount:  *table.LedgerAccount,
+		journalEntry:   *table.JournalEntry,
+		posting:        *table.Posting,
+		hold:           *table.Hold,
+		currency:       *table.Currency,
+		fxQuote:        *table.FxQuote,
+		statusChange:   *table.WalletStatusChange,
+		keys:           *table.IdempotencyKey,
+		systemAccounts: &sync.Map{},
 	}
 }
 
@@ -34,88 +66,495 @@ func (r *WalletRepo) Close() error {
 }
 
 func (r *WalletRepo) Create(ctx context.Context, account uuid.UUID, currency domain.Currency) (domain.Wallet, error) {
+	return r.create(ctx, domain.Wallet{Account: account, Currency: currency, Status: domain.WalletActive})
+}
+
+func (r *WalletRepo) CreateMultiCurrency(ctx context.Context, account uuid.UUID, currency domain.Currency) (domain.Wallet, error) {
+	return r.create(ctx, domain.Wallet{Account: account, Currency: currency, MultiCurrency: true, Status: domain.WalletActive})
+}
+
+func (r *WalletRepo) create(ctx context.Context, wallet domain.Wallet) (domain.Wallet, error) {
+	// wallet currency is compared with currency of every transaction, so it's stored only in canonical form
+	if !wallet.Currency.Valid() {
+		return domain.Wallet{}, fmt.Errorf("%w, got %q", domain.ErrInvalidCurrency, wallet.Currency)
+	}
+
+	var result domain.Wallet
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		result, err = r.insertWallet(ctx, tx, wallet)
+		return err
+	})
+	if err != nil {
+		return domain.Wallet{}, err
+	}
+
+	return result, nil
+}
+
+// createPocket returns pocket of locked parent in currency, pocket is created when parent doesn't have it yet
+func (r *WalletRepo) createPocket(ctx context.Context, db qrm.DB, parent domain.Wallet, currency domain.Currency) (domain.Wallet, error) {
+	if !parent.MultiCurrency {
+		return domain.Wallet{}, fmt.Errorf("%w: wallet %d holds only %s", domain.ErrCurrencyMismatch, parent.ID, parent.Currency)
+	}
+	if parent.IsClosed() {
+		return domain.Wallet{}, fmt.Errorf("%w: wallet %d", domain.ErrWalletClosed, parent.ID)
+	}
+
+	var result domain.Wallet
+	query := r.wallet.SELECT(r.walletProjection()).
+		WHERE(r.wallet.ParentID.EQ(pg.Int(int64(parent.ID))).
+			AND(r.wallet.Currency.EQ(pg.String(string(currency)))))
+	err := query.QueryContext(ctx, db, &result)
+	if !errors.Is(err, qrm.ErrNoRows) {
+		return result, err
+	}
+
+	// pocket shares status of its wallet
+	return r.insertWallet(ctx, db, domain.Wallet{
+		Account:      parent.Account,
+		Currency:     currency,
+		ParentID:     parent.ID,
+		Status:       parent.Status,
+		BlockCredits: parent.BlockCredits,
+	})
+}
+
+// insertWallet creates wallet with its ledger account
+func (r *WalletRepo) insertWallet(ctx context.Context, db qrm.DB, wallet domain.Wallet) (domain.Wallet, error) {
+	var parentID *int
+	if wallet.IsPocket() {
+		parentID = &wallet.ParentID
+	}
+
 	query := r.wallet.INSERT(
 		r.wallet.Account,
 		r.wallet.Currency,
-	).VALUES(account, currency).
+		r.wallet.MultiCurrency,
+		r.wallet.ParentID,
+		r.wallet.Status,
+		r.wallet.BlockCredits,
+	).VALUES(wallet.Account, wallet.Currency, wallet.MultiCurrency, parentID, wallet.Status, wallet.BlockCredits).
 		RETURNING(r.wallet.AllColumns.Except(r.wallet.CreatedAt, r.wallet.UpdatedAt))
 
 	var result domain.Wallet
-	if err := query.QueryContext(ctx, r.db, &result); err != nil {
+	if err := query.QueryContext(ctx, db, &result); err != nil {
+		return domain.Wallet{}, err
+	}
+
+	ledgerQuery := r.ledgerAccount.INSERT(r.ledgerAccount.WalletID, r.ledgerAccount.Currency).
+		VALUES(result.ID, result.Currency)
+	if _, err := ledgerQuery.ExecContext(ctx, db); err != nil {
 		return domain.Wallet{}, err
 	}
 
 	return result, nil
 }
 
-func (r *WalletRepo) List(ctx context.Context, account uuid.UUID) ([]domain.Wallet, error) {
-	query := r.wallet.SELECT(
-		r.wallet.AllColumns.Except(r.wallet.CreatedAt, r.wallet.UpdatedAt)).
-		WHERE(r.wallet.Account.EQ(pg.UUID(account)))
+func (r *WalletRepo) List(ctx context.Context, filter domain.WalletFilter) ([]domain.Wallet, error) {
+	// pockets are listed with their wallets, so pages are built of wallets only
+	condition := r.wallet.Account.EQ(pg.UUID(filter.Account)).
+		AND(r.wallet.ParentID.IS_NULL())
+	if filter.Currency != "" {
+		currency := pg.String(string(filter.Currency))
+		pocket := r.wallet.AS("pocket")
+		pockets := pocket.SELECT(pocket.ParentID).
+			WHERE(pocket.Account.EQ(pg.UUID(filter.Account)).AND(pocket.Currency.EQ(currency)))
+		condition = condition.AND(r.wallet.Currency.EQ(currency).OR(r.wallet.ID.IN(pockets)))
+	}
+
+	var orderBy []pg.OrderByClause
+	switch filter.Order {
+	case domain.OrderNewest:
+		orderBy = []pg.OrderByClause{r.wallet.ID.DESC()}
+	case domain.OrderCurrency:
+		orderBy = []pg.OrderByClause{r.wallet.Currency.ASC(), r.wallet.ID.ASC()}
+	default:
+		orderBy = []pg.OrderByClause{r.wallet.ID.ASC()}
+	}
+	if filter.After != nil {
+		condition = condition.AND(r.walletsAfter(filter.Order, *filter.After))
+	}
+
+	query := r.wallet.SELECT(r.walletProjection()).
+		WHERE(condition).
+		ORDER_BY(orderBy...).
+		LIMIT(int64(filter.Limit))
 
-	result := make([]domain.Wallet, 0, 1)
-	if err := query.QueryContext(ctx, r.db, &result); err != nil {
+	rows := make([]domain.Wallet, 0, filter.Limit)
+	if err := query.QueryContext(ctx, r.db, &rows); err != nil {
 		return nil, err
 	}
 
-	return result, nil
+	var parents []pg.Expression
+	for _, w := range rows {
+		if w.MultiCurrency {
+			parents = append(parents, pg.Int(int64(w.ID)))
+		}
+	}
+	if len(parents) > 0 {
+		pocketsQuery := r.wallet.SELECT(r.walletProjection()).
+			WHERE(r.wallet.ParentID.IN(parents...))
+
+		var pockets []domain.Wallet
+		if err := pocketsQuery.QueryContext(ctx, r.db, &pockets); err != nil {
+			return nil, err
+		}
+		rows = append(rows, pockets...)
+	}
+
+	return withPockets(rows), nil
+}
+
+// walletsAfter returns condition that selects wallets following cursor in order
+func (r *WalletRepo) walletsAfter(order domain.WalletOrder, cursor domain.WalletCursor) pg.BoolExpression {
+	id := pg.Int(int64(cursor.ID))
+	switch order {
+	case domain.OrderNewest:
+		return r.wallet.ID.LT(id)
+	case domain.OrderCurrency:
+		currency := pg.String(string(cursor.Currency))
+		return r.wallet.Currency.GT(currency).
+			OR(r.wallet.Currency.EQ(currency).AND(r.wallet.ID.GT(id)))
+	}
+
+	return r.wallet.ID.GT(id)
 }
 
 func (r *WalletRepo) Get(ctx context.Context, id int) (domain.Wallet, error) {
-	query := r.wallet.SELECT(
-		r.wallet.AllColumns.Except(r.wallet.CreatedAt, r.wallet.UpdatedAt)).
-		WHERE(r.wallet.ID.EQ(pg.Int(int64(id))))
+	query := r.wallet.SELECT(r.walletProjection()).
+		WHERE(r.wallet.ID.EQ(pg.Int(int64(id))).
+			OR(r.wallet.ParentID.EQ(pg.Int(int64(id)))))
 
-	var result domain.Wallet
-	if err := query.QueryContext(ctx, r.db, &result); err != nil {
+	var rows []domain.Wallet
+	if err := query.QueryContext(ctx, r.db, &rows); err != nil {
 		return domain.Wallet{}, err
 	}
 
-	return result, nil
+	for _, w := range withPockets(rows) {
+		if w.ID == id {
+			return w, nil
+		}
+	}
+
+	return domain.Wallet{}, domain.ErrWalletNotFound
 }
 
-func (r *WalletRepo) HasTransaction(ctx context.Context, transaction domain.Transaction) (bool, error) {
-	query := r.transaction.SELECT(pg.COUNT(r.transaction.WalletID).AS("count")).
-		WHERE(r.transaction.WalletID.EQ(pg.Int(int64(transaction.WalletID))).
-			AND(r.transaction.TransactionID.EQ(pg.UUID(transaction.ID))))
+// withPockets attaches pockets to their multi-currency wallets,
+// pockets which parent is not in rows are returned as wallets.
+func withPockets(rows []domain.Wallet) []domain.Wallet {
+	result := make([]domain.Wallet, 0, len(rows))
+	parents := make(map[int]int, len(rows))
+	for _, w := range rows {
+		if !w.IsPocket() {
+			parents[w.ID] = len(result)
+			result = append(result, w)
+		}
+	}
 
-	var result struct {
-		Count int
+	for _, w := range rows {
+		if !w.IsPocket() {
+			continue
+		}
+		if i, ok := parents[w.ParentID]; ok {
+			result[i].Pockets = append(result[i].Pockets, w.AsPocket())
+			continue
+		}
+		result = append(result, w)
 	}
-	if err := query.QueryContext(ctx, r.db, &result); err != nil {
-		return false, err
+
+	for i := range result {
+		slices.SortFunc(result[i].Pockets, func(a, b domain.Pocket) int {
+			return strings.Compare(string(a.Currency), string(b.Currency))
+		})
 	}
 
-	return result.Count > 0, nil
+	return result
+}
+
+func (r *WalletRepo) FindTransactions(ctx context.Context, id uuid.UUID) ([]domain.Transaction, error) {
+	query := r.transaction.SELECT(r.transactionProjection()).
+		WHERE(r.transaction.TransactionID.EQ(pg.UUID(id))).
+		ORDER_BY(r.transaction.WalletID.ASC())
+
+	var rows []model.Transaction
+	if err := query.QueryContext(ctx, r.db, &rows); err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.Transaction, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, convertTransaction(row))
+	}
+
+	return result, nil
 }
 
 func (r *WalletRepo) ProcessTransaction(ctx context.Context, transaction domain.Transaction) (domain.Wallet, error) {
-	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead})
+	var result domain.Wallet
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		w, err := r.applyTransaction(ctx, tx, transaction)
+		result = w
+		return err
+	})
 	if err != nil {
 		return domain.Wallet{}, err
 	}
-	defer tx.Rollback()
 
-	if err := r.createTransaction(ctx, tx, transaction); err != nil {
+	return result, nil
+}
+
+// applyTransaction checks transaction against locked wallet, updates wallet balance,
+// records transaction and its journal entry
+func (r *WalletRepo) applyTransaction(ctx context.Context, db qrm.DB, transaction domain.Transaction) (domain.Wallet, error) {
+	wallet, err := r.lockWallet(ctx, db, transaction.WalletID)
+	if err != nil {
 		return domain.Wallet{}, err
 	}
 
-	w, err := r.updateWallet(ctx, tx, transaction)
+	wallet, err = r.creditTarget(ctx, db, wallet, transaction)
 	if err != nil {
 		return domain.Wallet{}, err
 	}
+	transaction.WalletID = wallet.ID
 
-	if err := tx.Commit(); err != nil {
+	if err := r.claimKey(ctx, db, transaction.ID); err != nil {
 		return domain.Wallet{}, err
 	}
-	return w, nil
+
+	if err := wallet.CanApply(transaction); err != nil {
+		return domain.Wallet{}, err
+	}
+
+	_, w, err := r.recordTransaction(ctx, db, transaction)
+	return w, err
+}
+
+// creditTarget returns wallet credit to locked wallet is applied to. Pocket of multi-currency wallet
+// is created under lock of the wallet in transaction that applies the credit, so it's rolled back
+// with the credit that was rejected.
+func (r *WalletRepo) creditTarget(ctx context.Context, db qrm.DB, wallet domain.Wallet, credit domain.Transaction) (domain.Wallet, error) {
+	if !wallet.MultiCurrency || wallet.Currency == credit.Currency || credit.Amount <= 0 {
+		return wallet, nil
+	}
+
+	pocket, err := r.createPocket(ctx, db, wallet, credit.Currency)
+	if err != nil {
+		return domain.Wallet{}, fmt.Errorf("can't create %s pocket of wallet %d: %w", credit.Currency, wallet.ID, err)
+	}
+
+	return pocket, nil
+}
+
+// recordTransaction updates balance of wallet that was checked and locked, records transaction
+// and its journal entry, returns transaction with balance after it and updated wallet
+func (r *WalletRepo) recordTransaction(ctx context.Context, db qrm.DB, transaction domain.Transaction) (domain.Transaction, domain.Wallet, error) {
+	w, err := r.updateWallet(ctx, db, transaction)
+	if err != nil {
+		return domain.Transaction{}, domain.Wallet{}, err
+	}
+
+	transaction.Balance = w.Amount
+	if err := r.createTransaction(ctx, db, transaction); err != nil {
+		return domain.Transaction{}, domain.Wallet{}, err
+	}
+
+	if err := r.postJournalEntry(ctx, db, domain.NewTransactionEntry(transaction), w); err != nil {
+		return domain.Transaction{}, domain.Wallet{}, err
+	}
+
+	return transaction, w, nil
+}
+
+func (r *WalletRepo) Transfer(ctx context.Context, transfer domain.Transfer) (domain.Wallet, domain.Wallet, error) {
+	var from, to domain.Wallet
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		from, to, err = r.transfer(ctx, tx, transfer)
+		return err
+	})
+	if err != nil {
+		return domain.Wallet{}, domain.Wallet{}, err
+	}
+
+	return from, to, nil
+}
+
+// transfer applies both legs of transfer and returns updated source and destination wallets
+func (r *WalletRepo) transfer(ctx context.Context, tx *sql.Tx, transfer domain.Transfer) (domain.Wallet, domain.Wallet, error) {
+	// lock wallets in the same order for all transfers to avoid deadlocks
+	ids := []int{transfer.FromWalletID, transfer.ToWalletID}
+	if ids[1] < ids[0] {
+		ids[0], ids[1] = ids[1], ids[0]
+	}
+
+	locked := make(map[int]domain.Wallet, len(ids))
+	for _, id := range ids {
+		wallet, err := r.lockWallet(ctx, tx, id)
+		if err != nil {
+			return domain.Wallet{}, domain.Wallet{}, err
+		}
+		locked[id] = wallet
+	}
+
+	// credit leg is applied to pocket of destination when it's created
+	_, credit := transfer.Transactions()
+	destination, err := r.creditTarget(ctx, tx, locked[transfer.ToWalletID], credit)
+	if err != nil {
+		return domain.Wallet{}, domain.Wallet{}, err
+	}
+	transfer.ToWalletID = destination.ID
+	locked[destination.ID] = destination
+
+	debit, credit := transfer.Transactions()
+	legs := []*domain.Transaction{&debit, &credit}
+	if credit.WalletID < debit.WalletID {
+		legs[0], legs[1] = legs[1], legs[0]
+	}
+
+	for _, leg := range legs {
+		if err := locked[leg.WalletID].CanApply(*leg); err != nil {
+			return domain.Wallet{}, domain.Wallet{}, err
+		}
+	}
+
+	if err := r.claimKey(ctx, tx, transfer.ID); err != nil {
+		return domain.Wallet{}, domain.Wallet{}, err
+	}
+
+	wallets := make(map[int]domain.Wallet, len(legs))
+	for _, leg := range legs {
+		w, err := r.updateWallet(ctx, tx, *leg)
+		if err != nil {
+			return domain.Wallet{}, domain.Wallet{}, err
+		}
+		leg.Balance = w.Amount
+		wallets[w.ID] = w
+	}
+
+	for _, leg := range legs {
+		if err := r.createTransaction(ctx, tx, *leg); err != nil {
+			return domain.Wallet{}, domain.Wallet{}, err
+		}
+	}
+
+	entry := domain.NewTransferEntry(transfer)
+	if err := r.postJournalEntry(ctx, tx, entry, wallets[transfer.FromWalletID], wallets[transfer.ToWalletID]); err != nil {
+		return domain.Wallet{}, domain.Wallet{}, err
+	}
+
+	return wallets[transfer.FromWalletID], wallets[transfer.ToWalletID], nil
+}
+
+func (r *WalletRepo) ListTransactions(ctx context.Context, filter domain.TransactionFilter) ([]domain.Transaction, error) {
+	// history of multi-currency wallet includes transactions of its pockets
+	wallets := r.wallet.SELECT(r.wallet.ID).
+		WHERE(r.wallet.ID.EQ(pg.Int(int64(filter.WalletID))).
+			OR(r.wallet.ParentID.EQ(pg.Int(int64(filter.WalletID)))))
+	condition := r.transaction.WalletID.IN(wallets)
+	if !filter.From.IsZero() {
+		condition = condition.AND(r.transaction.CreatedAt.GT_EQ(pg.TimestampzT(filter.From)))
+	}
+	if !filter.To.IsZero() {
+		condition = condition.AND(r.transaction.CreatedAt.LT(pg.TimestampzT(filter.To)))
+	}
+	switch filter.Sign {
+	case domain.SignCredit:
+		condition = condition.AND(r.transaction.Amount.GT(pg.Int(0)))
+	case domain.SignDebit:
+		condition = condition.AND(r.transaction.Amount.LT(pg.Int(0)))
+	}
+	if filter.After != nil {
+		createdAt := pg.TimestampzT(filter.After.CreatedAt)
+		condition = condition.AND(r.transaction.CreatedAt.LT(createdAt).
+			OR(r.transaction.CreatedAt.EQ(createdAt).AND(r.transaction.TransactionID.LT(pg.UUID(filter.After.ID)))))
+	}
+
+	query := r.transaction.SELECT(r.transactionProjection()).
+		WHERE(condition).
+		ORDER_BY(r.transaction.CreatedAt.DESC(), r.transaction.TransactionID.DESC()).
+		LIMIT(int64(filter.Limit))
+
+	var rows []model.Transaction
+	if err := query.QueryContext(ctx, r.db, &rows); err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.Transaction, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, convertTransaction(row))
+	}
+
+	return result, nil
 }
 
 func (r *WalletRepo) createTransaction(ctx context.Context, db qrm.Executable, transaction domain.Transaction) error {
-	query := r.transaction.INSERT(r.transaction.WalletID, r.transaction.TransactionID).
-		VALUES(transaction.WalletID, transaction.ID)
+	var counterparty *int
+	if transaction.CounterpartyWalletID != 0 {
+		counterparty = &transaction.CounterpartyWalletID
+	}
+	var reversalOf *uuid.UUID
+	if transaction.ReversalOf != uuid.Nil {
+		reversalOf = &transaction.ReversalOf
+	}
+	var fx model.Transaction
+	if c := transaction.Conversion; c.Applied() {
+		amount, currency, rate, spread := int64(c.FromAmount), string(c.FromCurrency), int64(c.Rate), int32(c.SpreadBps)
+		fx.FxAmount, fx.FxCurrency, fx.FxRate, fx.FxSpreadBps = &amount, &currency, &rate, &spread
+		if c.QuoteID != uuid.Nil {
+			fx.FxQuoteID = &c.QuoteID
+		}
+	}
+
+	query := r.transaction.INSERT(
+		r.transaction.WalletID,
+		r.transaction.TransactionID,
+		r.transaction.Amount,
+		r.transaction.Currency,
+		r.transaction.Balance,
+		r.transaction.CounterpartyWalletID,
+		r.transaction.ReversalOf,
+		r.transaction.FxAmount,
+		r.transaction.FxCurrency,
+		r.transaction.FxRate,
+		r.transaction.FxSpreadBps,
+		r.transaction.FxQuoteID,
+	).VALUES(transaction.WalletID, transaction.ID, transaction.Amount, transaction.Currency, transaction.Balance, counterparty, reversalOf,
+		fx.FxAmount, fx.FxCurrency, fx.FxRate, fx.FxSpreadBps, fx.FxQuoteID)
 
 	if _, err := query.ExecContext(ctx, db); err != nil {
+		if isUniqueViolation(err, transactionPrimaryKey) {
+			return domain.ErrDuplicateTransaction
+		}
+		return err
+	}
+
+	return nil
+}
+
+// lockWallet reads wallet and locks it until the end of transaction
+func (r *WalletRepo) lockWallet(ctx context.Context, db qrm.Queryable, id int) (domain.Wallet, error) {
+	query := r.wallet.SELECT(r.walletProjection()).
+		WHERE(r.wallet.ID.EQ(pg.Int(int64(id)))).
+		FOR(pg.UPDATE().OF(r.wallet))
+
+	var result domain.Wallet
+	if err := query.QueryContext(ctx, db, &result); err != nil {
+		return domain.Wallet{}, fmt.Errorf("can't get wallet %d: %w", id, notFound(err, domain.ErrWalletNotFound))
+	}
+
+	return result, nil
+}
+
+// claimKey records idempotency key, it returns domain.ErrDuplicateTransaction when key was already used.
+// Transaction that uses the same key concurrently waits for this one and fails when it commits.
+func (r *WalletRepo) claimKey(ctx context.Context, db qrm.Executable, id uuid.UUID) error {
+	query := r.keys.INSERT(r.keys.TransactionID).VALUES(id)
+
+	if _, err := query.ExecContext(ctx, db); err != nil {
+		if isUniqueViolation(err, idempotencyKeyPrimaryKey) {
+			return domain.ErrDuplicateTransaction
+		}
 		return err
 	}
 
@@ -127,7 +566,7 @@ func (r *WalletRepo) updateWallet(ctx context.Context, db qrm.Queryable, transac
 		SET(r.wallet.Amount.ADD(pg.Int(int64(transaction.Amount)))).
 		WHERE(r.wallet.ID.EQ(pg.Int(int64(transaction.WalletID))).
 			AND(r.wallet.Currency.EQ(pg.String(string(transaction.Currency))))).
-		RETURNING(r.wallet.AllColumns.Except(r.wallet.CreatedAt, r.wallet.UpdatedAt))
+		RETURNING(r.walletProjection())
 
 	var result domain.Wallet
 	if err := query.QueryContext(ctx, db, &result); err != nil {
@@ -136,3 +575,74 @@ func (r *WalletRepo) updateWallet(ctx context.Context, db qrm.Queryable, transac
 
 	return result, nil
 }
+
+// walletProjection selects wallet columns together with funds reserved by active holds
+func (r *WalletRepo) walletProjection() pg.ProjectionList {
+	held := pg.SELECT(pg.COALESCE(pg.SUM(r.hold.Amount), pg.Int(0))).
+		FROM(r.hold).
+		WHERE(r.hold.WalletID.EQ(r.wallet.ID).
+			AND(r.hold.Status.EQ(pg.String(string(domain.HoldActive)))).
+			AND(r.hold.ExpiresAt.GT(pg.NOW())))
+
+	// version of multi-currency wallet is sum of its and pockets versions, so it increases with change of any of them
+	pocket := r.wallet.AS("pocket")
+	pocketsVersion := pg.SELECT(pg.COALESCE(pg.SUM(pocket.Version), pg.Int(0))).
+		FROM(pocket).
+		WHERE(pocket.ParentID.EQ(r.wallet.ID))
+
+	return pg.ProjectionList{
+		r.wallet.AllColumns.Except(r.wallet.CreatedAt, r.wallet.UpdatedAt, r.wallet.Version),
+		r.wallet.Version.ADD(pg.IntExp(pocketsVersion)).AS("wallet.version"),
+		pg.IntExp(held).AS("wallet.held"),
+	}
+}
+
+func (r *WalletRepo) transactionProjection() pg.ProjectionList {
+	return pg.ProjectionList{
+		r.transaction.WalletID,
+		r.transaction.TransactionID,
+		r.transaction.Amount,
+		r.transaction.Currency,
+		r.transaction.Balance,
+		r.transaction.CounterpartyWalletID,
+		r.transaction.ReversalOf,
+		r.transaction.FxAmount,
+		r.transaction.FxCurrency,
+		r.transaction.FxRate,
+		r.transaction.FxSpreadBps,
+		r.transaction.FxQuoteID,
+		r.transaction.CreatedAt,
+	}
+}
+
+func convertTransaction(t model.Transaction) domain.Transaction {
+	result := domain.Transaction{
+		ID:        t.TransactionID,
+		WalletID:  int(t.WalletID),
+		Amount:    domain.Amount(t.Amount),
+		Currency:  domain.Currency(t.Currency),
+		Balance:   domain.Amount(t.Balance),
+		CreatedAt: t.CreatedAt,
+	}
+	if t.CounterpartyWalletID != nil {
+		result.CounterpartyWalletID = int(*t.CounterpartyWalletID)
+	}
+	if t.ReversalOf != nil {
+		result.ReversalOf = *t.ReversalOf
+	}
+	if t.FxAmount != nil && t.FxCurrency != nil && t.FxRate != nil && t.FxSpreadBps != nil {
+		result.Conversion = &domain.Conversion{
+			FromAmount:   domain.Amount(*t.FxAmount),
+			FromCurrency: domain.Currency(*t.FxCurrency),
+			ToAmount:     result.Amount,
+			ToCurrency:   result.Currency,
+			Rate:         domain.Rate(*t.FxRate),
+			SpreadBps:    int(*t.FxSpreadBps),
+		}
+		if t.FxQuoteID != nil {
+			result.Conversion.QuoteID = *t.FxQuoteID
+		}
+	}
+
+	return result
+}
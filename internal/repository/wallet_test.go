@@ -4,11 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"log"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/ximura/gowallet/internal/core/domain"
 	"github.com/ximura/gowallet/internal/repository"
 	"github.com/ximura/gowallet/internal/repository/jet/model"
@@ -24,6 +27,60 @@ func newMock() (*sql.DB, sqlmock.Sqlmock) {
 	return db, mock
 }
 
+// walletColumns is wallet projection with version including versions of pockets and funds reserved by holds,
+// placeholders of the projection are numbered starting from first
+func walletColumns(first int) string {
+	return fmt.Sprintf(`wallet.id AS "wallet.id", wallet.account AS "wallet.account", wallet.amount AS "wallet.amount", wallet.currency AS "wallet.currency",
+		wallet.parent_id AS "wallet.parent_id", wallet.multi_currency AS "wallet.multi_currency",
+		wallet.status AS "wallet.status", wallet.block_credits AS "wallet.block_credits",
+		\(wallet.version \+ \(
+			SELECT COALESCE\(SUM\(pocket.version\), \$%d\)
+			FROM public.wallet AS pocket
+			WHERE pocket.parent_id = wallet.id
+		\)\) AS "wallet.version",
+		\(
+			SELECT COALESCE\(SUM\(hold.amount\), \$%d\)
+			FROM public.hold
+			WHERE \(\(hold.wallet_id = wallet.id\) AND \(hold.status = \$%d::text\)\) AND \(hold.expires_at > NOW\(\)\)
+		\) AS "wallet.held"`, first, first+1, first+2)
+}
+
+var updateWalletQuery = `UPDATE public.wallet
+	SET amount = \(wallet.amount \+ \$1\)
+	WHERE \(wallet.id = \$2\) AND \(wallet.currency = \$3::text\)
+	RETURNING ` + walletColumns(4) + `;`
+
+var insertTransactionQuery = `INSERT INTO public.transaction \(wallet_id, transaction_id, amount, currency, balance, counterparty_wallet_id, reversal_of,
+	fx_amount, fx_currency, fx_rate, fx_spread_bps, fx_quote_id\)
+	VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, \$7, \$8, \$9, \$10, \$11, \$12\);`
+
+var transactionColumns = []string{"transaction.wallet_id", "transaction.transaction_id", "transaction.amount",
+	"transaction.currency", "transaction.balance", "transaction.counterparty_wallet_id", "transaction.reversal_of", "transaction.created_at"}
+
+var selectTransactionQuery = `SELECT transaction.wallet_id AS "transaction.wallet_id", transaction.transaction_id AS "transaction.transaction_id", transaction.amount AS "transaction.amount", transaction.currency AS "transaction.currency", transaction.balance AS "transaction.balance", transaction.counterparty_wallet_id AS "transaction.counterparty_wallet_id", transaction.reversal_of AS "transaction.reversal_of", transaction.fx_amount AS "transaction.fx_amount", transaction.fx_currency AS "transaction.fx_currency", transaction.fx_rate AS "transaction.fx_rate", transaction.fx_spread_bps AS "transaction.fx_spread_bps", transaction.fx_quote_id AS "transaction.fx_quote_id", transaction.created_at AS "transaction.created_at"
+	FROM public.transaction `
+
+var lockWalletQuery = `SELECT ` + walletColumns(1) + ` FROM public.wallet WHERE wallet.id = \$4 FOR UPDATE OF wallet;`
+
+var claimKeyQuery = `INSERT INTO public.idempotency_key \(transaction_id\) VALUES \(\$1\);`
+
+// expectLockWallet expects wallet row to be locked for the rest of database transaction
+func expectLockWallet(mock sqlmock.Sqlmock, id int, currency domain.Currency, amount, held domain.Amount) {
+	mock.ExpectQuery(lockWalletQuery).WithArgs(0, 0, domain.HoldActive, id).
+		WillReturnRows(sqlmock.NewRows([]string{"wallet.id", "wallet.account", "wallet.amount", "wallet.currency", "wallet.held"}).
+			AddRow(id, uuid.New(), amount, currency, held))
+}
+
+// expectClaimKey expects idempotency key to be recorded, key that was used violates its primary key
+func expectClaimKey(mock sqlmock.Sqlmock, id uuid.UUID, used bool) {
+	if used {
+		mock.ExpectExec(claimKeyQuery).WithArgs(id).
+			WillReturnError(&pq.Error{Code: "23505", Constraint: "idempotency_key_pkey", Message: "duplicate key value violates unique constraint"})
+		return
+	}
+	mock.ExpectExec(claimKeyQuery).WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
 func TestGet(t *testing.T) {
 	ctx := context.Background()
 	db, mock := newMock()
@@ -57,15 +114,20 @@ func TestGet(t *testing.T) {
 				m.WillReturnError(sql.ErrNoRows)
 			},
 		},
+		"NotFound": {
+			err: domain.ErrWalletNotFound,
+			mocks: func(m *sqlmock.ExpectedQuery) {
+				m.WillReturnRows(sqlmock.NewRows([]string{"wallet.id", "wallet.account", "wallet.amount", "wallet.currency"}))
+			},
+		},
 	}
 
 	for name, tt := range tests {
 		tt := tt
 		t.Run(name, func(t *testing.T) {
-			query := `SELECT wallet.id AS "wallet.id", wallet.account AS "wallet.account", wallet.amount AS "wallet.amount", wallet.currency AS "wallet.currency" 
-			  FROM public.wallet WHERE wallet.id = \$1;`
+			query := `SELECT ` + walletColumns(1) + ` FROM public.wallet WHERE \(wallet.id = \$4\) OR \(wallet.parent_id = \$5\);`
 
-			q := mock.ExpectQuery(query).WithArgs(wallet.ID)
+			q := mock.ExpectQuery(query).WithArgs(0, 0, domain.HoldActive, wallet.ID, wallet.ID)
 			tt.mocks(q)
 
 			w, err := repo.Get(ctx, int(wallet.ID))
@@ -75,7 +137,7 @@ func TestGet(t *testing.T) {
 				assert.NilError(t, err)
 				assert.Equal(t, int32(w.ID), wallet.ID)
 				assert.Equal(t, w.Account, wallet.Account)
-				assert.Equal(t, int32(w.Amount), wallet.Amount)
+				assert.Equal(t, int64(w.Amount), wallet.Amount)
 				assert.Equal(t, string(w.Currency), wallet.Currency)
 			}
 		})
@@ -84,44 +146,65 @@ func TestGet(t *testing.T) {
 
 func TestList(t *testing.T) {
 	ctx := context.Background()
-	db, mock := newMock()
-	repo := repository.NewWalletRepo(db)
-	defer func() {
-		repo.Close()
-	}()
 	account := uuid.New()
-	wallet := []model.Wallet{{
-		ID:       1,
-		Account:  account,
-		Amount:   100,
-		Currency: "usd",
-	},
-		{
-			ID:       2,
-			Account:  account,
-			Amount:   200,
-			Currency: "eur",
-		},
-	}
+	columns := []string{"wallet.id", "wallet.account", "wallet.amount", "wallet.currency", "wallet.parent_id", "wallet.multi_currency", "wallet.held"}
+	selectQuery := `SELECT ` + walletColumns(1) + ` FROM public.wallet `
+	accountQuery := `\(wallet.account = \$4\) AND wallet.parent_id IS NULL`
 
 	tests := map[string]struct {
-		err   error
-		mocks func(m *sqlmock.ExpectedQuery)
+		filter domain.WalletFilter
+		err    error
+		mocks  func(m sqlmock.Sqlmock)
+		ids    []int
 	}{
 		"Ok": {
-			err: nil,
-			mocks: func(m *sqlmock.ExpectedQuery) {
-				rows := sqlmock.NewRows([]string{"wallet.id", "wallet.account", "wallet.amount", "wallet.currency"})
-				for _, w := range wallet {
-					rows.AddRow(w.ID, w.Account, w.Amount, w.Currency)
-				}
-				m.WillReturnRows(rows)
+			filter: domain.WalletFilter{Account: account, Limit: 10},
+			ids:    []int{1, 2},
+			mocks: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(selectQuery+`WHERE `+accountQuery+` ORDER BY wallet.id ASC LIMIT \$5;`).
+					WithArgs(0, 0, domain.HoldActive, account, 10).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(1, account, 100, "usd", nil, false, 0).
+						AddRow(2, account, 200, "eur", nil, false, 0))
 			},
 		},
-		"ErrNoRows": {
-			err: sql.ErrNoRows,
-			mocks: func(m *sqlmock.ExpectedQuery) {
-				m.WillReturnError(sql.ErrNoRows)
+		"newest after cursor": {
+			filter: domain.WalletFilter{Account: account, Order: domain.OrderNewest, Limit: 10, After: &domain.WalletCursor{Order: domain.OrderNewest, ID: 5}},
+			ids:    []int{4},
+			mocks: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(selectQuery+`WHERE \(`+accountQuery+`\) AND \(wallet.id < \$5\) ORDER BY wallet.id DESC LIMIT \$6;`).
+					WithArgs(0, 0, domain.HoldActive, account, 5, 10).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(4, account, 100, "usd", nil, false, 0))
+			},
+		},
+		"currency with pockets": {
+			filter: domain.WalletFilter{
+				Account:  account,
+				Currency: "usd",
+				Order:    domain.OrderCurrency,
+				Limit:    10,
+				After:    &domain.WalletCursor{Order: domain.OrderCurrency, Currency: "eur", ID: 7},
+			},
+			ids: []int{3, 8},
+			mocks: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(selectQuery+`WHERE \(\(`+accountQuery+`\) AND \(\(wallet.currency = \$5::text\) OR \(wallet.id IN \(`+
+					` SELECT pocket.parent_id AS "pocket.parent_id" FROM public.wallet AS pocket WHERE \(pocket.account = \$6\) AND \(pocket.currency = \$7::text\) \)\)\)\) `+
+					`AND \(\(wallet.currency > \$8::text\) OR \(\(wallet.currency = \$9::text\) AND \(wallet.id > \$10\)\)\) `+
+					`ORDER BY wallet.currency ASC, wallet.id ASC LIMIT \$11;`).
+					WithArgs(0, 0, domain.HoldActive, account, "usd", account, "usd", "eur", "eur", 7, 10).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(3, account, 100, "eur", nil, true, 0).
+						AddRow(8, account, 200, "usd", nil, false, 0))
+				m.ExpectQuery(selectQuery+`WHERE wallet.parent_id IN \(\$4\);`).
+					WithArgs(0, 0, domain.HoldActive, 3).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(4, account, 50, "usd", 3, false, 0))
+			},
+		},
+		"Error": {
+			filter: domain.WalletFilter{Account: account, Limit: 10},
+			err:    sql.ErrConnDone,
+			mocks: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(selectQuery).WillReturnError(sql.ErrConnDone)
 			},
 		},
 	}
@@ -129,24 +212,25 @@ func TestList(t *testing.T) {
 	for name, tt := range tests {
 		tt := tt
 		t.Run(name, func(t *testing.T) {
-			query := `SELECT wallet.id AS "wallet.id", wallet.account AS "wallet.account", wallet.amount AS "wallet.amount", wallet.currency AS "wallet.currency" 
-	FROM public.wallet WHERE wallet.account = \$1;`
-			q := mock.ExpectQuery(query).WithArgs(account)
-			tt.mocks(q)
-
-			result, err := repo.List(ctx, account)
+			db, mock := newMock()
+			repo := repository.NewWalletRepo(db)
+			defer func() {
+				repo.Close()
+			}()
+			tt.mocks(mock)
 
-			if err != nil {
+			result, err := repo.List(ctx, tt.filter)
+			if tt.err != nil {
 				assert.ErrorContains(t, err, tt.err.Error())
-			} else {
-				assert.NilError(t, err)
-				assert.Equal(t, len(result), len(wallet))
-				for i := range wallet {
-					assert.Equal(t, int32(result[i].ID), wallet[i].ID)
-					assert.Equal(t, result[i].Account, wallet[i].Account)
-					assert.Equal(t, int32(result[i].Amount), wallet[i].Amount)
-					assert.Equal(t, string(result[i].Currency), wallet[i].Currency)
-				}
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.NilError(t, mock.ExpectationsWereMet())
+			assert.Equal(t, len(result), len(tt.ids))
+			for i, id := range tt.ids {
+				assert.Equal(t, result[i].ID, id)
+				assert.Equal(t, result[i].Account, account)
 			}
 		})
 	}
@@ -154,11 +238,6 @@ func TestList(t *testing.T) {
 
 func TestCreate(t *testing.T) {
 	ctx := context.Background()
-	db, mock := newMock()
-	repo := repository.NewWalletRepo(db)
-	defer func() {
-		repo.Close()
-	}()
 	account := uuid.New()
 	wallet := model.Wallet{
 		ID:       1,
@@ -166,23 +245,53 @@ func TestCreate(t *testing.T) {
 		Amount:   100,
 		Currency: "usd",
 	}
+	query := `INSERT INTO public.wallet \(account, currency, multi_currency, parent_id, status, block_credits\)
+		VALUES \(\$1, \$2, \$3, \$4, \$5, \$6\)
+		RETURNING wallet.id AS "wallet.id", wallet.account AS "wallet.account",
+		wallet.amount AS "wallet.amount", wallet.currency AS "wallet.currency",
+		wallet.parent_id AS "wallet.parent_id", wallet.multi_currency AS "wallet.multi_currency",
+		wallet.status AS "wallet.status", wallet.block_credits AS "wallet.block_credits",
+		wallet.version AS "wallet.version";`
+	ledgerQuery := `INSERT INTO public.ledger_account \(wallet_id, currency\) VALUES \(\$1, \$2\);`
 
 	tests := map[string]struct {
-		err   error
-		mocks func(m *sqlmock.ExpectedQuery)
+		currency domain.Currency
+		err      error
+		mocks    func(m sqlmock.Sqlmock)
 	}{
+		"invalid currency": {
+			currency: "USD",
+			err:      domain.ErrInvalidCurrency,
+			mocks:    func(mock sqlmock.Sqlmock) {},
+		},
 		"Ok": {
 			err: nil,
-			mocks: func(m *sqlmock.ExpectedQuery) {
+			mocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
 				rows := sqlmock.NewRows([]string{"wallet.id", "wallet.account", "wallet.amount", "wallet.currency"}).
 					AddRow(wallet.ID, wallet.Account, wallet.Amount, wallet.Currency)
-				m.WillReturnRows(rows)
+				mock.ExpectQuery(query).WithArgs(account, wallet.Currency, false, nil, domain.WalletActive, false).WillReturnRows(rows)
+				mock.ExpectExec(ledgerQuery).WithArgs(wallet.ID, wallet.Currency).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
 			},
 		},
 		"ErrNoRows": {
 			err: sql.ErrNoRows,
-			mocks: func(m *sqlmock.ExpectedQuery) {
-				m.WillReturnError(sql.ErrNoRows)
+			mocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(query).WithArgs(account, wallet.Currency, false, nil, domain.WalletActive, false).WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+		},
+		"ledger account error": {
+			err: sql.ErrConnDone,
+			mocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				rows := sqlmock.NewRows([]string{"wallet.id", "wallet.account", "wallet.amount", "wallet.currency"}).
+					AddRow(wallet.ID, wallet.Account, wallet.Amount, wallet.Currency)
+				mock.ExpectQuery(query).WithArgs(account, wallet.Currency, false, nil, domain.WalletActive, false).WillReturnRows(rows)
+				mock.ExpectExec(ledgerQuery).WithArgs(wallet.ID, wallet.Currency).WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
 			},
 		},
 	}
@@ -190,68 +299,198 @@ func TestCreate(t *testing.T) {
 	for name, tt := range tests {
 		tt := tt
 		t.Run(name, func(t *testing.T) {
+			db, mock := newMock()
+			repo := repository.NewWalletRepo(db)
+			defer func() {
+				repo.Close()
+			}()
+			tt.mocks(mock)
 
-			query := `INSERT INTO public.wallet \(account, currency\)
-				VALUES \(\$1, \$2\)
-				RETURNING wallet.id AS "wallet.id", wallet.account AS "wallet.account",
-				wallet.amount AS "wallet.amount", wallet.currency AS "wallet.currency";`
-
-			q := mock.ExpectQuery(query).WithArgs(account, wallet.Currency)
-			tt.mocks(q)
-
-			result, err := repo.Create(ctx, account, domain.Currency(wallet.Currency))
-			if err != nil {
+			currency := domain.Currency(wallet.Currency)
+			if tt.currency != "" {
+				currency = tt.currency
+			}
+			result, err := repo.Create(ctx, account, currency)
+			if tt.err != nil {
 				assert.ErrorContains(t, err, tt.err.Error())
 			} else {
 				assert.NilError(t, err)
+				assert.NilError(t, mock.ExpectationsWereMet())
 				assert.Equal(t, int32(result.ID), wallet.ID)
 				assert.Equal(t, result.Account, wallet.Account)
-				assert.Equal(t, int32(result.Amount), wallet.Amount)
+				assert.Equal(t, int64(result.Amount), wallet.Amount)
 				assert.Equal(t, string(result.Currency), wallet.Currency)
 			}
 		})
 	}
 }
 
-func TestHasTransaction(t *testing.T) {
+func TestGetPockets(t *testing.T) {
 	ctx := context.Background()
 	db, mock := newMock()
 	repo := repository.NewWalletRepo(db)
 	defer func() {
 		repo.Close()
 	}()
-	transaction := domain.Transaction{
-		ID:       uuid.New(),
-		WalletID: 1,
-		Amount:   10,
-		Currency: "usd",
+	account := uuid.New()
+
+	query := `SELECT ` + walletColumns(1) + ` FROM public.wallet WHERE \(wallet.id = \$4\) OR \(wallet.parent_id = \$5\);`
+	rows := sqlmock.NewRows([]string{"wallet.id", "wallet.account", "wallet.amount", "wallet.currency", "wallet.parent_id", "wallet.multi_currency", "wallet.held"}).
+		AddRow(3, account, 500, "usd", 1, false, 100).
+		AddRow(1, account, 100, "eur", nil, true, 0).
+		AddRow(2, account, 200, "jpy", 1, false, 0)
+	mock.ExpectQuery(query).WithArgs(0, 0, domain.HoldActive, 1, 1).WillReturnRows(rows)
+
+	w, err := repo.Get(ctx, 1)
+	assert.NilError(t, err)
+	assert.NilError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, w.ID, 1)
+	assert.Equal(t, w.Currency, domain.Currency("eur"))
+	assert.Assert(t, w.MultiCurrency)
+	assert.DeepEqual(t, w.Pockets, []domain.Pocket{
+		{WalletID: 2, Currency: "jpy", Amount: 200},
+		{WalletID: 3, Currency: "usd", Amount: 500, Held: 100},
+	})
+}
+
+func TestProcessPocketTransaction(t *testing.T) {
+	ctx := context.Background()
+	account := uuid.New()
+	credit := domain.Transaction{ID: uuid.New(), WalletID: 1, Amount: 10, Currency: "usd"}
+	columns := []string{"wallet.id", "wallet.account", "wallet.amount", "wallet.currency", "wallet.parent_id", "wallet.multi_currency",
+		"wallet.status", "wallet.block_credits", "wallet.held"}
+	pocketQuery := `SELECT ` + walletColumns(1) + ` FROM public.wallet WHERE \(wallet.parent_id = \$4\) AND \(wallet.currency = \$5::text\);`
+	insertQuery := `INSERT INTO public.wallet \(account, currency, multi_currency, parent_id, status, block_credits\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6\) RETURNING `
+	ledgerQuery := `INSERT INTO public.ledger_account \(wallet_id, currency\) VALUES \(\$1, \$2\);`
+	expectLockParent := func(mock sqlmock.Sqlmock, status domain.WalletStatus, blockCredits bool) {
+		mock.ExpectQuery(lockWalletQuery).WithArgs(0, 0, domain.HoldActive, 1).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(1, account, 100, "eur", nil, true, status, blockCredits, 0))
+	}
+	expectInsertPocket := func(mock sqlmock.Sqlmock, status domain.WalletStatus, blockCredits bool) {
+		mock.ExpectQuery(pocketQuery).WithArgs(0, 0, domain.HoldActive, 1, "usd").
+			WillReturnRows(sqlmock.NewRows(columns))
+		mock.ExpectQuery(insertQuery).WithArgs(account, "usd", false, 1, status, blockCredits).
+			WillReturnRows(sqlmock.NewRows(columns[:8]).AddRow(3, account, 0, "usd", 1, false, status, blockCredits))
+		mock.ExpectExec(ledgerQuery).WithArgs(3, "usd").WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+	pocketCredit := func(id int) domain.Transaction {
+		t := credit
+		t.WalletID = id
+		return t
+	}
+
+	// pocket is created in transaction that applies the credit, so it's rolled back with rejected credit
+	tests := map[string]struct {
+		err   error
+		mocks func(m sqlmock.Sqlmock)
+		id    int
+	}{
+		"closed wallet": {
+			err: domain.ErrWalletClosed,
+			mocks: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				expectLockParent(m, domain.WalletClosed, false)
+				m.ExpectRollback()
+			},
+		},
+		"credits blocked": {
+			err: domain.ErrWalletFrozen,
+			mocks: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				expectLockParent(m, domain.WalletFrozen, true)
+				expectInsertPocket(m, domain.WalletFrozen, true)
+				expectClaimKey(m, credit.ID, false)
+				m.ExpectRollback()
+			},
+		},
+		"duplicate key": {
+			err: domain.ErrDuplicateTransaction,
+			mocks: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				expectLockParent(m, domain.WalletActive, false)
+				expectInsertPocket(m, domain.WalletActive, false)
+				expectClaimKey(m, credit.ID, true)
+				m.ExpectRollback()
+			},
+		},
+		"existing pocket": {
+			id: 2,
+			mocks: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				expectLockParent(m, domain.WalletActive, false)
+				m.ExpectQuery(pocketQuery).WithArgs(0, 0, domain.HoldActive, 1, "usd").
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(2, account, 0, "usd", 1, false, domain.WalletActive, false, 0))
+				expectRecordCredit(m, pocketCredit(2), 10, 1)
+				m.ExpectCommit()
+			},
+		},
+		"Ok": {
+			id: 3,
+			mocks: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				expectLockParent(m, domain.WalletActive, false)
+				expectInsertPocket(m, domain.WalletActive, false)
+				expectRecordCredit(m, pocketCredit(3), 10, 1)
+				m.ExpectCommit()
+			},
+		},
 	}
 
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			db, mock := newMock()
+			repo := repository.NewWalletRepo(db)
+			defer func() {
+				repo.Close()
+			}()
+			mock.MatchExpectationsInOrder(true)
+			tt.mocks(mock)
+
+			result, err := repo.ProcessTransaction(ctx, credit)
+			assert.NilError(t, mock.ExpectationsWereMet())
+			if tt.err != nil {
+				assert.ErrorContains(t, err, tt.err.Error())
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.Equal(t, result.ID, tt.id)
+			assert.Equal(t, result.Amount, domain.Amount(10))
+		})
+	}
+}
+
+func TestFindTransactions(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	createdAt := time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC)
+	query := selectTransactionQuery + `WHERE transaction.transaction_id = \$1 ORDER BY transaction.wallet_id ASC;`
+
 	tests := map[string]struct {
-		result bool
+		result int
 		err    error
-		mocks  func(m *sqlmock.ExpectedQuery)
+		mocks  func(m sqlmock.Sqlmock)
 	}{
-		"True": {
-			result: true,
-			err:    nil,
-			mocks: func(m *sqlmock.ExpectedQuery) {
-				rows := sqlmock.NewRows([]string{"count"}).AddRow(1)
-				m.WillReturnRows(rows)
+		"transfer": {
+			result: 2,
+			mocks: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(transactionColumns).
+					AddRow(1, id, -10, "usd", 90, 2, nil, createdAt).
+					AddRow(2, id, 10, "usd", 10, 1, nil, createdAt)
+				m.ExpectQuery(query).WithArgs(id).WillReturnRows(rows)
 			},
 		},
-		"False": {
-			result: false,
-			err:    nil,
-			mocks: func(m *sqlmock.ExpectedQuery) {
-				rows := sqlmock.NewRows([]string{"count"}).AddRow(0)
-				m.WillReturnRows(rows)
+		"not found": {
+			result: 0,
+			mocks: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(query).WithArgs(id).WillReturnRows(sqlmock.NewRows(transactionColumns))
 			},
 		},
-		"ErrNoRows": {
-			err: sql.ErrNoRows,
-			mocks: func(m *sqlmock.ExpectedQuery) {
-				m.WillReturnError(sql.ErrNoRows)
+		"error": {
+			err: sql.ErrConnDone,
+			mocks: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(query).WithArgs(id).WillReturnError(sql.ErrConnDone)
 			},
 		},
 	}
@@ -259,17 +498,20 @@ func TestHasTransaction(t *testing.T) {
 	for name, tt := range tests {
 		tt := tt
 		t.Run(name, func(t *testing.T) {
-			query := `SELECT COUNT\(transaction.wallet_id\) AS "count" FROM public.transaction WHERE \(transaction.wallet_id = \$1\) AND \(transaction.transaction_id = \$2\);`
+			db, mock := newMock()
+			repo := repository.NewWalletRepo(db)
+			defer func() {
+				repo.Close()
+			}()
 
-			q := mock.ExpectQuery(query).WithArgs(transaction.WalletID, transaction.ID)
-			tt.mocks(q)
+			tt.mocks(mock)
 
-			ok, err := repo.HasTransaction(ctx, transaction)
+			result, err := repo.FindTransactions(ctx, id)
 			if tt.err != nil {
 				assert.ErrorContains(t, err, tt.err.Error())
 			} else {
 				assert.NilError(t, err)
-				assert.Equal(t, ok, tt.result)
+				assert.Equal(t, len(result), tt.result)
 			}
 		})
 	}
@@ -293,15 +535,81 @@ func TestProcessTransaction(t *testing.T) {
 	tests := map[string]struct {
 		err   error
 		mocks func(m sqlmock.Sqlmock, err error)
+		// exact marks error that is returned as is, without details of database error
+		exact bool
 	}{
 		"Transaction_Exist": {
-			err: errors.New("pq: duplicate key value violates unique constraint \"transaction_pkey\""),
+			err: domain.ErrDuplicateTransaction,
 			mocks: func(mock sqlmock.Sqlmock, err error) {
 				mock.MatchExpectationsInOrder(true)
 				mock.ExpectBegin()
+				expectLockWallet(mock, transaction.WalletID, transaction.Currency, 90, 0)
+				expectClaimKey(mock, transaction.ID, false)
+
+				rows := sqlmock.NewRows([]string{"wallet.id", "wallet.account", "wallet.amount", "wallet.currency"}).
+					AddRow(wallet.ID, wallet.Account, wallet.Amount, wallet.Currency)
+				mock.ExpectQuery(updateWalletQuery).WithArgs(transaction.Amount, transaction.WalletID, transaction.Currency, 0, 0, domain.HoldActive).WillReturnRows(rows)
 
-				query := `INSERT INTO public.transaction \(wallet_id, transaction_id\) VALUES \(\$1, \$2\);`
-				mock.ExpectExec(query).WithArgs(transaction.WalletID, transaction.ID).WillReturnError(err)
+				mock.ExpectExec(insertTransactionQuery).
+					WithArgs(transaction.WalletID, transaction.ID, transaction.Amount, transaction.Currency, wallet.Amount, nil, nil, nil, nil, nil, nil, nil).
+					WillReturnError(&pq.Error{Code: "23505", Constraint: "transaction_pkey", Message: "duplicate key value violates unique constraint"})
+				mock.ExpectRollback()
+			},
+		},
+		"duplicate key": {
+			err: domain.ErrDuplicateTransaction,
+			mocks: func(mock sqlmock.Sqlmock, err error) {
+				mock.MatchExpectationsInOrder(true)
+				mock.ExpectBegin()
+				expectLockWallet(mock, transaction.WalletID, transaction.Currency, 90, 0)
+				expectClaimKey(mock, transaction.ID, true)
+				mock.ExpectRollback()
+			},
+		},
+		"currency mismatch": {
+			err: domain.ErrCurrencyMismatch,
+			mocks: func(mock sqlmock.Sqlmock, err error) {
+				mock.MatchExpectationsInOrder(true)
+				mock.ExpectBegin()
+				expectLockWallet(mock, transaction.WalletID, "eur", 90, 0)
+				expectClaimKey(mock, transaction.ID, false)
+				mock.ExpectRollback()
+			},
+		},
+		"overflow": {
+			err: domain.ErrAmountOverflow,
+			mocks: func(mock sqlmock.Sqlmock, err error) {
+				mock.MatchExpectationsInOrder(true)
+				mock.ExpectBegin()
+				expectLockWallet(mock, transaction.WalletID, transaction.Currency, domain.MaxAmount-5, 0)
+				expectClaimKey(mock, transaction.ID, false)
+				mock.ExpectRollback()
+			},
+		},
+		"retries exhausted": {
+			err:   domain.ErrConcurrentUpdate,
+			exact: true,
+			mocks: func(mock sqlmock.Sqlmock, err error) {
+				mock.MatchExpectationsInOrder(true)
+				for i := 0; i < 10; i++ {
+					mock.ExpectBegin()
+					mock.ExpectQuery(lockWalletQuery).WillReturnError(&pq.Error{Code: "40001", Message: "could not serialize access due to concurrent update"})
+					mock.ExpectRollback()
+				}
+			},
+		},
+		"retry": {
+			err: errors.New("test error"),
+			mocks: func(mock sqlmock.Sqlmock, err error) {
+				mock.MatchExpectationsInOrder(true)
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockWalletQuery).WillReturnError(&pq.Error{Code: "40001", Message: "could not serialize access due to concurrent update"})
+				mock.ExpectRollback()
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockWalletQuery).WillReturnError(&pq.Error{Code: "40P01", Message: "deadlock detected"})
+				mock.ExpectRollback()
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockWalletQuery).WillReturnError(err)
 				mock.ExpectRollback()
 			},
 		},
@@ -310,15 +618,9 @@ func TestProcessTransaction(t *testing.T) {
 			mocks: func(mock sqlmock.Sqlmock, err error) {
 				mock.MatchExpectationsInOrder(true)
 				mock.ExpectBegin()
-
-				query := `INSERT INTO public.transaction \(wallet_id, transaction_id\) VALUES \(\$1, \$2\);`
-				mock.ExpectExec(query).WithArgs(transaction.WalletID, transaction.ID).WillReturnResult(sqlmock.NewResult(0, 0))
-
-				query = `UPDATE public.wallet
-					SET amount = \(wallet.amount \+ \$1\)
-					WHERE \(wallet.id = \$2\) AND \(wallet.currency = \$3::text\)
-					RETURNING wallet.id AS "wallet.id", wallet.account AS "wallet.account", wallet.amount AS "wallet.amount", wallet.currency AS "wallet.currency";`
-				mock.ExpectQuery(query).WithArgs(transaction.Amount, transaction.WalletID, transaction.Currency).WillReturnError(err)
+				expectLockWallet(mock, transaction.WalletID, transaction.Currency, 90, 0)
+				expectClaimKey(mock, transaction.ID, false)
+				mock.ExpectQuery(updateWalletQuery).WithArgs(transaction.Amount, transaction.WalletID, transaction.Currency, 0, 0, domain.HoldActive).WillReturnError(err)
 				mock.ExpectRollback()
 			},
 		},
@@ -327,18 +629,22 @@ func TestProcessTransaction(t *testing.T) {
 			mocks: func(mock sqlmock.Sqlmock, err error) {
 				mock.MatchExpectationsInOrder(true)
 				mock.ExpectBegin()
-
-				query := `INSERT INTO public.transaction \(wallet_id, transaction_id\) VALUES \(\$1, \$2\);`
-				mock.ExpectExec(query).WithArgs(transaction.WalletID, transaction.ID).WillReturnResult(sqlmock.NewResult(0, 0))
+				expectLockWallet(mock, transaction.WalletID, transaction.Currency, 90, 0)
+				expectClaimKey(mock, transaction.ID, false)
 
 				rows := sqlmock.NewRows([]string{"wallet.id", "wallet.account", "wallet.amount", "wallet.currency"}).
 					AddRow(wallet.ID, wallet.Account, wallet.Amount, wallet.Currency)
+				mock.ExpectQuery(updateWalletQuery).WithArgs(transaction.Amount, transaction.WalletID, transaction.Currency, 0, 0, domain.HoldActive).WillReturnRows(rows)
+
+				mock.ExpectExec(insertTransactionQuery).
+					WithArgs(transaction.WalletID, transaction.ID, transaction.Amount, transaction.Currency, wallet.Amount, nil, nil, nil, nil, nil, nil, nil).
+					WillReturnResult(sqlmock.NewResult(0, 1))
 
-				query = `UPDATE public.wallet
-					SET amount = \(wallet.amount \+ \$1\)
-					WHERE \(wallet.id = \$2\) AND \(wallet.currency = \$3::text\)
-					RETURNING wallet.id AS "wallet.id", wallet.account AS "wallet.account", wallet.amount AS "wallet.amount", wallet.currency AS "wallet.currency";`
-				mock.ExpectQuery(query).WithArgs(transaction.Amount, transaction.WalletID, transaction.Currency).WillReturnRows(rows)
+				expectJournalEntry(mock, transaction.ID, domain.EntryTransaction, 1)
+				expectWalletPosting(mock, transaction.WalletID, transaction.Amount, 1, int64(wallet.Amount))
+				expectSystemAccount(mock, domain.ExternalFunding, transaction.Currency, 2)
+				mock.ExpectExec(postingQuery).WithArgs(1, 1, transaction.Amount, 1, 2, -transaction.Amount).
+					WillReturnResult(sqlmock.NewResult(0, 2))
 				mock.ExpectCommit()
 			},
 		},
@@ -356,10 +662,271 @@ func TestProcessTransaction(t *testing.T) {
 			tt.mocks(mock, tt.err)
 
 			_, err := repo.ProcessTransaction(ctx, transaction)
+			switch {
+			case tt.exact:
+				assert.Error(t, err, tt.err.Error())
+			case tt.err != nil:
+				assert.ErrorContains(t, err, tt.err.Error())
+			default:
+				assert.NilError(t, err)
+			}
+			assert.NilError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTransfer(t *testing.T) {
+	ctx := context.Background()
+	transfer := domain.Transfer{
+		ID:           uuid.New(),
+		FromWalletID: 2,
+		ToWalletID:   1,
+		Amount:       10,
+		Currency:     "usd",
+	}
+	from := model.Wallet{ID: 2, Account: uuid.New(), Amount: 90, Currency: "usd"}
+	to := model.Wallet{ID: 1, Account: uuid.New(), Amount: 110, Currency: "usd"}
+	walletRows := func(w model.Wallet) *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"wallet.id", "wallet.account", "wallet.amount", "wallet.currency"}).
+			AddRow(w.ID, w.Account, w.Amount, w.Currency)
+	}
+
+	tests := map[string]struct {
+		err   error
+		mocks func(m sqlmock.Sqlmock, err error)
+	}{
+		"insufficient funds": {
+			err: domain.ErrInsufficientFunds,
+			mocks: func(mock sqlmock.Sqlmock, err error) {
+				mock.MatchExpectationsInOrder(true)
+				mock.ExpectBegin()
+				expectLockWallet(mock, transfer.ToWalletID, transfer.Currency, 100, 0)
+				expectLockWallet(mock, transfer.FromWalletID, transfer.Currency, 100, 95)
+				mock.ExpectRollback()
+			},
+		},
+		"negative": {
+			err: errors.New("pq: new row for relation \"wallet\" violates check constraint \"positive_amount\""),
+			mocks: func(mock sqlmock.Sqlmock, err error) {
+				mock.MatchExpectationsInOrder(true)
+				mock.ExpectBegin()
+				expectLockWallet(mock, transfer.ToWalletID, transfer.Currency, 100, 0)
+				expectLockWallet(mock, transfer.FromWalletID, transfer.Currency, 100, 0)
+				expectClaimKey(mock, transfer.ID, false)
+				mock.ExpectQuery(updateWalletQuery).WithArgs(transfer.Amount, to.ID, transfer.Currency, 0, 0, domain.HoldActive).WillReturnRows(walletRows(to))
+				mock.ExpectQuery(updateWalletQuery).WithArgs(-transfer.Amount, from.ID, transfer.Currency, 0, 0, domain.HoldActive).WillReturnError(err)
+				mock.ExpectRollback()
+			},
+		},
+		"Transaction_Exist": {
+			err: errors.New("pq: duplicate key value violates unique constraint \"transaction_pkey\""),
+			mocks: func(mock sqlmock.Sqlmock, err error) {
+				mock.MatchExpectationsInOrder(true)
+				mock.ExpectBegin()
+				expectLockWallet(mock, transfer.ToWalletID, transfer.Currency, 100, 0)
+				expectLockWallet(mock, transfer.FromWalletID, transfer.Currency, 100, 0)
+				expectClaimKey(mock, transfer.ID, false)
+				mock.ExpectQuery(updateWalletQuery).WithArgs(transfer.Amount, to.ID, transfer.Currency, 0, 0, domain.HoldActive).WillReturnRows(walletRows(to))
+				mock.ExpectQuery(updateWalletQuery).WithArgs(-transfer.Amount, from.ID, transfer.Currency, 0, 0, domain.HoldActive).WillReturnRows(walletRows(from))
+				mock.ExpectExec(insertTransactionQuery).
+					WithArgs(to.ID, transfer.ID, transfer.Amount, transfer.Currency, to.Amount, from.ID, nil, nil, nil, nil, nil, nil).
+					WillReturnError(err)
+				mock.ExpectRollback()
+			},
+		},
+		"Ok": {
+			err: nil,
+			mocks: func(mock sqlmock.Sqlmock, err error) {
+				mock.MatchExpectationsInOrder(true)
+				mock.ExpectBegin()
+				expectLockWallet(mock, transfer.ToWalletID, transfer.Currency, 100, 0)
+				expectLockWallet(mock, transfer.FromWalletID, transfer.Currency, 100, 0)
+				expectClaimKey(mock, transfer.ID, false)
+				mock.ExpectQuery(updateWalletQuery).WithArgs(transfer.Amount, to.ID, transfer.Currency, 0, 0, domain.HoldActive).WillReturnRows(walletRows(to))
+				mock.ExpectQuery(updateWalletQuery).WithArgs(-transfer.Amount, from.ID, transfer.Currency, 0, 0, domain.HoldActive).WillReturnRows(walletRows(from))
+				mock.ExpectExec(insertTransactionQuery).
+					WithArgs(to.ID, transfer.ID, transfer.Amount, transfer.Currency, to.Amount, from.ID, nil, nil, nil, nil, nil, nil).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(insertTransactionQuery).
+					WithArgs(from.ID, transfer.ID, -transfer.Amount, transfer.Currency, from.Amount, to.ID, nil, nil, nil, nil, nil, nil).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				expectJournalEntry(mock, transfer.ID, domain.EntryTransfer, 1)
+				expectWalletPosting(mock, transfer.FromWalletID, -transfer.Amount, 2, int64(from.Amount))
+				expectWalletPosting(mock, transfer.ToWalletID, transfer.Amount, 1, int64(to.Amount))
+				mock.ExpectExec(postingQuery).WithArgs(1, 2, -transfer.Amount, 1, 1, transfer.Amount).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			db, mock := newMock()
+			repo := repository.NewWalletRepo(db)
+			defer func() {
+				repo.Close()
+			}()
+
+			tt.mocks(mock, tt.err)
+
+			f, d, err := repo.Transfer(ctx, transfer)
 			if tt.err != nil {
 				assert.ErrorContains(t, err, tt.err.Error())
 			} else {
 				assert.NilError(t, err)
+				assert.NilError(t, mock.ExpectationsWereMet())
+				assert.Equal(t, int32(f.ID), from.ID)
+				assert.Equal(t, int64(f.Amount), from.Amount)
+				assert.Equal(t, int32(d.ID), to.ID)
+				assert.Equal(t, int64(d.Amount), to.Amount)
+			}
+		})
+	}
+}
+
+func TestTransferToNewPocket(t *testing.T) {
+	db, mock := newMock()
+	repo := repository.NewWalletRepo(db)
+	defer func() {
+		repo.Close()
+	}()
+	account := uuid.New()
+	transfer := domain.Transfer{ID: uuid.New(), FromWalletID: 2, ToWalletID: 1, Amount: 10, Currency: "usd"}
+	columns := []string{"wallet.id", "wallet.account", "wallet.amount", "wallet.currency", "wallet.parent_id", "wallet.multi_currency",
+		"wallet.status", "wallet.block_credits", "wallet.held"}
+	walletRows := func(id int, amount domain.Amount) *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"wallet.id", "wallet.amount", "wallet.currency"}).AddRow(id, amount, "usd")
+	}
+
+	// pocket of destination is created under its wallet lock in transaction that applies the transfer
+	mock.MatchExpectationsInOrder(true)
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockWalletQuery).WithArgs(0, 0, domain.HoldActive, 1).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(1, account, 0, "eur", nil, true, domain.WalletActive, false, 0))
+	expectLockWallet(mock, 2, "usd", 100, 0)
+	mock.ExpectQuery(`SELECT `+walletColumns(1)+` FROM public.wallet WHERE \(wallet.parent_id = \$4\) AND \(wallet.currency = \$5::text\);`).
+		WithArgs(0, 0, domain.HoldActive, 1, "usd").
+		WillReturnRows(sqlmock.NewRows(columns))
+	mock.ExpectQuery(`INSERT INTO public.wallet \(account, currency, multi_currency, parent_id, status, block_credits\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6\) RETURNING `).
+		WithArgs(account, "usd", false, 1, domain.WalletActive, false).
+		WillReturnRows(sqlmock.NewRows(columns[:8]).AddRow(3, account, 0, "usd", 1, false, domain.WalletActive, false))
+	mock.ExpectExec(`INSERT INTO public.ledger_account \(wallet_id, currency\) VALUES \(\$1, \$2\);`).WithArgs(3, "usd").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectClaimKey(mock, transfer.ID, false)
+	mock.ExpectQuery(updateWalletQuery).WithArgs(-transfer.Amount, 2, "usd", 0, 0, domain.HoldActive).WillReturnRows(walletRows(2, 90))
+	mock.ExpectQuery(updateWalletQuery).WithArgs(transfer.Amount, 3, "usd", 0, 0, domain.HoldActive).WillReturnRows(walletRows(3, 10))
+	mock.ExpectExec(insertTransactionQuery).
+		WithArgs(2, transfer.ID, -transfer.Amount, "usd", 90, 3, nil, nil, nil, nil, nil, nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(insertTransactionQuery).
+		WithArgs(3, transfer.ID, transfer.Amount, "usd", 10, 2, nil, nil, nil, nil, nil, nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectJournalEntry(mock, transfer.ID, domain.EntryTransfer, 1)
+	expectWalletPosting(mock, 2, -transfer.Amount, 2, 90)
+	expectWalletPosting(mock, 3, transfer.Amount, 3, 10)
+	mock.ExpectExec(postingQuery).WithArgs(1, 2, -transfer.Amount, 1, 3, transfer.Amount).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	from, to, err := repo.Transfer(context.Background(), transfer)
+	assert.NilError(t, err)
+	assert.NilError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, from.ID, 2)
+	assert.Equal(t, to.ID, 3)
+	assert.Equal(t, to.Amount, domain.Amount(10))
+}
+
+func TestListTransactions(t *testing.T) {
+	ctx := context.Background()
+	createdAt := time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC)
+	counterparty := int32(2)
+	transactions := []model.Transaction{
+		{WalletID: 1, TransactionID: uuid.New(), Amount: -10, Currency: "usd", Balance: 90, CounterpartyWalletID: &counterparty, CreatedAt: createdAt.Add(time.Minute)},
+		{WalletID: 1, TransactionID: uuid.New(), Amount: 100, Currency: "usd", Balance: 100, CreatedAt: createdAt},
+	}
+	walletsQuery := `transaction.wallet_id IN \( SELECT wallet.id AS "wallet.id" FROM public.wallet WHERE \(wallet.id = \$1\) OR \(wallet.parent_id = \$2\) \)`
+	orderQuery := ` ORDER BY transaction.created_at DESC, transaction.transaction_id DESC LIMIT \$\d+;`
+	cursor := domain.TransactionCursor{CreatedAt: createdAt, ID: uuid.New()}
+
+	tests := map[string]struct {
+		filter domain.TransactionFilter
+		err    error
+		mocks  func(m sqlmock.Sqlmock)
+	}{
+		"Ok": {
+			filter: domain.TransactionFilter{WalletID: 1, Limit: 10},
+			mocks: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(transactionColumns)
+				for _, t := range transactions {
+					rows.AddRow(t.WalletID, t.TransactionID, t.Amount, t.Currency, t.Balance, t.CounterpartyWalletID, t.ReversalOf, t.CreatedAt)
+				}
+				m.ExpectQuery(selectTransactionQuery+`WHERE `+walletsQuery+orderQuery).
+					WithArgs(1, 1, 10).
+					WillReturnRows(rows)
+			},
+		},
+		"Filters": {
+			filter: domain.TransactionFilter{
+				WalletID: 1,
+				From:     createdAt,
+				To:       createdAt.Add(time.Hour),
+				Sign:     domain.SignDebit,
+				Limit:    10,
+				After:    &cursor,
+			},
+			mocks: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(selectTransactionQuery+
+					`WHERE \(\(\(\(`+walletsQuery+`\) AND \(transaction.created_at >= \$3::timestamp with time zone\)\) `+
+					`AND \(transaction.created_at < \$4::timestamp with time zone\)\) AND \(transaction.amount < \$5\)\) `+
+					`AND \(\(transaction.created_at < \$6::timestamp with time zone\) OR \(\(transaction.created_at = \$7::timestamp with time zone\) AND \(transaction.transaction_id < \$8\)\)\)`+
+					orderQuery).
+					WithArgs(1, 1, createdAt, createdAt.Add(time.Hour), 0, cursor.CreatedAt, cursor.CreatedAt, cursor.ID, 10).
+					WillReturnRows(sqlmock.NewRows(transactionColumns))
+			},
+		},
+		"Error": {
+			filter: domain.TransactionFilter{WalletID: 1, Limit: 10},
+			err:    sql.ErrConnDone,
+			mocks: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(selectTransactionQuery).WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			db, mock := newMock()
+			repo := repository.NewWalletRepo(db)
+			defer func() {
+				repo.Close()
+			}()
+			tt.mocks(mock)
+
+			result, err := repo.ListTransactions(ctx, tt.filter)
+			if tt.err != nil {
+				assert.ErrorContains(t, err, tt.err.Error())
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.NilError(t, mock.ExpectationsWereMet())
+			if tt.filter.After == nil {
+				assert.Equal(t, len(result), len(transactions))
+				for i := range transactions {
+					assert.Equal(t, result[i].ID, transactions[i].TransactionID)
+					assert.Equal(t, int32(result[i].WalletID), transactions[i].WalletID)
+					assert.Equal(t, int64(result[i].Amount), transactions[i].Amount)
+					assert.Equal(t, string(result[i].Currency), transactions[i].Currency)
+					assert.Equal(t, int64(result[i].Balance), transactions[i].Balance)
+					assert.Equal(t, result[i].CreatedAt, transactions[i].CreatedAt)
+					if transactions[i].CounterpartyWalletID != nil {
+						assert.Equal(t, int32(result[i].CounterpartyWalletID), *transactions[i].CounterpartyWalletID)
+					}
+				}
 			}
 		})
 	}
@@ -0,0 +1,151 @@
+package walletfeed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/ximura/gowallet/internal/core/domain"
+	"github.com/ximura/gowallet/internal/core/ports"
+)
+
+var _ ports.WalletFeed = (*Feed)(nil)
+
+// Channel is Postgres notification channel wallet triggers publish changes to
+const Channel = "wallet_changed"
+
+const (
+	minReconnectInterval = time.Second
+	maxReconnectInterval = time.Minute
+	// connection is checked when there were no notifications for a while
+	pingInterval = time.Minute
+)
+
+// Feed listens for wallet changes committed to Postgres and fans them out to subscribers of the server
+type Feed struct {
+	dsn string
+
+	mu          sync.Mutex
+	subscribers map[int]map[chan struct{}]struct{}
+}
+
+// NewFeed returns feed that listens on database connection opened with dsn once it's run
+func NewFeed(dsn string) *Feed {
+	return &Feed{
+		dsn:         dsn,
+		subscribers: make(map[int]map[chan struct{}]struct{}),
+	}
+}
+
+// Run listens for notifications until context is canceled, connection is reopened when it's lost
+func (f *Feed) Run(ctx context.Context) error {
+	listener := pq.NewListener(f.dsn, minReconnectInterval, maxReconnectInterval, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Println(fmt.Errorf("wallet feed connection event %d: %w", event, err))
+		}
+	})
+	defer listener.Close()
+
+	if err := listener.Listen(Channel); err != nil {
+		return fmt.Errorf("can't listen %s: %w", Channel, err)
+	}
+
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case n := <-listener.Notify:
+			if n == nil {
+				// connection was reopened, notifications sent meanwhile are lost
+				f.publishAll()
+				continue
+			}
+			f.handle(n.Extra)
+		case <-ticker.C:
+			go listener.Ping()
+		}
+	}
+}
+
+// Subscribe returns channel that receives a value when wallet or its pockets may have changed.
+// Channel buffers one value, so changes that subscriber didn't read yet are coalesced.
+func (f *Feed) Subscribe(ctx context.Context, walletID int) <-chan struct{} {
+	ch := make(chan struct{}, 1)
+
+	f.mu.Lock()
+	if f.subscribers[walletID] == nil {
+		f.subscribers[walletID] = make(map[chan struct{}]struct{})
+	}
+	f.subscribers[walletID][ch] = struct{}{}
+	f.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		delete(f.subscribers[walletID], ch)
+		if len(f.subscribers[walletID]) == 0 {
+			delete(f.subscribers, walletID)
+		}
+		close(ch)
+	}()
+
+	return ch
+}
+
+// handle publishes change from notification payload sent by notify_wallet_changed trigger
+func (f *Feed) handle(payload string) {
+	var change struct {
+		ID       int   `json:"id"`
+		ParentID *int  `json:"parent_id"`
+		Version  int64 `json:"version"`
+	}
+	if err := json.Unmarshal([]byte(payload), &change); err != nil {
+		log.Println(fmt.Errorf("invalid wallet change %q: %w", payload, err))
+		return
+	}
+
+	result := domain.WalletChange{WalletID: change.ID, Version: change.Version}
+	if change.ParentID != nil {
+		result.ParentID = *change.ParentID
+	}
+	f.publish(result)
+}
+
+// publish notifies subscribers of changed wallet, change of pocket is published to subscribers of its wallet too
+func (f *Feed) publish(change domain.WalletChange) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.notify(change.WalletID)
+	if change.ParentID != 0 {
+		f.notify(change.ParentID)
+	}
+}
+
+func (f *Feed) publishAll() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for walletID := range f.subscribers {
+		f.notify(walletID)
+	}
+}
+
+// notify signals subscribers of wallet without blocking, it's called with mu locked
+func (f *Feed) notify(walletID int) {
+	for ch := range f.subscribers[walletID] {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
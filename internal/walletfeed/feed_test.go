@@ -0,0 +1,135 @@
+package walletfeed
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ximura/gowallet/internal/core/domain"
+	"github.com/ximura/gowallet/internal/repository"
+	"gotest.tools/v3/assert"
+)
+
+func received(ch <-chan struct{}) bool {
+	select {
+	case _, ok := <-ch:
+		return ok
+	default:
+		return false
+	}
+}
+
+func TestPublish(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	feed := NewFeed("")
+	wallet := feed.Subscribe(ctx, 1)
+	pocket := feed.Subscribe(ctx, 2)
+	other := feed.Subscribe(ctx, 3)
+
+	tests := map[string]struct {
+		publish func()
+		wallet  bool
+		pocket  bool
+		other   bool
+	}{
+		"wallet": {
+			publish: func() { feed.publish(domain.WalletChange{WalletID: 1, Version: 1}) },
+			wallet:  true,
+		},
+		"pocket": {
+			publish: func() { feed.publish(domain.WalletChange{WalletID: 2, ParentID: 1, Version: 1}) },
+			wallet:  true,
+			pocket:  true,
+		},
+		"notification": {
+			publish: func() { feed.handle(`{"id": 3, "parent_id": null, "version": 4}`) },
+			other:   true,
+		},
+		"invalid notification": {
+			publish: func() { feed.handle(`{"id": "3"}`) },
+		},
+		"reconnect": {
+			publish: feed.publishAll,
+			wallet:  true,
+			pocket:  true,
+			other:   true,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			tt.publish()
+			assert.Equal(t, received(wallet), tt.wallet)
+			assert.Equal(t, received(pocket), tt.pocket)
+			assert.Equal(t, received(other), tt.other)
+		})
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	feed := NewFeed("")
+	ch := feed.Subscribe(ctx, 1)
+
+	// pending changes are coalesced
+	feed.publish(domain.WalletChange{WalletID: 1, Version: 1})
+	feed.publish(domain.WalletChange{WalletID: 1, Version: 2})
+	assert.Assert(t, received(ch))
+	assert.Assert(t, !received(ch))
+
+	cancel()
+	_, ok := <-ch
+	assert.Assert(t, !ok)
+
+	feed.mu.Lock()
+	defer feed.mu.Unlock()
+	assert.Equal(t, len(feed.subscribers), 0)
+}
+
+// TestRunHoldChange checks that creating a hold notifies watchers of its wallet, it needs database, e.g.
+// WALLET_TEST_DSN="host=localhost port=5432 user=postgres password=postgres dbname=wallet sslmode=disable"
+func TestRunHoldChange(t *testing.T) {
+	dsn := os.Getenv("WALLET_TEST_DSN")
+	if dsn == "" {
+		t.Skip("WALLET_TEST_DSN is not set")
+	}
+	db, err := sql.Open("postgres", dsn)
+	assert.NilError(t, err)
+	repo := repository.NewWalletRepo(db)
+	defer func() {
+		repo.Close()
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	wallet, err := repo.Create(ctx, uuid.New(), "usd")
+	assert.NilError(t, err)
+	_, err = repo.ProcessTransaction(ctx, domain.Transaction{ID: uuid.New(), WalletID: wallet.ID, Amount: 100, Currency: "usd"})
+	assert.NilError(t, err)
+
+	feed := NewFeed(dsn)
+	changes := feed.Subscribe(ctx, wallet.ID)
+	stopped := make(chan error)
+	go func() { stopped <- feed.Run(ctx) }()
+	// listener may start after the change, reconnect of listener is published to every subscriber too
+	time.Sleep(100 * time.Millisecond)
+	for received(changes) {
+	}
+
+	_, err = repo.CreateHold(ctx, domain.Hold{ID: uuid.New(), WalletID: wallet.ID, Amount: 30, Currency: "usd", ExpiresAt: time.Now().Add(time.Hour)})
+	assert.NilError(t, err)
+	select {
+	case <-changes:
+	case <-time.After(5 * time.Second):
+		t.Fatal("hold change was not published")
+	}
+
+	cancel()
+	assert.NilError(t, <-stopped)
+}
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS transaction;
+DROP TABLE IF EXISTS wallet;
+DROP FUNCTION IF EXISTS update_updated_at_trigger;
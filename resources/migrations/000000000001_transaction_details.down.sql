@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_transaction_wallet_created_at;
+
+ALTER TABLE transaction
+    DROP COLUMN amount,
+    DROP COLUMN currency,
+    DROP COLUMN balance;
@@ -0,0 +1,13 @@
+-- Transactions processed before this migration have no recorded amount,
+-- they keep zero values for the new columns.
+ALTER TABLE transaction
+    ADD COLUMN amount BIGINT DEFAULT 0 NOT NULL,
+    ADD COLUMN currency VARCHAR(3) DEFAULT '' NOT NULL,
+    ADD COLUMN balance BIGINT DEFAULT 0 NOT NULL;
+
+UPDATE transaction
+SET currency = wallet.currency
+FROM wallet
+WHERE wallet.id = transaction.wallet_id;
+
+CREATE INDEX idx_transaction_wallet_created_at ON transaction (wallet_id, created_at DESC, transaction_id DESC);
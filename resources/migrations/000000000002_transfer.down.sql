@@ -0,0 +1,2 @@
+ALTER TABLE transaction
+    DROP COLUMN counterparty_wallet_id;
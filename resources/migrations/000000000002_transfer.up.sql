@@ -0,0 +1,5 @@
+ALTER TABLE transaction
+    ADD COLUMN counterparty_wallet_id INTEGER NULL,
+    ADD CONSTRAINT fk_counterparty_wallet
+      FOREIGN KEY(counterparty_wallet_id)
+        REFERENCES wallet(id);
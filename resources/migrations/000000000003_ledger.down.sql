@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS posting;
+DROP FUNCTION IF EXISTS check_journal_entry_balanced_trigger;
+DROP TABLE IF EXISTS journal_entry;
+DROP TABLE IF EXISTS ledger_account;
@@ -0,0 +1,99 @@
+CREATE TABLE ledger_account (
+    id  SERIAL PRIMARY KEY,
+    -- wallet owning the account, NULL for system accounts
+    wallet_id INTEGER NULL UNIQUE,
+    -- name of system account, NULL for wallet accounts
+    code VARCHAR(32) NULL,
+    currency VARCHAR(3) NOT NULL,
+    -- sum of account postings, maintained for wallet accounts only
+    balance BIGINT DEFAULT 0 NOT NULL,
+    created_at   TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+    CONSTRAINT wallet_or_system CHECK ((wallet_id IS NULL) <> (code IS NULL)),
+    CONSTRAINT unique_system_account UNIQUE (code, currency),
+    CONSTRAINT fk_wallet
+      FOREIGN KEY(wallet_id)
+        REFERENCES wallet(id)
+);
+
+CREATE TABLE journal_entry (
+    id  BIGSERIAL PRIMARY KEY,
+    transaction_id UUID NOT NULL,
+    kind VARCHAR(32) NOT NULL,
+    created_at   TIMESTAMPTZ DEFAULT NOW() NOT NULL
+);
+
+CREATE INDEX idx_journal_entry_transaction_id ON journal_entry (transaction_id);
+
+CREATE TABLE posting (
+    id  BIGSERIAL PRIMARY KEY,
+    journal_entry_id BIGINT NOT NULL,
+    ledger_account_id INTEGER NOT NULL,
+    amount BIGINT NOT NULL,
+    CONSTRAINT fk_journal_entry
+      FOREIGN KEY(journal_entry_id)
+        REFERENCES journal_entry(id),
+    CONSTRAINT fk_ledger_account
+      FOREIGN KEY(ledger_account_id)
+        REFERENCES ledger_account(id)
+);
+
+CREATE INDEX idx_posting_journal_entry_id ON posting (journal_entry_id);
+CREATE INDEX idx_posting_ledger_account_id ON posting (ledger_account_id);
+
+-- Postings of every journal entry must sum up to zero in each currency,
+-- checked at commit so all postings of the entry are already inserted.
+CREATE FUNCTION check_journal_entry_balanced_trigger()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF EXISTS (
+        SELECT 1
+        FROM posting
+        JOIN ledger_account ON ledger_account.id = posting.ledger_account_id
+        WHERE posting.journal_entry_id = NEW.journal_entry_id
+        GROUP BY ledger_account.currency
+        HAVING SUM(posting.amount) <> 0
+    ) THEN
+        RAISE EXCEPTION 'journal entry % is not balanced', NEW.journal_entry_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ language 'plpgsql';
+
+CREATE CONSTRAINT TRIGGER check_journal_entry_balanced
+    AFTER INSERT ON posting
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW
+EXECUTE PROCEDURE check_journal_entry_balanced_trigger();
+
+INSERT INTO ledger_account (code, currency)
+SELECT code, currency
+FROM (VALUES ('external_funding'), ('external_withdrawal'), ('opening_balance')) AS system(code)
+CROSS JOIN (VALUES ('usd'), ('eur'), ('uah'), ('jpy')) AS currencies(currency);
+
+INSERT INTO ledger_account (wallet_id, currency, balance)
+SELECT id, currency, amount FROM wallet;
+
+-- Existing balances are moved into the ledger as opening balance entries
+DO $$
+DECLARE
+    account RECORD;
+    entry_id BIGINT;
+BEGIN
+    FOR account IN SELECT id, currency, balance FROM ledger_account WHERE wallet_id IS NOT NULL AND balance <> 0 LOOP
+        INSERT INTO ledger_account (code, currency)
+        VALUES ('opening_balance', account.currency)
+        ON CONFLICT (code, currency) DO NOTHING;
+
+        INSERT INTO journal_entry (transaction_id, kind)
+        VALUES (gen_random_uuid(), 'opening_balance')
+        RETURNING id INTO entry_id;
+
+        INSERT INTO posting (journal_entry_id, ledger_account_id, amount)
+        SELECT entry_id, account.id, account.balance
+        UNION ALL
+        SELECT entry_id, ledger_account.id, -account.balance
+        FROM ledger_account
+        WHERE code = 'opening_balance' AND currency = account.currency;
+    END LOOP;
+END;
+$$;
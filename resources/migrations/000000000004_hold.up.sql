@@ -0,0 +1,23 @@
+CREATE TABLE hold (
+    -- idempotency key of the hold, captured funds are recorded as transaction with the same id
+    id UUID PRIMARY KEY,
+    wallet_id INTEGER NOT NULL,
+    amount BIGINT NOT NULL CONSTRAINT positive_hold_amount CHECK (amount > 0),
+    currency VARCHAR(3) NOT NULL,
+    status VARCHAR(16) DEFAULT 'active' NOT NULL,
+    captured_amount BIGINT DEFAULT 0 NOT NULL,
+    expires_at   TIMESTAMPTZ NOT NULL,
+    updated_at   TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+    created_at   TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+    CONSTRAINT fk_wallet
+      FOREIGN KEY(wallet_id)
+        REFERENCES wallet(id)
+);
+
+CREATE INDEX idx_hold_wallet_active ON hold (wallet_id) WHERE status = 'active';
+CREATE INDEX idx_hold_expires_at_active ON hold (expires_at) WHERE status = 'active';
+
+CREATE TRIGGER update_hold_updated_at
+    BEFORE UPDATE ON hold
+    FOR EACH ROW
+EXECUTE PROCEDURE update_updated_at_trigger();
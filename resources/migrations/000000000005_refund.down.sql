@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_transaction_reversal_of;
+
+ALTER TABLE transaction
+    DROP COLUMN reversal_of;
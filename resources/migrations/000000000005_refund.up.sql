@@ -0,0 +1,7 @@
+ALTER TABLE transaction
+    ADD COLUMN reversal_of UUID NULL,
+    ADD CONSTRAINT fk_reversal_of
+      FOREIGN KEY(wallet_id, reversal_of)
+        REFERENCES transaction(wallet_id, transaction_id);
+
+CREATE INDEX idx_transaction_reversal_of ON transaction(wallet_id, reversal_of) WHERE reversal_of IS NOT NULL;
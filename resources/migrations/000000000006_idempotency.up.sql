@@ -0,0 +1,2 @@
+-- idempotency keys are looked up without wallet id
+CREATE INDEX idx_transaction_transaction_id ON transaction(transaction_id);
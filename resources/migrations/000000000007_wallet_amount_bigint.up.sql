@@ -0,0 +1,2 @@
+ALTER TABLE wallet
+    ALTER COLUMN amount TYPE BIGINT;
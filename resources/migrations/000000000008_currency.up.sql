@@ -0,0 +1,26 @@
+CREATE TABLE currency (
+    -- ISO 4217 alphabetic code, in lowercase
+    code VARCHAR(3) PRIMARY KEY,
+    -- ISO 4217 numeric code
+    numeric_code INTEGER NOT NULL UNIQUE,
+    -- number of digits after the decimal separator of the minor unit
+    exponent INTEGER NOT NULL CONSTRAINT valid_exponent CHECK (exponent BETWEEN 0 AND 4),
+    enabled BOOLEAN DEFAULT TRUE NOT NULL,
+    updated_at   TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+    created_at   TIMESTAMPTZ DEFAULT NOW() NOT NULL
+);
+
+CREATE TRIGGER update_currency_updated_at
+    BEFORE UPDATE ON currency
+    FOR EACH ROW
+EXECUTE PROCEDURE update_updated_at_trigger();
+
+INSERT INTO currency (code, numeric_code, exponent, enabled) VALUES
+    ('usd', 840, 2, TRUE),
+    ('eur', 978, 2, TRUE),
+    ('uah', 980, 2, TRUE),
+    ('jpy', 392, 0, TRUE),
+    ('gbp', 826, 2, FALSE),
+    ('chf', 756, 2, FALSE),
+    ('pln', 985, 2, FALSE),
+    ('kwd', 414, 3, FALSE);
@@ -0,0 +1,5 @@
+-- original case of currencies is not restored
+ALTER TABLE ledger_account DROP CONSTRAINT IF EXISTS canonical_currency;
+ALTER TABLE hold DROP CONSTRAINT IF EXISTS canonical_currency;
+ALTER TABLE transaction DROP CONSTRAINT IF EXISTS canonical_currency;
+ALTER TABLE wallet DROP CONSTRAINT IF EXISTS canonical_currency;
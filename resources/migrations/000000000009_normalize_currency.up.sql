@@ -0,0 +1,29 @@
+-- system accounts created for currency in other case are merged into account of canonical currency
+INSERT INTO ledger_account (code, currency)
+SELECT DISTINCT code, LOWER(TRIM(currency))
+FROM ledger_account
+WHERE code IS NOT NULL AND currency <> LOWER(TRIM(currency))
+ON CONFLICT (code, currency) DO NOTHING;
+
+UPDATE posting
+SET ledger_account_id = canonical.id
+FROM ledger_account duplicate, ledger_account canonical
+WHERE posting.ledger_account_id = duplicate.id
+    AND duplicate.code IS NOT NULL
+    AND duplicate.currency <> LOWER(TRIM(duplicate.currency))
+    AND canonical.code = duplicate.code
+    AND canonical.currency = LOWER(TRIM(duplicate.currency));
+
+DELETE FROM ledger_account
+WHERE code IS NOT NULL AND currency <> LOWER(TRIM(currency));
+
+UPDATE ledger_account SET currency = LOWER(TRIM(currency)) WHERE currency <> LOWER(TRIM(currency));
+UPDATE wallet SET currency = LOWER(TRIM(currency)) WHERE currency <> LOWER(TRIM(currency));
+UPDATE transaction SET currency = LOWER(TRIM(currency)) WHERE currency <> LOWER(TRIM(currency));
+UPDATE hold SET currency = LOWER(TRIM(currency)) WHERE currency <> LOWER(TRIM(currency));
+
+-- new rows are checked, rows that were invalid before normalization are left for manual review
+ALTER TABLE wallet ADD CONSTRAINT canonical_currency CHECK (currency ~ '^[a-z]{3}$') NOT VALID;
+ALTER TABLE transaction ADD CONSTRAINT canonical_currency CHECK (currency ~ '^[a-z]{3}$') NOT VALID;
+ALTER TABLE hold ADD CONSTRAINT canonical_currency CHECK (currency ~ '^[a-z]{3}$') NOT VALID;
+ALTER TABLE ledger_account ADD CONSTRAINT canonical_currency CHECK (currency ~ '^[a-z]{3}$') NOT VALID;
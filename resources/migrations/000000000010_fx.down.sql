@@ -0,0 +1,9 @@
+ALTER TABLE transaction
+    DROP CONSTRAINT complete_conversion,
+    DROP COLUMN fx_quote_id,
+    DROP COLUMN fx_spread_bps,
+    DROP COLUMN fx_rate,
+    DROP COLUMN fx_currency,
+    DROP COLUMN fx_amount;
+
+DROP TABLE fx_quote;
@@ -0,0 +1,25 @@
+CREATE TABLE fx_quote (
+    id UUID PRIMARY KEY,
+    -- currency of transaction amount
+    from_currency VARCHAR(3) NOT NULL CONSTRAINT canonical_from_currency CHECK (from_currency ~ '^[a-z]{3}$'),
+    -- currency of wallet amount is converted to
+    to_currency VARCHAR(3) NOT NULL CONSTRAINT canonical_to_currency CHECK (to_currency ~ '^[a-z]{3}$'),
+    -- price of one major unit of from_currency in to_currency, scaled by 10^9
+    rate BIGINT NOT NULL CONSTRAINT positive_rate CHECK (rate > 0),
+    spread_bps INTEGER NOT NULL CONSTRAINT valid_spread CHECK (spread_bps >= 0 AND spread_bps < 10000),
+    expires_at   TIMESTAMPTZ NOT NULL,
+    created_at   TIMESTAMPTZ DEFAULT NOW() NOT NULL
+);
+
+-- amount and currency of converted transactions as they were requested, with applied rate and spread
+ALTER TABLE transaction
+    ADD COLUMN fx_amount BIGINT,
+    ADD COLUMN fx_currency VARCHAR(3),
+    ADD COLUMN fx_rate BIGINT,
+    ADD COLUMN fx_spread_bps INTEGER,
+    ADD COLUMN fx_quote_id UUID REFERENCES fx_quote(id),
+    ADD CONSTRAINT complete_conversion CHECK (
+        (fx_amount IS NULL) = (fx_currency IS NULL) AND
+        (fx_amount IS NULL) = (fx_rate IS NULL) AND
+        (fx_amount IS NULL) = (fx_spread_bps IS NULL)
+    );
@@ -0,0 +1,7 @@
+-- pockets become separate wallets of the account
+DROP INDEX idx_wallet_pocket;
+
+ALTER TABLE wallet
+    DROP CONSTRAINT pocket_of_multi_currency,
+    DROP COLUMN multi_currency,
+    DROP COLUMN parent_id;
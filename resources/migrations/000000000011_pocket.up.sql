@@ -0,0 +1,7 @@
+-- multi-currency wallet keeps balances of other currencies in pockets, child wallets of the same account
+ALTER TABLE wallet
+    ADD COLUMN parent_id INTEGER REFERENCES wallet(id),
+    ADD COLUMN multi_currency BOOLEAN DEFAULT FALSE NOT NULL,
+    ADD CONSTRAINT pocket_of_multi_currency CHECK (parent_id IS NULL OR NOT multi_currency);
+
+CREATE UNIQUE INDEX idx_wallet_pocket ON wallet (parent_id, currency) WHERE parent_id IS NOT NULL;
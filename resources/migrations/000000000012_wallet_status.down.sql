@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS wallet_status_change;
+
+ALTER TABLE wallet
+    DROP COLUMN IF EXISTS block_credits,
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1,21 @@
+-- frozen wallet rejects debits, and credits too when block_credits is set, closed wallet rejects all transactions
+ALTER TABLE wallet
+    ADD COLUMN status VARCHAR(16) DEFAULT 'active' NOT NULL
+        CONSTRAINT valid_wallet_status CHECK (status IN ('active', 'frozen', 'closed')),
+    ADD COLUMN block_credits BOOLEAN DEFAULT FALSE NOT NULL;
+
+-- audit of wallet status changes, who changed the status and why
+CREATE TABLE wallet_status_change (
+    id BIGSERIAL PRIMARY KEY,
+    wallet_id INTEGER NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    block_credits BOOLEAN NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    reason VARCHAR(1024) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+    CONSTRAINT fk_wallet
+      FOREIGN KEY(wallet_id)
+        REFERENCES wallet(id)
+);
+
+CREATE INDEX idx_wallet_status_change_wallet ON wallet_status_change (wallet_id, created_at);
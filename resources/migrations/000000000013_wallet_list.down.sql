@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_wallet_account_currency_id;
+DROP INDEX IF EXISTS idx_wallet_account_id;
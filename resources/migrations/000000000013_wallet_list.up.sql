@@ -0,0 +1,3 @@
+-- keyset pagination of account wallets, pockets are listed with their wallets
+CREATE INDEX idx_wallet_account_id ON wallet (account, id) WHERE parent_id IS NULL;
+CREATE INDEX idx_wallet_account_currency_id ON wallet (account, currency, id);
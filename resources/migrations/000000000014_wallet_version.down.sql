@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS notify_wallet_changed ON wallet;
+DROP FUNCTION IF EXISTS notify_wallet_changed_trigger();
+DROP TRIGGER IF EXISTS increment_wallet_version ON wallet;
+DROP FUNCTION IF EXISTS increment_wallet_version_trigger();
+
+ALTER TABLE wallet DROP COLUMN IF EXISTS version;
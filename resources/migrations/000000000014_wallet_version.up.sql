@@ -0,0 +1,29 @@
+-- version increases with every change of wallet row, watchers resume from the last version they have seen
+ALTER TABLE wallet ADD COLUMN version BIGINT DEFAULT 0 NOT NULL;
+
+CREATE FUNCTION increment_wallet_version_trigger()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.version = OLD.version + 1;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER increment_wallet_version
+    BEFORE UPDATE ON wallet
+    FOR EACH ROW
+EXECUTE PROCEDURE increment_wallet_version_trigger();
+
+-- listeners of wallet_changed channel get notification when transaction that changed the wallet commits
+CREATE FUNCTION notify_wallet_changed_trigger()
+RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify('wallet_changed', json_build_object('id', NEW.id, 'parent_id', NEW.parent_id, 'version', NEW.version)::text);
+    RETURN NULL;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER notify_wallet_changed
+    AFTER UPDATE ON wallet
+    FOR EACH ROW
+EXECUTE PROCEDURE notify_wallet_changed_trigger();
@@ -0,0 +1,11 @@
+-- Idempotency key is claimed by the first transaction that uses it, transactions of different wallets
+-- can't share the key even when they are processed concurrently
+CREATE TABLE idempotency_key (
+    transaction_id UUID PRIMARY KEY,
+    created_at   TIMESTAMPTZ DEFAULT NOW() NOT NULL
+);
+
+INSERT INTO idempotency_key (transaction_id, created_at)
+SELECT transaction_id, MIN(created_at)
+FROM transaction
+GROUP BY transaction_id;
@@ -0,0 +1,3 @@
+DELETE FROM idempotency_key
+WHERE transaction_id IN (SELECT id FROM hold)
+  AND transaction_id NOT IN (SELECT transaction_id FROM transaction);
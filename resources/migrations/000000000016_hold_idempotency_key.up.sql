@@ -0,0 +1,4 @@
+-- hold id is idempotency key of its capture, transactions can't take keys of existing holds
+INSERT INTO idempotency_key (transaction_id, created_at)
+SELECT id, created_at FROM hold
+ON CONFLICT (transaction_id) DO NOTHING;
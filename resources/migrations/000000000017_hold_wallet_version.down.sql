@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS touch_hold_wallet ON hold;
+DROP FUNCTION IF EXISTS touch_hold_wallet_trigger();
@@ -0,0 +1,14 @@
+-- held funds are part of wallet state, so creating or closing a hold touches its wallet row:
+-- version of the wallet is incremented and watchers are notified by wallet triggers
+CREATE FUNCTION touch_hold_wallet_trigger()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE wallet SET version = version WHERE id = NEW.wallet_id;
+    RETURN NULL;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER touch_hold_wallet
+    AFTER INSERT OR UPDATE OF status ON hold
+    FOR EACH ROW
+EXECUTE PROCEDURE touch_hold_wallet_trigger();
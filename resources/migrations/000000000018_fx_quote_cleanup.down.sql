@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_fx_quote_expires_at;
+DROP INDEX IF EXISTS idx_transaction_fx_quote_id;
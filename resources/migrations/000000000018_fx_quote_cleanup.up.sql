@@ -0,0 +1,3 @@
+-- expired quotes are deleted unless a transaction references them
+CREATE INDEX idx_transaction_fx_quote_id ON transaction (fx_quote_id) WHERE fx_quote_id IS NOT NULL;
+CREATE INDEX idx_fx_quote_expires_at ON fx_quote (expires_at);
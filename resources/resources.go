@@ -0,0 +1,9 @@
+// Package resources embeds files the server binary needs at runtime
+package resources
+
+import "embed"
+
+// Migrations are database schema migrations in golang-migrate format
+//
+//go:embed migrations/*.sql
+var Migrations embed.FS